@@ -0,0 +1,32 @@
+// Package backoff computes the waits between retries of failing operations.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Exponential returns `min` doubled `n`-1 times, capped at `max`.
+func Exponential(min, max time.Duration, n int) time.Duration {
+	d := min
+	for i := 1; i < n && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+// Jittered returns a random duration between half and all of `d` so concurrent retries spread out.
+func Jittered(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Wait returns the jittered exponential wait before retrying after `n` attempts.
+func Wait(min, max time.Duration, n int) time.Duration {
+	return Jittered(Exponential(min, max, n))
+}
@@ -0,0 +1,28 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	for _, tc := range []struct {
+		n   int
+		max time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, 5 * time.Second},
+	} {
+		for i := 0; i < 100; i++ {
+			if got := Wait(time.Second, 5*time.Second, tc.n); got < tc.max/2 || got > tc.max {
+				t.Errorf("Wait(1s, 5s, %d) = %v, want between %v and %v", tc.n, got, tc.max/2, tc.max)
+			}
+		}
+	}
+	if got := Wait(0, 0, 3); got != 0 {
+		t.Errorf("Wait(0, 0, 3) = %v, want 0", got)
+	}
+}
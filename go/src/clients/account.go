@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountData is the Lending Pool's own view of a user's account. Unlike `LoanAmount`, it uses
+// AAVE's price oracle rather than Chainlink feeds queried by the bot.
+type AccountData struct {
+	// The totals are in wei.
+	TotalCollateralETH  *big.Int
+	TotalDebtETH        *big.Int
+	AvailableBorrowsETH *big.Int
+	// LiquidationThreshold and LTV are in units of 1/10000.
+	LiquidationThreshold uint16
+	LTV                  uint16
+	// HealthFactor is nil if the account has no debt.
+	HealthFactor *big.Rat
+}
+
+// AccountData retrieves the Lending Pool's account data for the given user.
+func (c *Client) AccountData(ctx context.Context, u common.Address) (*AccountData, error) {
+	data, err := c.lp.GetUserAccountData(&bind.CallOpts{Context: ctx}, u)
+	if err != nil {
+		return nil, fmt.Errorf("getting account data for %v: %w", u, err)
+	}
+	return newAccountData(data), nil
+}
+
+// userAccountData is the result of the Lending Pool's `getUserAccountData`.
+type userAccountData = struct {
+	TotalCollateralETH          *big.Int
+	TotalDebtETH                *big.Int
+	AvailableBorrowsETH         *big.Int
+	CurrentLiquidationThreshold *big.Int
+	Ltv                         *big.Int
+	HealthFactor                *big.Int
+}
+
+func newAccountData(data userAccountData) *AccountData {
+	ret := &AccountData{
+		TotalCollateralETH:   data.TotalCollateralETH,
+		TotalDebtETH:         data.TotalDebtETH,
+		AvailableBorrowsETH:  data.AvailableBorrowsETH,
+		LiquidationThreshold: uint16(data.CurrentLiquidationThreshold.Uint64()),
+		LTV:                  uint16(data.Ltv.Uint64()),
+	}
+	// The health factor is a fixed point number with 18 decimals, or the maximum uint256 without
+	// debt.
+	if data.TotalDebtETH.Sign() > 0 {
+		ret.HealthFactor = new(big.Rat).SetFrac(data.HealthFactor, big.NewInt(1e18))
+	}
+	return ret
+}
+
+// NoCollateralRatio is the ratio of an account with debt but no collateral, such as after its
+// collateral was liquidated. It's above any threshold, but the loan can't be repaid from collateral.
+const NoCollateralRatio = math.MaxUint16
+
+// Ratio returns the ratio of debt to collateral in units of 1/10000, comparable to
+// `LoanAmount.Ratio`. It's `NoCollateralRatio` if the account has debt but no collateral.
+func (a *AccountData) Ratio() uint16 {
+	if a.TotalCollateralETH.Sign() == 0 {
+		if a.TotalDebtETH.Sign() > 0 {
+			return NoCollateralRatio
+		}
+		return 0
+	}
+	ratioF, _ := new(big.Rat).SetFrac(a.TotalDebtETH, a.TotalCollateralETH).Float64()
+	return uint16(ratioF * 10000)
+}
+
+// Divergence returns the relative difference between the ratio reported by the Lending Pool and the
+// ratio computed from the bot's own price feeds.
+func (a *AccountData) Divergence(l *LoanAmount) float64 {
+	if a.TotalCollateralETH.Sign() == 0 {
+		return 0
+	}
+	aave, _ := new(big.Rat).SetFrac(a.TotalDebtETH, a.TotalCollateralETH).Float64()
+	feeds, _ := l.CurrentRatio.Float64()
+	if aave == 0 {
+		return feeds
+	}
+	return math.Abs(feeds-aave) / aave
+}
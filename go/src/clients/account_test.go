@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDivergence(t *testing.T) {
+	account := &AccountData{TotalCollateralETH: big.NewInt(100), TotalDebtETH: big.NewInt(50)}
+	for _, tc := range []struct {
+		ratio *big.Rat
+		want  float64
+	}{
+		{big.NewRat(1, 2), 0},
+		{big.NewRat(55, 100), 0.1},
+		{big.NewRat(45, 100), 0.1},
+	} {
+		got := account.Divergence(&LoanAmount{CurrentRatio: tc.ratio})
+		if got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("Divergence(%v) = %v, want %v", tc.ratio, got, tc.want)
+		}
+	}
+}
+
+func TestRatio(t *testing.T) {
+	for _, tc := range []struct {
+		collateral, debt int64
+		want             uint16
+	}{
+		{100, 50, 5000},
+		{100, 0, 0},
+		{0, 0, 0},
+		{0, 50, NoCollateralRatio},
+	} {
+		account := &AccountData{TotalCollateralETH: big.NewInt(tc.collateral), TotalDebtETH: big.NewInt(tc.debt)}
+		if got := account.Ratio(); got != tc.want {
+			t.Errorf("Ratio() with collateral %d and debt %d = %d, want %d", tc.collateral, tc.debt, got, tc.want)
+		}
+	}
+}
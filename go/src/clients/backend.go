@@ -0,0 +1,25 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Backend is the chain access of a `Client`. `*ethclient.Client` implements it, and so can a
+// simulated chain, a pool of nodes or a proxy recording calls.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+
+	// BalanceAt serves the funds watcher.
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	// BlockNumber serves the transaction managers counting confirmations.
+	BlockNumber(ctx context.Context) (uint64, error)
+	// SubscribeNewHead serves the monitor.
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
@@ -0,0 +1,348 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"aaveoracle"
+	"aggregator"
+	"erc20"
+	"lendingpool"
+	"multicall"
+	"txmanager"
+)
+
+var (
+	// The ABIs of the contracts read in batches.
+	erc20ABI, lendingPoolABI, oracleABI, aggregatorABI abi.ABI
+)
+
+func init() {
+	for _, a := range []struct {
+		dst  *abi.ABI
+		json string
+	}{
+		{&erc20ABI, erc20.Erc20ABI},
+		{&lendingPoolABI, lendingpool.LendingpoolABI},
+		{&oracleABI, aaveoracle.AaveoracleABI},
+		{&aggregatorABI, aggregator.AggregatorABI},
+	} {
+		var err error
+		if *a.dst, err = abi.JSON(strings.NewReader(a.json)); err != nil {
+			log.Fatalf("Error parsing ABI: %v", err)
+		}
+	}
+}
+
+// LoanState is a loan as of a block: the Lending Pool's account data, which decides whether the
+// loan is repaid, and the amounts computed from the bot's price feeds, which cross-check it.
+type LoanState struct {
+	*Loan
+	// Block is the number of the block the state was read at, or 0 if it was read over several
+	// calls.
+	Block uint64
+	// Account is nil if `Err` is set.
+	Account *AccountData
+	Err     error
+	// Amount is nil if `AmountErr` is set.
+	Amount    *LoanAmount
+	AmountErr error
+}
+
+// ReadLoan reads the state of the loan one call at a time.
+func (c *Client) ReadLoan(ctx context.Context, loan *Loan) *LoanState {
+	state := &LoanState{Loan: loan}
+	state.Account, state.Err = c.AccountData(ctx, loan.User)
+	if state.Err == nil {
+		state.Amount, state.AmountErr = loan.Data(ctx, c)
+	}
+	return state
+}
+
+// ReadLoans reads the states of the loans at the same block through the client's
+// `MulticallAddress`, in calls of at most `MulticallBatchSize` reads, keyed by user. Only the feeds
+// of new assets, the decimals of new aggregators, the rounds of feeds the oracle switched to and the
+// rounds preceding unseen Chainlink rounds are read separately, as `PriceOf` does. The error is for
+// the batch as a whole; each state has its own.
+func (c *Client) ReadLoans(ctx context.Context, loans []*Loan) (map[common.Address]*LoanState, error) {
+	ret := make(map[common.Address]*LoanState)
+	if len(loans) == 0 {
+		return ret, nil
+	}
+	b := &batch{}
+
+	// Each asset is priced once across loans.
+	prices := make(map[common.Address]*batchPrice)
+	_, oracleAddr, oracleErr := c.priceOracle(ctx)
+	for _, loan := range loans {
+		for _, rs := range [][]*Reserve{loan.Collateral, loan.Debt} {
+			for _, r := range rs {
+				if _, ok := prices[r.Asset]; ok || r.Asset == c.WETH9Address() {
+					continue
+				}
+				p := &batchPrice{oracleErr: oracleErr}
+				prices[r.Asset] = p
+				if oracleErr == nil {
+					p.oracle = b.add(oracleAddr, &oracleABI, "getAssetPrice", &p.oraclePrice, r.Asset)
+					p.sourceCall = b.add(oracleAddr, &oracleABI, "getSourceOfAsset", &p.source, r.Asset)
+				}
+				// The feed the oracle was last seen reading is batched, and checked against its
+				// current source once the batch is made.
+				if v, ok := c.sources.Load(r.Asset); ok {
+					p.feed = v.(common.Address)
+					p.hasFeed = p.feed != (common.Address{})
+				} else {
+					p.feed, p.hasFeed, p.feedErr = c.PriceFeed(ctx, r.Asset)
+				}
+				if !p.hasFeed {
+					continue
+				}
+				if v, ok := c.feedDecimals.Load(p.feed); ok {
+					p.decimals = v.(uint8)
+				} else {
+					p.decimalsCall = b.add(p.feed, &aggregatorABI, "decimals", &p.decimals)
+				}
+				p.round = b.add(p.feed, &aggregatorABI, "latestRoundData", &p.data)
+			}
+		}
+	}
+
+	type balance struct {
+		call   *batchCall
+		amount *big.Int
+	}
+	type loanCalls struct {
+		account  *batchCall
+		data     userAccountData
+		balances map[common.Address]*balance
+	}
+	users := make([]*loanCalls, len(loans))
+	for i, loan := range loans {
+		lc := &loanCalls{balances: make(map[common.Address]*balance)}
+		lc.account = b.add(c.LendingPoolAddress(), &lendingPoolABI, "getUserAccountData", &lc.data, loan.User)
+		var tokens []common.Address
+		for _, r := range loan.Collateral {
+			tokens = append(tokens, r.AToken)
+		}
+		for _, r := range loan.Debt {
+			tokens = append(tokens, r.StableDebt, r.VariableDebt)
+		}
+		for _, token := range tokens {
+			if _, ok := lc.balances[token]; ok {
+				continue
+			}
+			bal := &balance{}
+			bal.call = b.add(token, &erc20ABI, "balanceOf", &bal.amount, loan.User)
+			lc.balances[token] = bal
+		}
+		users[i] = lc
+	}
+
+	block, err := c.multicall(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	for asset, p := range prices {
+		p.resolve(ctx, c, asset)
+	}
+	priceOf := func(r *Reserve) (*big.Int, *big.Int, error) {
+		if r.Asset == c.WETH9Address() {
+			return big.NewInt(1), big.NewInt(1), nil
+		}
+		p := prices[r.Asset]
+		return p.price, p.factor, p.err
+	}
+	for i, loan := range loans {
+		lc := users[i]
+		state := &LoanState{Loan: loan, Block: block}
+		ret[loan.User] = state
+		if lc.account.err != nil {
+			state.Err = fmt.Errorf("getting account data for %v: %w", loan.User, lc.account.err)
+			continue
+		}
+		state.Account = newAccountData(lc.data)
+		state.Amount, state.AmountErr = loan.amounts(func(token common.Address) (*big.Int, error) {
+			bal := lc.balances[token]
+			if bal.call.err != nil {
+				return nil, fmt.Errorf("querying balance of token %v for %v: %w", token, loan.User, bal.call.err)
+			}
+			return bal.amount, nil
+		}, priceOf)
+	}
+	return ret, nil
+}
+
+// batchPrice is the price of an asset read in a batch.
+type batchPrice struct {
+	oracle      *batchCall
+	oraclePrice *big.Int
+	oracleErr   error
+
+	// sourceCall reads the oracle's current `source` for the asset.
+	sourceCall *batchCall
+	source     common.Address
+
+	// feed is the Chainlink feed read in the batch if `hasFeed`, or `feedErr` is the error looking it
+	// up.
+	feed         common.Address
+	hasFeed      bool
+	feedErr      error
+	decimalsCall *batchCall
+	decimals     uint8
+	round        *batchCall
+	data         round
+
+	// price, factor and err are the resolved price, as returned by `PriceOf`.
+	price, factor *big.Int
+	err           error
+}
+
+// resolve picks the price of the asset from the results of the batch, as `PriceOf` does.
+func (p *batchPrice) resolve(ctx context.Context, c *Client, asset common.Address) {
+	addr := asset.Hex()
+	price, err := p.oraclePrice, p.oracleErr
+	if err == nil {
+		if p.oracle.err != nil {
+			err = fmt.Errorf("getting oracle price for %v: %w", asset, p.oracle.err)
+		} else {
+			price, err = checkOraclePrice(asset, price)
+		}
+	}
+	feed, hasFeed, feedErr := p.feed, p.hasFeed, p.feedErr
+	batched := true
+	if p.sourceCall != nil && p.sourceCall.err == nil && (feedErr != nil || p.source != feed) {
+		// The oracle reads another feed than the one batched, whose rounds are read separately.
+		c.sources.Store(asset, p.source)
+		feed, hasFeed, feedErr = p.source, p.source != (common.Address{}), nil
+		batched = false
+	}
+	if feedErr == nil && !hasFeed {
+		p.price, p.factor, p.err = price, oracleFactor, err
+		return
+	}
+	var feedPrice, feedFactor *big.Int
+	switch {
+	case feedErr != nil:
+	case batched:
+		feedPrice, feedFactor, feedErr = p.feedPrice(ctx, c, addr)
+	default:
+		feedPrice, feedFactor, feedErr = c.chainlinkPrice(ctx, addr, feed)
+	}
+	if err == nil && c.confirmRound(addr, price, feedErr) {
+		p.price, p.factor, p.err = price, oracleFactor, nil
+		return
+	}
+	p.price, p.factor, p.err = crossCheck(addr, price, err, feedPrice, feedFactor, feedErr)
+}
+
+// feedPrice returns the Chainlink price of the asset at hex address `addr` from the results of the
+// batch, as `chainlinkPrice` does.
+func (p *batchPrice) feedPrice(ctx context.Context, c *Client, addr string) (*big.Int, *big.Int, error) {
+	agg, err := c.Aggregator(p.feed)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting aggregator for %s: %w", addr, err)
+	}
+	if p.decimalsCall != nil {
+		if err := p.decimalsCall.err; err != nil {
+			return nil, nil, fmt.Errorf("getting decimals for %s: %w", addr, err)
+		}
+		c.feedDecimals.Store(p.feed, p.decimals)
+	}
+	if err := p.round.err; err != nil {
+		return nil, nil, fmt.Errorf("getting price data for %s: %w", addr, err)
+	}
+	return c.roundPrice(ctx, addr, p.feed, agg, p.decimals, p.data)
+}
+
+// batch collects contract calls to make through the Multicall contract.
+type batch struct {
+	calls   []multicall.Multicall2Call
+	pending []*batchCall
+}
+
+// batchCall is a call of a batch. Its output is unpacked where it was added with, or `err` is set.
+type batchCall struct {
+	contract *abi.ABI
+	method   string
+	out      interface{}
+	err      error
+}
+
+// add adds a call of `method` of the contract at `to` with `args`, whose output is unpacked into
+// `out` once the batch is made.
+func (b *batch) add(to common.Address, contract *abi.ABI, method string, out interface{},
+	args ...interface{}) *batchCall {
+	call := &batchCall{contract: contract, method: method, out: out}
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		call.err = fmt.Errorf("packing %s call: %w", method, err)
+		return call
+	}
+	b.calls = append(b.calls, multicall.Multicall2Call{Target: to, CallData: data})
+	b.pending = append(b.pending, call)
+	return call
+}
+
+// multicall makes the calls of the batch through the client's `MulticallAddress` and returns the
+// number of the block they were made at. The calls are split into chunks of `MulticallBatchSize`,
+// which are made at the block of the first. Calls that fail don't fail the others.
+func (c *Client) multicall(ctx context.Context, b *batch) (uint64, error) {
+	addr := c.MulticallAddress()
+	if addr == (common.Address{}) {
+		return 0, errors.New("no multicall contract configured")
+	}
+	caller, err := multicall.NewMulticallCaller(addr, c.eth)
+	if err != nil {
+		return 0, fmt.Errorf("multicall client for %v: %w", addr, err)
+	}
+	size := c.MulticallBatchSize()
+	if size <= 0 {
+		size = len(b.calls)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	var block uint64
+	for start := 0; start < len(b.calls); start += size {
+		end := start + size
+		if end > len(b.calls) {
+			end = len(b.calls)
+		}
+		var out []interface{}
+		if err := (&multicall.MulticallCallerRaw{Contract: caller}).Call(opts, &out, "tryBlockAndAggregate", false,
+			b.calls[start:end]); err != nil {
+			return 0, fmt.Errorf("making calls %d to %d of %d through multicall %v: %w", start, end, len(b.calls),
+				addr.Hex(), txmanager.DecodeRevert(err))
+		}
+		if opts.BlockNumber == nil {
+			// The remaining chunks are read at the same block as the first.
+			opts.BlockNumber = out[0].(*big.Int)
+			block = opts.BlockNumber.Uint64()
+		}
+		results := *abi.ConvertType(out[2], new([]multicall.Multicall2Result)).(*[]multicall.Multicall2Result)
+		if len(results) != end-start {
+			return 0, fmt.Errorf("multicall %v returned %d results for %d calls", addr.Hex(), len(results),
+				end-start)
+		}
+		for i, result := range results {
+			call := b.pending[start+i]
+			if !result.Success {
+				call.err = &txmanager.RevertError{Err: vm.ErrExecutionReverted}
+				if reason, err := abi.UnpackRevert(result.ReturnData); err == nil {
+					call.err = &txmanager.RevertError{Reason: reason, Err: vm.ErrExecutionReverted}
+				}
+				continue
+			}
+			call.err = call.contract.UnpackIntoInterface(call.out, call.method, result.ReturnData)
+		}
+	}
+	return block, nil
+}
@@ -0,0 +1,199 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"aggregator"
+	"env"
+	"simulated"
+)
+
+// counting is a backend counting the calls made through it.
+type counting struct {
+	Backend
+	calls int32
+}
+
+func (c *counting) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte,
+	error) {
+	atomic.AddInt32(&c.calls, 1)
+	return c.Backend.CallContract(ctx, call, blockNumber)
+}
+
+// batchSize overrides the Multicall batch size of parameters.
+type batchSize struct {
+	env.Params
+	size int
+}
+
+func (b *batchSize) MulticallBatchSize() int {
+	return b.size
+}
+
+func TestReadLoans(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+	backend := &counting{Backend: chain.Backend()}
+	c, err := NewClientWithBackend(chain.Params, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loan, err := c.Loan(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Lending Pool has no account data for the other user.
+	other := &Loan{User: common.HexToAddress("0x01"), Collateral: loan.Collateral, Debt: loan.Debt}
+
+	want := c.ReadLoan(ctx, loan)
+	if want.Err != nil || want.AmountErr != nil {
+		t.Fatalf("ReadLoan(...) = %v, %v, want no errors", want.Err, want.AmountErr)
+	}
+	// The first batch looks up the price oracle, the feed of Dai and its decimals.
+	if _, err := c.ReadLoans(ctx, []*Loan{loan, other}); err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	atomic.StoreInt32(&backend.calls, 0)
+	states, err := c.ReadLoans(ctx, []*Loan{loan, other})
+	if err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	if n := atomic.LoadInt32(&backend.calls); n != 1 {
+		t.Errorf("ReadLoans(...) made %d calls, want 1", n)
+	}
+
+	got := states[loan.User]
+	if got == nil || got.Err != nil || got.AmountErr != nil {
+		t.Fatalf("state of %v = %+v, want no errors", loan.User, got)
+	}
+	head, err := chain.Backend().BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Block != head {
+		t.Errorf("Block = %d, want the head %d", got.Block, head)
+	}
+	if got.Account.Ratio() != want.Account.Ratio() || got.Account.HealthFactor.Cmp(want.Account.HealthFactor) != 0 {
+		t.Errorf("Account = %+v, want %+v", got.Account, want.Account)
+	}
+	if got.Amount.CollateralETH.Cmp(want.Amount.CollateralETH) != 0 ||
+		got.Amount.DebtETH.Cmp(want.Amount.DebtETH) != 0 {
+		t.Errorf("Amount = %v/%v ETH, want %v/%v ETH", got.Amount.CollateralETH, got.Amount.DebtETH,
+			want.Amount.CollateralETH, want.Amount.DebtETH)
+	}
+	if state := states[other.User]; state == nil || state.Err == nil {
+		t.Errorf("state of %v without account data = %+v, want an error", other.User, state)
+	}
+
+	// Batched prices are checked like single ones, including those of a feed the oracle switches
+	// to.
+	stale := big.NewInt(time.Now().Add(-c.FeedHeartbeat() - time.Hour).Unix())
+	if err := chain.DAI.Feed.Returns(ctx, "latestRoundData", nil, big.NewInt(2), simulated.DAIPrice, stale,
+		stale, big.NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+	if states, err = c.ReadLoans(ctx, []*Loan{loan}); err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	if state := states[loan.User]; state.Err != nil || state.AmountErr == nil {
+		t.Errorf("state with a stale price = %v, %v, want _, an error", state.Err, state.AmountErr)
+	}
+	feed, err := chain.DeployMock(ctx, aggregator.AggregatorABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := big.NewInt(time.Now().Unix())
+	for _, r := range []struct {
+		method string
+		out    []interface{}
+	}{
+		{"decimals", []interface{}{uint8(18)}},
+		{"latestRoundData", []interface{}{big.NewInt(1), big.NewInt(-1), fresh, fresh, big.NewInt(1)}},
+	} {
+		if err := feed.Returns(ctx, r.method, nil, r.out...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := chain.Market.Oracle.Returns(ctx, "getSourceOfAsset", []interface{}{chain.DAI.Asset.Address},
+		feed.Address); err != nil {
+		t.Fatal(err)
+	}
+	if states, err = c.ReadLoans(ctx, []*Loan{loan}); err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	if state := states[loan.User]; state.Err != nil || state.AmountErr == nil {
+		t.Errorf("state with a negative price from a new feed = %v, %v, want _, an error", state.Err,
+			state.AmountErr)
+	}
+}
+
+func TestReadLoansInBatches(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+	backend := &counting{Backend: chain.Backend()}
+	// Each loan takes a few reads, so 3 per call splits them over several calls.
+	c, err := NewClientWithBackend(&batchSize{Params: chain.Params, size: 3}, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loan, err := c.Loan(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := c.ReadLoan(ctx, loan)
+	if want.Err != nil || want.AmountErr != nil {
+		t.Fatalf("ReadLoan(...) = %v, %v, want no errors", want.Err, want.AmountErr)
+	}
+	loans := []*Loan{loan}
+	for i := 1; i <= 3; i++ {
+		loans = append(loans, &Loan{User: common.BigToAddress(big.NewInt(int64(i))), Collateral: loan.Collateral,
+			Debt: loan.Debt})
+	}
+	if _, err := c.ReadLoans(ctx, loans); err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	atomic.StoreInt32(&backend.calls, 0)
+	states, err := c.ReadLoans(ctx, loans)
+	if err != nil {
+		t.Fatalf("ReadLoans(...) = _, %v, want _, nil", err)
+	}
+	if n := atomic.LoadInt32(&backend.calls); n < 2 {
+		t.Errorf("ReadLoans(...) made %d calls, want several", n)
+	}
+	head, err := chain.Backend().BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := states[loan.User]
+	if got == nil || got.Err != nil || got.AmountErr != nil {
+		t.Fatalf("state of %v = %+v, want no errors", loan.User, got)
+	}
+	if got.Account.HealthFactor.Cmp(want.Account.HealthFactor) != 0 ||
+		got.Amount.CollateralETH.Cmp(want.Amount.CollateralETH) != 0 ||
+		got.Amount.DebtETH.Cmp(want.Amount.DebtETH) != 0 {
+		t.Errorf("state = %+v/%+v, want %+v/%+v", got.Account, got.Amount, want.Account, want.Amount)
+	}
+	for _, l := range loans {
+		if state := states[l.User]; state == nil || state.Block != head {
+			t.Errorf("state of %v = %+v, want one at the head %d", l.User, state, head)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"aggregator"
+)
+
+// heartbeatGrace is how late past its heartbeat a Chainlink round may be before it's stale, to
+// allow for the time the update transaction takes to be mined.
+const heartbeatGrace = 10 * time.Minute
+
+// RoundError reports a Chainlink round that can't be trusted: stale, incomplete, not positive or
+// too far from the previous round.
+type RoundError struct {
+	// Asset is the hex address of the asset priced by the feed.
+	Asset  string
+	Round  *big.Int
+	Reason string
+	// Deviation is how far the answer moved from the previous round's if that's why the round was
+	// rejected, or 0.
+	Deviation float64
+
+	// data is the rejected round of the aggregator at feed, and factor the factor to divide its
+	// answer by.
+	feed   common.Address
+	data   round
+	factor *big.Int
+}
+
+func (e *RoundError) Error() string {
+	return fmt.Sprintf("bad Chainlink round %v for %s: %s", e.Round, e.Asset, e.Reason)
+}
+
+// round is the data of a Chainlink aggregator round.
+type round = struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}
+
+// aggregatorDecimals returns the decimals of the aggregator `agg` at `feed`, which are only read
+// once.
+func (c *Client) aggregatorDecimals(ctx context.Context, feed common.Address, agg *aggregator.Aggregator) (uint8,
+	error) {
+	if v, ok := c.feedDecimals.Load(feed); ok {
+		return v.(uint8), nil
+	}
+	decimals, err := agg.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	c.feedDecimals.Store(feed, decimals)
+	return decimals, nil
+}
+
+// heartbeat returns the heartbeat of the feed of the asset at hex address `addr`. Feeds missing
+// from the `priceFeeds` table are held to the client's `FeedHeartbeat`.
+func (c *Client) heartbeat(addr string, feed common.Address) time.Duration {
+	if heartbeat, ok := tableHeartbeat(feed); ok {
+		return heartbeat
+	}
+	heartbeat := c.FeedHeartbeat()
+	if _, logged := c.defaultHeartbeats.LoadOrStore(feed, true); !logged {
+		log.Printf("Chainlink feed %v of %s has no known heartbeat; checking it against %v", feed.Hex(), addr,
+			heartbeat)
+	}
+	return heartbeat
+}
+
+// checkRound validates the latest round `data` of the aggregator `agg` at `feed` of the asset at
+// hex address `addr` at time `now`. The answer must be positive, from a complete round no older
+// than the feed's heartbeat, and within the client's `MaxRoundDeviation` of the previous round's.
+func (c *Client) checkRound(ctx context.Context, addr string, feed common.Address, agg *aggregator.Aggregator,
+	data round, now time.Time) error {
+	bad := func(format string, args ...interface{}) *RoundError {
+		return &RoundError{Asset: addr, Round: data.RoundId, Reason: fmt.Sprintf(format, args...), feed: feed,
+			data: data}
+	}
+	switch {
+	case data.Answer.Sign() <= 0:
+		return bad("answer %v is not positive", data.Answer)
+	case data.UpdatedAt.Sign() == 0:
+		return bad("round is incomplete")
+	case data.AnsweredInRound.Cmp(data.RoundId) < 0:
+		return bad("answer is carried over from round %v", data.AnsweredInRound)
+	}
+	heartbeat := c.heartbeat(addr, feed)
+	updated := time.Unix(data.UpdatedAt.Int64(), 0)
+	if age := now.Sub(updated); age > heartbeat+heartbeatGrace {
+		return bad("updated %v ago, past the %v heartbeat", age.Truncate(time.Second), heartbeat)
+	}
+
+	// Rounds don't change, so the deviation of a round is only checked once, and the previous round
+	// is usually the one seen last.
+	prevID := new(big.Int).Sub(data.RoundId, big.NewInt(1))
+	var prevAnswer *big.Int
+	if v, ok := c.rounds.Load(feed); ok {
+		switch last := v.(round); last.RoundId.Cmp(prevID) {
+		case 1:
+			return nil
+		case 0:
+			prevAnswer = last.Answer
+		}
+	}
+	// The first round of a feed, or of a new phase of its proxy, has no previous round to compare
+	// with.
+	if prevAnswer == nil && prevID.Sign() > 0 {
+		prev, err := agg.GetRoundData(&bind.CallOpts{Context: ctx}, prevID)
+		if err != nil {
+			log.Printf("Not checking Chainlink round %v for %s against the previous one: %v", data.RoundId, addr,
+				err)
+		} else {
+			prevAnswer = prev.Answer
+		}
+	}
+	if prevAnswer != nil && prevAnswer.Sign() > 0 {
+		d := priceDivergence(prevAnswer, big.NewInt(1), data.Answer, big.NewInt(1))
+		if d > c.MaxRoundDeviation() {
+			err := bad("answer %v moved %.2f%% from %v in the previous round", data.Answer, d*100, prevAnswer)
+			err.Deviation = d
+			return err
+		}
+	}
+	c.rounds.Store(feed, data)
+	return nil
+}
+
+// confirmRound reports whether the AAVE oracle's `price` of the asset at hex address `addr`
+// confirms a round rejected with `feedErr` for moving too far from the previous round, and then
+// accepts the round. A real crash moves the price more than a round should, and liquidations
+// follow it since the oracle reads the same feed, so protection can't wait for it to recover.
+func (c *Client) confirmRound(addr string, price *big.Int, feedErr error) bool {
+	var roundErr *RoundError
+	if price == nil || !errors.As(feedErr, &roundErr) || roundErr.Deviation == 0 || roundErr.factor == nil {
+		return false
+	}
+	if d := priceDivergence(price, oracleFactor, roundErr.data.Answer, roundErr.factor); d > priceTolerance {
+		return false
+	}
+	log.Printf("ALERT: %v; accepting it since the AAVE oracle reports the same price", feedErr)
+	c.rounds.Store(roundErr.feed, roundErr.data)
+	return true
+}
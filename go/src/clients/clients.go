@@ -3,20 +3,27 @@ package clients
 
 import (
 	"context"
-	"encoding/binary"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 
+	"aaveoracle"
 	"aggregator"
 	"env"
 	"erc20"
+	"gas"
 	"lendingpool"
+	"pool"
+	"txmanager"
 	"wallets"
 	"weth9"
 )
@@ -24,11 +31,20 @@ import (
 type aggregatorEntry struct {
 	name       string
 	aggregator *common.Address
+	// heartbeat is the longest time the aggregator goes without a new round when the price is
+	// stable. Older answers are stale.
+	heartbeat time.Duration
 }
 
+// day is the heartbeat of most ETH-denominated Chainlink feeds.
+const day = 24 * time.Hour
+
 var (
 	// priceFeeds has references to chainlink aggregator addresses with prices in ETH keyed by
-	// asset address hex strings.
+	// asset address hex strings. Prices come from the AAVE price oracle and are cross-checked
+	// against the feed the oracle reads; the table's aggregators stand in for it when the oracle
+	// can't be queried. The heartbeats apply to the table's aggregators wherever they're found, and
+	// the names are used for tokens that don't report their name on-chain.
 	//
 	// Map keys are hex strings for comparability.
 	priceFeeds map[string]aggregatorEntry
@@ -38,48 +54,49 @@ func init() {
 	priceFeeds = make(map[string]aggregatorEntry)
 	for _, entry := range []struct {
 		name, token, aggregator string
+		heartbeat               time.Duration
 	}{
 		{"USDT",
-			"0xdAC17F958D2ee523a2206206994597C13D831ec7", "0xEe9F2375b4bdF6387aa8265dD4FB8F16512A1d46"},
+			"0xdAC17F958D2ee523a2206206994597C13D831ec7", "0xEe9F2375b4bdF6387aa8265dD4FB8F16512A1d46", day},
 		{"WBTC",
-			"0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", "0xdeb288F737066589598e9214E782fa5A8eD689e8"},
-		{"WETH9", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", ""}, // 1 by definition.
+			"0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", "0xdeb288F737066589598e9214E782fa5A8eD689e8", day},
+		{"WETH9", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "", 0}, // 1 by definition.
 		{"YFI",
-			"0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e", "0x7c5d4F8345e66f68099581Db340cd65B078C41f4"},
+			"0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e", "0x7c5d4F8345e66f68099581Db340cd65B078C41f4", day},
 		{"ZRXToken",
-			"0xE41d2489571d322189246DaFA5ebDe1F4699F498", "0x2Da4983a622a8498bb1a21FaE9D8F6C664939962"},
+			"0xE41d2489571d322189246DaFA5ebDe1F4699F498", "0x2Da4983a622a8498bb1a21FaE9D8F6C664939962", day},
 		{"Uni",
-			"0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984", "0xD6aA3D25116d8dA79Ea0246c4826EB951872e02e"},
+			"0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984", "0xD6aA3D25116d8dA79Ea0246c4826EB951872e02e", day},
 		{"AAVE",
-			"0x7Fc66500c84A76Ad7e9c93437bFc5Ac33E2DDaE9", "0x6Df09E975c830ECae5bd4eD9d90f3A95a4f88012"},
+			"0x7Fc66500c84A76Ad7e9c93437bFc5Ac33E2DDaE9", "0x6Df09E975c830ECae5bd4eD9d90f3A95a4f88012", day},
 		{"BAToken",
-			"0x0D8775F648430679A709E98d2b0Cb6250d2887EF", "0x0d16d4528239e9ee52fa531af613AcdB23D88c94"},
+			"0x0D8775F648430679A709E98d2b0Cb6250d2887EF", "0x0d16d4528239e9ee52fa531af613AcdB23D88c94", day},
 		{"Binance USD",
-			"0x4Fabb145d64652a948d72533023f6E7A623C7C53", "0x614715d2Af89E6EC99A233818275142cE88d1Cfd"},
+			"0x4Fabb145d64652a948d72533023f6E7A623C7C53", "0x614715d2Af89E6EC99A233818275142cE88d1Cfd", day},
 		{"Dai",
-			"0x6B175474E89094C44Da98b954EedeAC495271d0F", "0x773616E4d11A78F511299002da57A0a94577F1f4"},
+			"0x6B175474E89094C44Da98b954EedeAC495271d0F", "0x773616E4d11A78F511299002da57A0a94577F1f4", day},
 		{"ENJToken",
-			"0xF629cBd94d3791C9250152BD8dfBDF380E2a3B9c", "0x24D9aB51950F3d62E9144fdC2f3135DAA6Ce8D1B"},
+			"0xF629cBd94d3791C9250152BD8dfBDF380E2a3B9c", "0x24D9aB51950F3d62E9144fdC2f3135DAA6Ce8D1B", day},
 		{"KyberNetworkCrystal",
-			"0xdd974D5C2e2928deA5F71b9825b8b646686BD200", "0x656c0544eF4C98A6a98491833A89204Abb045d6b"},
+			"0xdd974D5C2e2928deA5F71b9825b8b646686BD200", "0x656c0544eF4C98A6a98491833A89204Abb045d6b", day},
 		{"LinkToken",
-			"0x514910771AF9Ca656af840dff83E8264EcF986CA", "0xDC530D9457755926550b59e8ECcdaE7624181557"},
+			"0x514910771AF9Ca656af840dff83E8264EcF986CA", "0xDC530D9457755926550b59e8ECcdaE7624181557", day},
 		{"MANAToken",
-			"0x0F5D2fB29fb7d3CFeE444a200298f468908cC942", "0x82A44D92D6c329826dc557c5E1Be6ebeC5D5FeB9"},
-		{"DSToken", "0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2", ""}, // No Chainlink feed.
+			"0x0F5D2fB29fb7d3CFeE444a200298f468908cC942", "0x82A44D92D6c329826dc557c5E1Be6ebeC5D5FeB9", day},
+		{"DSToken", "0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2", "", 0}, // No Chainlink feed.
 		{"RepublicToken",
-			"0x408e41876cCCDC0F92210600ef50372656052a38", "0xF1939BECE7708382b5fb5e559f630CB8B39a10ee"},
+			"0x408e41876cCCDC0F92210600ef50372656052a38", "0xF1939BECE7708382b5fb5e559f630CB8B39a10ee", day},
 		{"Synthetix Network Token",
-			"0xC011a73ee8576Fb46F5E1c5751cA3B9Fe0af2a6F", "0xF9A76ae7a1075Fe7d646b06fF05Bd48b9FA5582e"},
+			"0xC011a73ee8576Fb46F5E1c5751cA3B9Fe0af2a6F", "0xF9A76ae7a1075Fe7d646b06fF05Bd48b9FA5582e", day},
 		{"Synth sUSD",
-			"0x57Ab1ec28D129707052df4dF418D58a2D46d5f51", "0xb343e7a1aF578FA35632435243D814e7497622f7"},
+			"0x57Ab1ec28D129707052df4dF418D58a2D46d5f51", "0xb343e7a1aF578FA35632435243D814e7497622f7", day},
 		{"TrueUSD",
-			"0x0000000000085d4780B73119b644AE5ecd22b376", "0x7aeCF1c19661d12E962b69eBC8f6b2E63a55C660"},
+			"0x0000000000085d4780B73119b644AE5ecd22b376", "0x7aeCF1c19661d12E962b69eBC8f6b2E63a55C660", day},
 		{"USD Coin",
-			"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "0x64EaC61A2DFda2c3Fa04eED49AA33D021AeC8838"},
+			"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "0x64EaC61A2DFda2c3Fa04eED49AA33D021AeC8838", day},
 		{"Vyper_contract",
-			"0xD533a949740bb3306d119CC777fa900bA034cd52", "0x8a12Be339B0cD1829b91Adc01977caa5E9ac121e"},
-		{"Gemini dollar", "0x056Fd409E1d7A124BD7017459dFEa2F387b6d5Cd", ""}, // No Chainlink feed.
+			"0xD533a949740bb3306d119CC777fa900bA034cd52", "0x8a12Be339B0cD1829b91Adc01977caa5E9ac121e", day},
+		{"Gemini dollar", "0x056Fd409E1d7A124BD7017459dFEa2F387b6d5Cd", "", 0}, // No Chainlink feed.
 	} {
 		var aggregator *common.Address
 		if common.IsHexAddress(entry.aggregator) {
@@ -89,6 +106,7 @@ func init() {
 		priceFeeds[entry.token] = aggregatorEntry{
 			name:       entry.name,
 			aggregator: aggregator,
+			heartbeat:  entry.heartbeat,
 		}
 	}
 }
@@ -97,28 +115,67 @@ func init() {
 type Client struct {
 	env.Params
 
-	eth  *ethclient.Client
-	bot  *wallets.Wallet
+	eth  Backend
+	gas  gas.Strategy
+	bot  wallets.Signer
 	weth *weth9.Weth9
 	lp   *lendingpool.Lendingpool
+	// oracle is the AAVE price oracle, looked up on first use.
+	oracle     *aaveoracle.Aaveoracle
+	oracleAddr common.Address
+	oracleMu   sync.Mutex
 	// tokens maps `common.Address` token addresses to `*erc20.Erc20` token instances.
 	tokens sync.Map
-	// prices maps `common.Address` token addresses to `*aggregator.Aggregator` instances.
+	// prices maps `common.Address` feed addresses to `*aggregator.Aggregator` instances.
 	prices sync.Map
+	// sources maps `common.Address` token addresses to the `common.Address` of the feed last found
+	// to price them, which is zero for tokens without one.
+	sources sync.Map
+	// rounds maps `common.Address` feed addresses to their latest `round` that passed `checkRound`.
+	rounds sync.Map
+	// feedDecimals maps `common.Address` feed addresses to their `uint8` decimals, which never
+	// change.
+	feedDecimals sync.Map
+	// defaultHeartbeats holds the `common.Address` feed addresses checked against the
+	// `FeedHeartbeat`, which are logged once.
+	defaultHeartbeats sync.Map
+	// managers maps wallet `common.Address` addresses to their `*txmanager.Manager` instances.
+	managers sync.Map
 	// loans serves as a cache for the expensive `Loan` computations it maps `common.Address` account
 	// addresses to `*loanFuture` instances.
 	loans sync.Map
 }
 
-// NewClient initializes a new Client instance.
+// NewClient initializes a new Client instance connected to the nodes at the parameters' `ETHURIs`.
+// Several nodes are pooled so the client fails over between them.
 func NewClient(params env.Params) (*Client, error) {
-	eth, err := ethclient.Dial(params.ETHURI())
+	if uris := params.ETHURIs(); len(uris) > 1 {
+		p, err := pool.Dial(context.Background(), uris, pool.Config{Quorum: params.RPCQuorum()})
+		if err != nil {
+			return nil, fmt.Errorf("dialing node pool: %w", err)
+		}
+		// The pool checks the nodes for the lifetime of the process.
+		go p.Run(context.Background())
+		return newClient(params, p, p)
+	}
+	rpcClient, err := rpc.Dial(params.ETHURI())
 	if err != nil {
 		return nil, fmt.Errorf("dialing %s: %w", params.ETHURI(), err)
 	}
-	bot, err := wallets.NewWallet(params.BotKey())
+	return newClient(params, ethclient.NewClient(rpcClient), rpcClient)
+}
+
+// NewClientWithBackend initializes a new Client instance using `backend` for chain access. Without
+// raw JSON-RPC access, the fee history gas strategy falls back to the backend's suggestions.
+func NewClientWithBackend(params env.Params, backend Backend) (*Client, error) {
+	return newClient(params, backend, nil)
+}
+
+// newClient initializes a client. `rpcCaller` can be nil.
+func newClient(params env.Params, eth Backend, rpcCaller gas.RPCCaller) (*Client, error) {
+	bot, err := wallets.ForBot(params)
 	if err != nil {
-		return nil, fmt.Errorf("bot wallet from key %s: %w", params.BotKey(), err)
+		return nil, fmt.Errorf("bot signer: %w", err)
 	}
 	weth, err := weth9.NewWeth9(params.WETH9Address(), eth)
 	if err != nil {
@@ -132,52 +189,83 @@ func NewClient(params env.Params) (*Client, error) {
 	return &Client{
 		Params: params,
 		eth:    eth,
+		gas:    newGasStrategy(params, rpcCaller, eth),
 		bot:    bot,
 		weth:   weth,
 		lp:     lp,
 	}, nil
 }
 
-// ETH provides access to the underlying ETH client.
-func (c *Client) ETH() *ethclient.Client {
+// ETH provides access to the chain backend of the client.
+func (c *Client) ETH() Backend {
 	return c.eth
 }
 
-// Execute runs the transaction `t` using credentials of `from`.
-func (c *Client) Execute(ctx context.Context, from *wallets.Wallet, desc string,
+// LendingPool provides access to the Lending Pool binding, mainly to watch its events.
+func (c *Client) LendingPool() *lendingpool.Lendingpool {
+	return c.lp
+}
+
+// Execute runs the transaction `t` signed by `from` and waits for it to be confirmed.
+// Transactions of each signer go through a `txmanager.Manager`, which assigns nonces and replaces
+// the transaction if it gets stuck. Fees are chosen by the client's gas strategy for normal
+// urgency.
+func (c *Client) Execute(ctx context.Context, from wallets.Signer, desc string,
 	t func(*bind.TransactOpts) (*types.Transaction, error)) error {
-	txr, err := from.NewTransactor(ctx, c.eth)
-	if err != nil {
-		return fmt.Errorf("creating transactor for %s: %w", desc, err)
-	}
-	tx, err := t(txr)
-	if err != nil {
-		return fmt.Errorf("executing %s: %w", desc, err)
-	}
-	r, err := c.eth.TransactionReceipt(ctx, tx.Hash())
+	_, err := c.execute(ctx, from, gas.Normal, desc, t)
+	return err
+}
+
+// execute sends the transaction and returns its report, which is also returned with the error if the
+// transaction was mined but reverted.
+func (c *Client) execute(ctx context.Context, from wallets.Signer, urgency gas.Urgency, desc string,
+	t func(*bind.TransactOpts) (*types.Transaction, error)) (*txmanager.Report, error) {
+	m := c.txManager(from)
+	fees, err := c.gas.Fees(ctx, urgency)
 	if err != nil {
-		return fmt.Errorf("obtaining receipt for %s: %w", desc, err)
-	}
-	if r.Status != 1 {
-		return fmt.Errorf("%s transaction failed: %v", desc, r)
+		return nil, fmt.Errorf("choosing fees for %s: %w", desc, err)
 	}
-	return nil
+	log.Printf("Paying %v for %s %s", fees, urgency, desc)
+	return m.Send(ctx, desc, func(txr *bind.TransactOpts) (*types.Transaction, error) {
+		fees.Apply(txr)
+		return t(txr)
+	})
+}
+
+// txManager returns the transaction manager of the signer.
+func (c *Client) txManager(s wallets.Signer) *txmanager.Manager {
+	if m, ok := c.managers.Load(s.Address()); ok {
+		return m.(*txmanager.Manager)
+	}
+	m, _ := c.managers.LoadOrStore(s.Address(), txmanager.New(c.eth, wallets.Transactor(s, c.ChainID()),
+		txmanager.Config{
+			Confirmations: c.Confirmations(),
+			MaxFeePerGas:  c.MaxFeePerGas(),
+		}))
+	return m.(*txmanager.Manager)
+}
+
+// Fees returns the fees the client's gas strategy chooses for the given urgency. It makes the client
+// a `gas.Strategy`.
+func (c *Client) Fees(ctx context.Context, urgency gas.Urgency) (*gas.Fees, error) {
+	return c.gas.Fees(ctx, urgency)
 }
 
 // BotAddress returns the address of the bot.
 func (c *Client) BotAddress() common.Address {
-	return c.bot.Address
+	return c.bot.Address()
 }
 
-// Execute performs the transaction `t` using the bot's credentials.
-func (c *Client) ExecuteAsBot(ctx context.Context, desc string,
-	t func(*bind.TransactOpts) (*types.Transaction, error)) error {
-	return c.Execute(ctx, c.bot, desc, t)
+// ExecuteAsBot performs the transaction `t` using the bot's signer with fees for the given
+// urgency. The report of the mined transaction is returned even if it reverted.
+func (c *Client) ExecuteAsBot(ctx context.Context, urgency gas.Urgency, desc string,
+	t func(*bind.TransactOpts) (*types.Transaction, error)) (*txmanager.Report, error) {
+	return c.execute(ctx, c.bot, urgency, desc, t)
 }
 
-// SignAsBot signs the given hash using the bot's credentials.
+// SignAsBot signs the given hash as an EIP-191 personal message using the bot's signer.
 func (c *Client) SignAsBot(hash common.Hash) ([]byte, error) {
-	return c.bot.Sign(hash)
+	return c.bot.SignHash(hash)
 }
 
 // Token returns an Erc20 token interface for the given token address.
@@ -194,82 +282,101 @@ func (c *Client) Token(addr common.Address) (*erc20.Erc20, error) {
 	return v.(*erc20.Erc20), nil
 }
 
-// Aggregator returns an `aggregator.Aggregator` instance for the given hex string token address.
-func (c *Client) Aggregator(addr string) (*aggregator.Aggregator, error) {
-	v, ok := c.prices.Load(addr)
+// Aggregator returns an `aggregator.Aggregator` instance for the given feed address.
+func (c *Client) Aggregator(feed common.Address) (*aggregator.Aggregator, error) {
+	v, ok := c.prices.Load(feed)
 	if !ok {
-		entry := priceFeeds[addr]
-		if entry.aggregator == nil {
-			return nil, fmt.Errorf("no aggregator for %s (%s)", addr, entry.name)
-		}
 		var err error
-		v, err = aggregator.NewAggregator(*entry.aggregator, c.eth)
+		v, err = aggregator.NewAggregator(feed, c.eth)
 		if err != nil {
-			return nil, fmt.Errorf("aggregator client for %s: %w", addr, err)
+			return nil, fmt.Errorf("aggregator client for %v: %w", feed, err)
 		}
-		v, _ = c.prices.LoadOrStore(addr, v)
+		v, _ = c.prices.LoadOrStore(feed, v)
 	}
 	return v.(*aggregator.Aggregator), nil
 }
 
-// PriceOf returns the price of the asset at hex address `addr` in ETH.
-func (c *Client) PriceOf(ctx context.Context, addr string) (*big.Int, *big.Int, error) {
-	var price *big.Int
-	var decimals uint8
-	if addr == c.WETH9Address().Hex() {
-		price = big.NewInt(1)
-		decimals = 0
-	} else {
-		agg, err := c.Aggregator(addr)
-		if err != nil {
-			return nil, nil, fmt.Errorf("getting aggregator for %s: %w", addr, err)
-		}
-		decimals, err = agg.Decimals(&bind.CallOpts{Context: ctx})
-		if err != nil {
-			return nil, nil, fmt.Errorf("getting decimals for %s: %w", addr, err)
-		}
-		data, err := agg.LatestRoundData(&bind.CallOpts{Context: ctx})
-		if err != nil {
-			return nil, nil, fmt.Errorf("getting price data for %s: %w", addr, err)
-		}
-		price = data.Answer
+// chainlinkFeed returns the address of the Chainlink price feed for the given asset from the
+// `priceFeeds` table. The boolean is false if the table has no feed for it.
+func chainlinkFeed(asset common.Address) (common.Address, bool) {
+	entry := priceFeeds[asset.Hex()]
+	if entry.aggregator == nil {
+		return common.Address{}, false
 	}
-	decFactor := big.NewInt(1)
-	for i := uint8(0); i < decimals; i++ {
-		decFactor = decFactor.Mul(decFactor, big.NewInt(10))
+	return *entry.aggregator, true
+}
+
+// tableHeartbeat returns the heartbeat of the given feed from the `priceFeeds` table. The boolean
+// is false if the feed isn't in the table.
+func tableHeartbeat(feed common.Address) (time.Duration, bool) {
+	for _, entry := range priceFeeds {
+		if entry.aggregator != nil && *entry.aggregator == feed && entry.heartbeat != 0 {
+			return entry.heartbeat, true
+		}
 	}
-	return price, decFactor, nil
+	return 0, false
 }
 
-// DepositETH deposits ETH into the lending pool from the given wallet. Used for testing.
-func (c *Client) DepositETH(ctx context.Context, from *wallets.Wallet, amount *big.Int) error {
-	txr, err := from.NewTransactor(ctx, c.eth)
+// chainlinkPrice returns the price of the asset at hex address `addr` in ETH from its Chainlink
+// aggregator at `feed` along with the factor to divide it by. Rounds that fail `checkRound` are
+// rejected with a `*RoundError`.
+func (c *Client) chainlinkPrice(ctx context.Context, addr string, feed common.Address) (*big.Int, *big.Int,
+	error) {
+	agg, err := c.Aggregator(feed)
 	if err != nil {
-		return fmt.Errorf("depositing WETH: %w", err)
+		return nil, nil, fmt.Errorf("getting aggregator for %s: %w", addr, err)
 	}
-	txr.Value = amount // Setting the value means we can't user the higher-level `Execute` method.
-	tx, err := c.weth.Deposit(txr)
+	decimals, err := c.aggregatorDecimals(ctx, feed, agg)
 	if err != nil {
-		return fmt.Errorf("wrapping ETH: %w", err)
+		return nil, nil, fmt.Errorf("getting decimals for %s: %w", addr, err)
 	}
-	r, err := c.eth.TransactionReceipt(ctx, tx.Hash())
+	data, err := agg.LatestRoundData(&bind.CallOpts{Context: ctx})
 	if err != nil {
-		return err
+		return nil, nil, fmt.Errorf("getting price data for %s: %w", addr, err)
+	}
+	return c.roundPrice(ctx, addr, feed, agg, decimals, data)
+}
+
+// roundPrice returns the price of the latest round `data` of the aggregator `agg` at `feed` of the
+// asset at hex address `addr` along with the factor to divide it by, once the round passes
+// `checkRound`.
+func (c *Client) roundPrice(ctx context.Context, addr string, feed common.Address, agg *aggregator.Aggregator,
+	decimals uint8, data round) (*big.Int, *big.Int, error) {
+	decFactor := big.NewInt(1)
+	for i := uint8(0); i < decimals; i++ {
+		decFactor = decFactor.Mul(decFactor, big.NewInt(10))
+	}
+	if err := c.checkRound(ctx, addr, feed, agg, data, time.Now()); err != nil {
+		// The rejected round can still be confirmed by the AAVE oracle. See `confirmRound`.
+		var roundErr *RoundError
+		if errors.As(err, &roundErr) {
+			roundErr.factor = decFactor
+		}
+		return nil, nil, err
 	}
-	if r.Status != 1 {
-		return fmt.Errorf("wrapping ETH transaction failed: %v", r)
+	return data.Answer, decFactor, nil
+}
+
+// DepositETH deposits ETH into the lending pool from the given wallet. Used for testing.
+func (c *Client) DepositETH(ctx context.Context, from wallets.Signer, amount *big.Int) error {
+	if err := c.Execute(ctx, from, "wrapping ETH",
+		func(txr *bind.TransactOpts) (*types.Transaction, error) {
+			txr.Value = amount
+			return c.weth.Deposit(txr)
+		}); err != nil {
+		return err
 	}
 
-	if err = c.Execute(ctx, from, "approving lending pool for WETH",
+	if err := c.Execute(ctx, from, "approving lending pool for WETH",
 		func(txr *bind.TransactOpts) (*types.Transaction, error) {
 			return c.weth.Approve(txr, c.LendingPoolAddress(), amount)
 		}); err != nil {
 		return err
 	}
 
-	if err = c.Execute(ctx, from, "depositing WETH collateral",
+	if err := c.Execute(ctx, from, "depositing WETH collateral",
 		func(txr *bind.TransactOpts) (*types.Transaction, error) {
-			return c.lp.Deposit(txr, c.WETH9Address(), amount, from.Address, 0)
+			return c.lp.Deposit(txr, c.WETH9Address(), amount, from.Address(), 0)
 		}); err != nil {
 		return err
 	}
@@ -278,152 +385,13 @@ func (c *Client) DepositETH(ctx context.Context, from *wallets.Wallet, amount *b
 }
 
 // Borrow borrows the given asset for the given wallet at the stable rate. Used for testing.
-func (c *Client) Borrow(ctx context.Context, onBehalfOf *wallets.Wallet, asset common.Address, amount *big.Int) error {
+func (c *Client) Borrow(ctx context.Context, onBehalfOf wallets.Signer, asset common.Address, amount *big.Int) error {
 	return c.Execute(ctx, onBehalfOf, fmt.Sprintf("borrowing %v", asset),
 		func(txr *bind.TransactOpts) (*types.Transaction, error) {
-			return c.lp.Borrow(txr, asset, amount, big.NewInt(1), 0, onBehalfOf.Address)
+			return c.lp.Borrow(txr, asset, amount, big.NewInt(1), 0, onBehalfOf.Address())
 		})
 }
 
-// Loan contains metadata about a loan.
-type Loan struct {
-	User common.Address
-
-	CollateralName       string
-	Collateral           common.Address
-	AToken               common.Address
-	LiquidationThreshold uint16
-
-	DebtName     string
-	Debt         common.Address
-	StableDebt   common.Address
-	VariableDebt common.Address
-}
-
-type loanFuture struct {
-	computeOnce sync.Once
-	loan        *Loan
-	err         error
-}
-
-// Loan returns (possibly cached) metadata about a user's loan.
-func (c *Client) Loan(ctx context.Context, u common.Address) (*Loan, error) {
-	v, ok := c.loans.Load(u)
-	if !ok {
-		v, _ = c.loans.LoadOrStore(u, &loanFuture{})
-	}
-	lf := v.(*loanFuture)
-	lf.computeOnce.Do(func() { lf.loan, lf.err = c.loan(ctx, u) })
-	return lf.loan, lf.err
-}
-
-func (c *Client) loan(ctx context.Context, u common.Address) (*Loan, error) {
-	reserves, err := c.lp.GetReservesList(&bind.CallOpts{Context: ctx})
-	if err != nil {
-		return nil, fmt.Errorf("getting reserves list: %v", err)
-	}
-	config, err := c.lp.GetUserConfiguration(&bind.CallOpts{Context: ctx}, u)
-	if err != nil {
-		return nil, fmt.Errorf("getting user configuration: %v", err)
-	}
-	var collateral []common.Address
-	var debt []common.Address
-	for i, asset := range reserves {
-		if config.Data.Bit(2*i+1) > 0 {
-			collateral = append(collateral, asset)
-		}
-		if config.Data.Bit(2*i) > 0 {
-			debt = append(debt, asset)
-		}
-	}
-	if len(collateral) != 1 || len(debt) != 1 {
-		return nil, fmt.Errorf(`only 1 collateral asset and 1 debt asset supported
-collateral assets: %v, debt assets: %v`, collateral, debt)
-	}
-
-	cInfo, err := c.lp.GetReserveData(&bind.CallOpts{Context: ctx}, collateral[0])
-	if err != nil {
-		return nil, fmt.Errorf("retrieving reserve data for %v: %v", collateral[0], err)
-	}
-
-	configBytes := cInfo.Configuration.Data.Bytes() // `Bytes` returns big endian.
-	threshold := binary.BigEndian.Uint16(configBytes[len(configBytes)-4 : len(configBytes)-2])
-
-	dInfo, err := c.lp.GetReserveData(&bind.CallOpts{Context: ctx}, debt[0])
-	if err != nil {
-		return nil, fmt.Errorf("retrieving reserve data for %v: %v", debt[0], err)
-	}
-
-	return &Loan{
-		User:                 u,
-		CollateralName:       priceFeeds[collateral[0].Hex()].name,
-		Collateral:           collateral[0],
-		AToken:               cInfo.ATokenAddress,
-		LiquidationThreshold: threshold,
-		DebtName:             priceFeeds[debt[0].Hex()].name,
-		Debt:                 debt[0],
-		StableDebt:           dInfo.StableDebtTokenAddress,
-		VariableDebt:         dInfo.VariableDebtTokenAddress,
-	}, nil
-}
-
-// LoanAmount contains information about a loan.
-type LoanAmount struct {
-	CollateralAmount *big.Int
-	DebtAmount       *big.Int
-	CurrentRatio     *big.Rat
-}
-
-// Ratio returns the ratio in units of 1/10000.
-func (l *LoanAmount) Ratio() uint16 {
-	ratioF, _ := l.CurrentRatio.Float64()
-	ratioF *= 10000
-	return uint16(ratioF)
-}
-
-// Data retrieves loan amounts.
-func (l *Loan) Data(ctx context.Context, c *Client) (*LoanAmount, error) {
-	cAmount, err := c.BalanceOf(ctx, l.AToken, l.User)
-	if err != nil {
-		return nil, fmt.Errorf("balance for user %v: %w", l.User, err)
-	}
-	cPrice, cFactor, err := c.PriceOf(ctx, l.Collateral.Hex())
-	if err != nil {
-		return nil, fmt.Errorf("converting collateral %v to eth: %w", l.Collateral, err)
-	}
-
-	dAmount, err := l.DebtAmount(ctx, c)
-	dPrice, dFactor, err := c.PriceOf(ctx, l.Debt.Hex())
-	if err != nil {
-		return nil, fmt.Errorf("converting debt %v to eth: %w", l.Debt, err)
-	}
-
-	cEthAmount := new(big.Int).Mul(cAmount, cPrice)
-	dEthAmount := new(big.Int).Mul(dAmount, dPrice)
-
-	ratio := new(big.Rat).SetFrac(dEthAmount, cEthAmount)
-	ratio = ratio.Mul(ratio, new(big.Rat).SetFrac(cFactor, dFactor))
-
-	return &LoanAmount{
-		CollateralAmount: cAmount,
-		DebtAmount:       dAmount,
-		CurrentRatio:     ratio,
-	}, nil
-}
-
-// DebtAmount returns the total amount of debt (stable plus variable).
-func (l *Loan) DebtAmount(ctx context.Context, c *Client) (*big.Int, error) {
-	sdAmount, err := c.BalanceOf(ctx, l.StableDebt, l.User)
-	if err != nil {
-		return nil, fmt.Errorf("retrieving stable debt balance for %v: %w", l.User, err)
-	}
-	vdAmount, err := c.BalanceOf(ctx, l.VariableDebt, l.User)
-	if err != nil {
-		return nil, fmt.Errorf("retrieving variable debt balance for %v: %w", l.User, err)
-	}
-	return new(big.Int).Add(sdAmount, vdAmount), nil
-}
-
 // BalanceOf returns the balance of the given ERC20 asset in the given wallet.
 func (c *Client) BalanceOf(ctx context.Context, asset common.Address, u common.Address) (*big.Int, error) {
 	token, err := c.Token(asset)
@@ -432,7 +400,7 @@ func (c *Client) BalanceOf(ctx context.Context, asset common.Address, u common.A
 	}
 	amount, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, u)
 	if err != nil {
-		return nil, fmt.Errorf("querying balance of token %v: %w", asset, u, err)
+		return nil, fmt.Errorf("querying balance of token %v for %v: %w", asset, u, err)
 	}
 	return amount, nil
 }
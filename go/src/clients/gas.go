@@ -0,0 +1,21 @@
+package clients
+
+import (
+	"env"
+	"gas"
+)
+
+// newGasStrategy returns the gas strategy selected by the parameters, capped at their maximum fee.
+// The fee history strategy needs `rpcCaller`, and the node's suggestions are used without it.
+func newGasStrategy(params env.Params, rpcCaller gas.RPCCaller, eth gas.SuggestionBackend) gas.Strategy {
+	var s gas.Strategy
+	switch {
+	case params.GasStrategy() == env.GasStatic:
+		s = &gas.Static{GasPrice: params.GasPrice()}
+	case params.GasStrategy() == env.GasSuggested || rpcCaller == nil:
+		s = &gas.Suggested{Backend: eth}
+	default:
+		s = &gas.FeeHistory{RPC: rpcCaller, Fallback: &gas.Suggested{Backend: eth}}
+	}
+	return &gas.Capped{Strategy: s, MaxFeePerGas: params.MaxFeePerGas()}
+}
@@ -0,0 +1,277 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reserve contains metadata about one of the AAVE reserves a loan uses.
+type Reserve struct {
+	Name  string
+	Asset common.Address
+	// Decimals is the number of decimals of the underlying asset.
+	Decimals uint8
+	// LiquidationThreshold is in units of 1/10000.
+	LiquidationThreshold uint16
+
+	AToken       common.Address
+	StableDebt   common.Address
+	VariableDebt common.Address
+}
+
+// Loan contains metadata about a loan. A loan may use several reserves as collateral and may
+// borrow from several reserves.
+type Loan struct {
+	User common.Address
+
+	Collateral []*Reserve
+	Debt       []*Reserve
+}
+
+type loanFuture struct {
+	computeOnce sync.Once
+	loan        *Loan
+	err         error
+}
+
+// Loan returns (possibly cached) metadata about a user's loan.
+func (c *Client) Loan(ctx context.Context, u common.Address) (*Loan, error) {
+	v, ok := c.loans.Load(u)
+	if !ok {
+		v, _ = c.loans.LoadOrStore(u, &loanFuture{})
+	}
+	lf := v.(*loanFuture)
+	lf.computeOnce.Do(func() { lf.loan, lf.err = c.loan(ctx, u) })
+	return lf.loan, lf.err
+}
+
+// ForgetLoan drops cached metadata about a user's loan. It should be called when the user changes
+// the reserves they use.
+func (c *Client) ForgetLoan(u common.Address) {
+	c.loans.Delete(u)
+}
+
+// Uses returns true if the loan uses the given asset as collateral or debt.
+func (l *Loan) Uses(asset common.Address) bool {
+	for _, rs := range [][]*Reserve{l.Collateral, l.Debt} {
+		for _, r := range rs {
+			if r.Asset == asset {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *Client) loan(ctx context.Context, u common.Address) (*Loan, error) {
+	reserves, err := c.lp.GetReservesList(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("getting reserves list: %v", err)
+	}
+	config, err := c.lp.GetUserConfiguration(&bind.CallOpts{Context: ctx}, u)
+	if err != nil {
+		return nil, fmt.Errorf("getting user configuration: %v", err)
+	}
+	loan := &Loan{User: u}
+	for i, asset := range reserves {
+		isCollateral := config.Data.Bit(2*i+1) > 0
+		isDebt := config.Data.Bit(2*i) > 0
+		if !isCollateral && !isDebt {
+			continue
+		}
+		r, err := c.reserve(ctx, asset)
+		if err != nil {
+			return nil, err
+		}
+		if isCollateral {
+			loan.Collateral = append(loan.Collateral, r)
+		}
+		if isDebt {
+			loan.Debt = append(loan.Debt, r)
+		}
+	}
+	if len(loan.Collateral) == 0 || len(loan.Debt) == 0 {
+		return nil, fmt.Errorf(`no loan found for %v
+collateral assets: %v, debt assets: %v`, u, loan.Collateral, loan.Debt)
+	}
+	return loan, nil
+}
+
+// reserve retrieves metadata for the reserve of the given asset.
+func (c *Client) reserve(ctx context.Context, asset common.Address) (*Reserve, error) {
+	info, err := c.lp.GetReserveData(&bind.CallOpts{Context: ctx}, asset)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving reserve data for %v: %v", asset, err)
+	}
+	// See ReserveConfiguration.sol for the layout: bits 16-31 hold the liquidation threshold and
+	// bits 48-55 hold the decimals.
+	config := info.Configuration.Data
+	return &Reserve{
+		Name:                 c.tokenName(ctx, asset),
+		Asset:                asset,
+		Decimals:             uint8(configBits(config, 48, 8)),
+		LiquidationThreshold: uint16(configBits(config, 16, 16)),
+		AToken:               info.ATokenAddress,
+		StableDebt:           info.StableDebtTokenAddress,
+		VariableDebt:         info.VariableDebtTokenAddress,
+	}, nil
+}
+
+// configBits extracts `width` bits starting at bit `start` from a reserve configuration bitmap.
+func configBits(config *big.Int, start, width uint) uint64 {
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), width), big.NewInt(1))
+	return new(big.Int).And(new(big.Int).Rsh(config, start), mask).Uint64()
+}
+
+func (r *Reserve) String() string {
+	return fmt.Sprintf("%s(%v)", r.Name, r.Asset.Hex())
+}
+
+// AssetAmount is the amount held (or owed) in a single reserve of a loan.
+type AssetAmount struct {
+	*Reserve
+
+	// Amount is in units of the underlying asset.
+	Amount *big.Int
+	// ETHValue is the value of `Amount` in ETH.
+	ETHValue *big.Rat
+}
+
+// LoanAmount contains information about a loan.
+type LoanAmount struct {
+	Collateral []*AssetAmount
+	Debt       []*AssetAmount
+
+	// CollateralETH and DebtETH are the totals across reserves in ETH.
+	CollateralETH *big.Rat
+	DebtETH       *big.Rat
+	// CurrentRatio is the ratio of total debt to total collateral.
+	CurrentRatio *big.Rat
+	// LiquidationThreshold is the average of the collateral liquidation thresholds weighted by
+	// their value, in units of 1/10000. The loan becomes liquidatable when `CurrentRatio` reaches
+	// this value.
+	LiquidationThreshold uint16
+	// HealthFactor is the collateral weighted by the liquidation thresholds divided by the debt. The
+	// loan becomes liquidatable below 1.
+	HealthFactor *big.Rat
+}
+
+// Ratio returns the ratio in units of 1/10000.
+func (l *LoanAmount) Ratio() uint16 {
+	ratioF, _ := l.CurrentRatio.Float64()
+	ratioF *= 10000
+	return uint16(ratioF)
+}
+
+// LargestCollateral returns the collateral with the highest value in ETH.
+func (l *LoanAmount) LargestCollateral() *AssetAmount {
+	return largest(l.Collateral)
+}
+
+// LargestDebt returns the debt with the highest value in ETH.
+func (l *LoanAmount) LargestDebt() *AssetAmount {
+	return largest(l.Debt)
+}
+
+func largest(amounts []*AssetAmount) *AssetAmount {
+	var ret *AssetAmount
+	for _, a := range amounts {
+		if ret == nil || a.ETHValue.Cmp(ret.ETHValue) > 0 {
+			ret = a
+		}
+	}
+	return ret
+}
+
+// Data retrieves loan amounts.
+func (l *Loan) Data(ctx context.Context, c *Client) (*LoanAmount, error) {
+	return l.amounts(func(token common.Address) (*big.Int, error) {
+		return c.BalanceOf(ctx, token, l.User)
+	}, func(r *Reserve) (*big.Int, *big.Int, error) {
+		return c.PriceOf(ctx, r.Asset.Hex())
+	})
+}
+
+// amounts computes the loan amounts from the user's token balances and the prices of the reserves,
+// given by `balanceOf` and `priceOf` respectively. See `PriceOf` for the prices.
+func (l *Loan) amounts(balanceOf func(token common.Address) (*big.Int, error),
+	priceOf func(r *Reserve) (*big.Int, *big.Int, error)) (*LoanAmount, error) {
+	ret := &LoanAmount{
+		CollateralETH: new(big.Rat),
+		DebtETH:       new(big.Rat),
+	}
+	// weighted is the sum of collateral values weighted by their liquidation thresholds.
+	weighted := new(big.Rat)
+	for _, r := range l.Collateral {
+		amount, err := balanceOf(r.AToken)
+		if err != nil {
+			return nil, fmt.Errorf("balance for user %v: %w", l.User, err)
+		}
+		value, err := ethValue(r, amount, priceOf)
+		if err != nil {
+			return nil, fmt.Errorf("converting collateral %v to eth: %w", r, err)
+		}
+		ret.Collateral = append(ret.Collateral, &AssetAmount{Reserve: r, Amount: amount, ETHValue: value})
+		ret.CollateralETH.Add(ret.CollateralETH, value)
+		threshold := big.NewRat(int64(r.LiquidationThreshold), 10000)
+		weighted.Add(weighted, new(big.Rat).Mul(value, threshold))
+	}
+	for _, r := range l.Debt {
+		sdAmount, err := balanceOf(r.StableDebt)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving stable debt balance for %v: %w", l.User, err)
+		}
+		vdAmount, err := balanceOf(r.VariableDebt)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving variable debt balance for %v: %w", l.User, err)
+		}
+		amount := new(big.Int).Add(sdAmount, vdAmount)
+		value, err := ethValue(r, amount, priceOf)
+		if err != nil {
+			return nil, fmt.Errorf("converting debt %v to eth: %w", r, err)
+		}
+		ret.Debt = append(ret.Debt, &AssetAmount{Reserve: r, Amount: amount, ETHValue: value})
+		ret.DebtETH.Add(ret.DebtETH, value)
+	}
+
+	if ret.CollateralETH.Sign() == 0 {
+		return nil, fmt.Errorf("no collateral for user %v", l.User)
+	}
+	ret.CurrentRatio = new(big.Rat).Quo(ret.DebtETH, ret.CollateralETH)
+	thresholdF, _ := new(big.Rat).Quo(weighted, ret.CollateralETH).Float64()
+	ret.LiquidationThreshold = uint16(thresholdF * 10000)
+	if ret.DebtETH.Sign() > 0 {
+		ret.HealthFactor = new(big.Rat).Quo(weighted, ret.DebtETH)
+	}
+	return ret, nil
+}
+
+// ethValue converts `amount` units of the reserve asset to ETH at the price given by `priceOf`.
+func ethValue(r *Reserve, amount *big.Int, priceOf func(r *Reserve) (*big.Int, *big.Int, error)) (*big.Rat,
+	error) {
+	price, factor, err := priceOf(r)
+	if err != nil {
+		return nil, err
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(r.Decimals)), nil)
+	denom.Mul(denom, factor)
+	return new(big.Rat).SetFrac(new(big.Int).Mul(amount, price), denom), nil
+}
+
+// DebtAmount returns the total amount of debt (stable plus variable) owed to the given reserve.
+func (l *Loan) DebtAmount(ctx context.Context, c *Client, r *Reserve) (*big.Int, error) {
+	sdAmount, err := c.BalanceOf(ctx, r.StableDebt, l.User)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving stable debt balance for %v: %w", l.User, err)
+	}
+	vdAmount, err := c.BalanceOf(ctx, r.VariableDebt, l.User)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving variable debt balance for %v: %w", l.User, err)
+	}
+	return new(big.Int).Add(sdAmount, vdAmount), nil
+}
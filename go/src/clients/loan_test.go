@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"simulated"
+)
+
+func TestConfigBits(t *testing.T) {
+	// WETH on mainnet: LTV 80%, liquidation threshold 82.5%, bonus 105%, 18 decimals, active.
+	config := new(big.Int).SetUint64(8000 | 8250<<16 | 10500<<32 | 18<<48 | 1<<56)
+	if got := configBits(config, 16, 16); got != 8250 {
+		t.Errorf("configBits(liquidation threshold) = %d, want 8250", got)
+	}
+	if got := configBits(config, 48, 8); got != 18 {
+		t.Errorf("configBits(decimals) = %d, want 18", got)
+	}
+}
+
+func TestLargest(t *testing.T) {
+	small := &AssetAmount{Reserve: &Reserve{Name: "small"}, ETHValue: big.NewRat(1, 2)}
+	large := &AssetAmount{Reserve: &Reserve{Name: "large"}, ETHValue: big.NewRat(3, 2)}
+	l := &LoanAmount{Collateral: []*AssetAmount{small, large}, Debt: []*AssetAmount{small}}
+	if got := l.LargestCollateral(); got != large {
+		t.Errorf("LargestCollateral() = %v, want %v", got.Reserve, large.Reserve)
+	}
+	if got := l.LargestDebt(); got != small {
+		t.Errorf("LargestDebt() = %v, want %v", got.Reserve, small.Reserve)
+	}
+}
+
+func TestLoanSimulated(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		newClient func(chain *simulated.Chain) (*Client, error)
+	}{
+		{"JSON-RPC", func(chain *simulated.Chain) (*Client, error) { return NewClient(chain.Params) }},
+		{"backend", func(chain *simulated.Chain) (*Client, error) {
+			return NewClientWithBackend(chain.Params, chain.Backend())
+		}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			chain := simulated.New(t)
+			c, err := tc.newClient(chain)
+			if err != nil {
+				t.Fatalf("creating client: %v", err)
+			}
+			testLoan(t, chain, c)
+		})
+	}
+}
+
+func testLoan(t *testing.T, chain *simulated.Chain, c *Client) {
+	ctx := context.Background()
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+
+	loan, err := c.Loan(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatalf("Loan(%v) = _, %v, want _, nil", chain.User.Address(), err)
+	}
+	if len(loan.Collateral) != 1 || loan.Collateral[0].Asset != chain.Market.WETH.Asset.Address {
+		t.Errorf("loan collateral = %v, want WETH", loan.Collateral)
+	}
+	if len(loan.Debt) != 1 || loan.Debt[0].Asset != chain.DAI.Asset.Address {
+		t.Errorf("loan debt = %v, want Dai", loan.Debt)
+	}
+	if got := loan.Collateral[0].LiquidationThreshold; got != 8250 {
+		t.Errorf("WETH liquidation threshold = %d, want 8250", got)
+	}
+
+	data, err := loan.Data(ctx, c)
+	if err != nil {
+		t.Fatalf("Data(...) = _, %v, want _, nil", err)
+	}
+	if got := data.Ratio(); got != 5000 {
+		t.Errorf("Ratio() = %d, want 5000", got)
+	}
+	if data.LiquidationThreshold != 8250 {
+		t.Errorf("LiquidationThreshold = %d, want 8250", data.LiquidationThreshold)
+	}
+	if want := big.NewRat(165, 100); data.HealthFactor.Cmp(want) != 0 {
+		t.Errorf("HealthFactor = %v, want %v", data.HealthFactor.FloatString(4), want.FloatString(4))
+	}
+
+	// Doubling the price of Dai brings the loan past the liquidation threshold.
+	if err := chain.Market.SetPrice(ctx, chain.DAI, new(big.Int).Mul(simulated.DAIPrice, big.NewInt(2))); err != nil {
+		t.Fatal(err)
+	}
+	if data, err = loan.Data(ctx, c); err != nil {
+		t.Fatalf("Data(...) = _, %v, want _, nil", err)
+	}
+	if got := data.Ratio(); got != 10000 {
+		t.Errorf("Ratio() after the price change = %d, want 10000", got)
+	}
+	account, err := c.AccountData(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatalf("AccountData(...) = _, %v, want _, nil", err)
+	}
+	if d := account.Divergence(data); d > 1e-9 {
+		t.Errorf("Divergence of the Lending Pool's account data = %v, want 0", d)
+	}
+}
@@ -0,0 +1,178 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"aaveoracle"
+	"addressesprovider"
+)
+
+const (
+	// priceTolerance is the relative difference between the AAVE oracle and the Chainlink table
+	// above which an alert is logged.
+	priceTolerance = 0.01
+)
+
+var (
+	// oracleFactor is the factor to divide AAVE oracle prices by. They are in wei per whole token.
+	oracleFactor = big.NewInt(1e18)
+)
+
+// priceOracle returns the AAVE price oracle registered with the Lending Pool's addresses provider
+// along with its address.
+func (c *Client) priceOracle(ctx context.Context) (*aaveoracle.Aaveoracle, common.Address, error) {
+	c.oracleMu.Lock()
+	defer c.oracleMu.Unlock()
+	if c.oracle != nil {
+		return c.oracle, c.oracleAddr, nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	providerAddr, err := c.lp.GetAddressesProvider(opts)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("getting addresses provider: %w", err)
+	}
+	provider, err := addressesprovider.NewAddressesprovider(providerAddr, c.eth)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("addresses provider client for %v: %w", providerAddr, err)
+	}
+	oracleAddr, err := provider.GetPriceOracle(opts)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("getting price oracle from %v: %w", providerAddr, err)
+	}
+	oracle, err := aaveoracle.NewAaveoracle(oracleAddr, c.eth)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("price oracle client for %v: %w", oracleAddr, err)
+	}
+	c.oracle, c.oracleAddr = oracle, oracleAddr
+	return oracle, oracleAddr, nil
+}
+
+// oraclePrice returns the price of the given asset in wei according to the AAVE price oracle.
+func (c *Client) oraclePrice(ctx context.Context, asset common.Address) (*big.Int, error) {
+	oracle, _, err := c.priceOracle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	price, err := oracle.GetAssetPrice(&bind.CallOpts{Context: ctx}, asset)
+	if err != nil {
+		return nil, fmt.Errorf("getting oracle price for %v: %w", asset, err)
+	}
+	return checkOraclePrice(asset, price)
+}
+
+// checkOraclePrice returns an error for the price the AAVE oracle reports for assets it has no
+// price for.
+func checkOraclePrice(asset common.Address, price *big.Int) (*big.Int, error) {
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("no oracle price for %v", asset)
+	}
+	return price, nil
+}
+
+// PriceOf returns the price of the asset at hex address `addr` in ETH along with the factor to
+// divide it by.
+//
+// Prices come from the AAVE price oracle, which is what the Lending Pool uses to decide on
+// liquidations. They are cross-checked against the Chainlink aggregator the oracle reads (see
+// `PriceFeed`), which also serves as a fallback if the oracle can't be queried. A stale or
+// anomalous Chainlink round is an error rather than a price, so it can neither trigger nor suppress
+// a repayment, unless it only moved further from the previous round than expected and the oracle
+// reports its price.
+func (c *Client) PriceOf(ctx context.Context, addr string) (*big.Int, *big.Int, error) {
+	if addr == c.WETH9Address().Hex() {
+		return big.NewInt(1), big.NewInt(1), nil
+	}
+	asset := common.HexToAddress(addr)
+	price, err := c.oraclePrice(ctx, asset)
+	feed, ok, feedErr := c.PriceFeed(ctx, asset)
+	if feedErr == nil && !ok {
+		return price, oracleFactor, err
+	}
+	var feedPrice, feedFactor *big.Int
+	if feedErr == nil {
+		feedPrice, feedFactor, feedErr = c.chainlinkPrice(ctx, addr, feed)
+	}
+	if err == nil && c.confirmRound(addr, price, feedErr) {
+		return price, oracleFactor, nil
+	}
+	return crossCheck(addr, price, err, feedPrice, feedFactor, feedErr)
+}
+
+// crossCheck picks the price of the asset at hex address `addr` from the AAVE oracle's `price` and
+// the Chainlink `feedPrice`, given with the errors getting them. See `PriceOf`.
+func crossCheck(addr string, price *big.Int, err error, feedPrice, feedFactor *big.Int, feedErr error) (
+	*big.Int, *big.Int, error) {
+	var roundErr *RoundError
+	switch {
+	case errors.As(feedErr, &roundErr):
+		// The oracle reads the same feed, so its price can't be trusted either.
+		log.Printf("ALERT: %v", feedErr)
+		return nil, nil, fmt.Errorf("no trusted price for %s: %w", addr, feedErr)
+	case err != nil && feedErr != nil:
+		return nil, nil, fmt.Errorf("no price for %s: %v; falling back to Chainlink: %w", addr, err, feedErr)
+	case err != nil:
+		log.Printf("Falling back to Chainlink price for %s: %v", addr, err)
+		return feedPrice, feedFactor, nil
+	case feedErr != nil:
+		log.Printf("Error cross-checking price for %s: %v", addr, feedErr)
+	default:
+		if d := priceDivergence(price, oracleFactor, feedPrice, feedFactor); d > priceTolerance {
+			log.Printf("ALERT: AAVE oracle and Chainlink prices for %s differ by %.2f%%", addr, d*100)
+		}
+	}
+	return price, oracleFactor, nil
+}
+
+// priceDivergence returns the relative difference of price `b` from price `a`, each given with
+// the factor to divide it by.
+func priceDivergence(a, aFactor, b, bFactor *big.Int) float64 {
+	aF, _ := new(big.Rat).SetFrac(a, aFactor).Float64()
+	bF, _ := new(big.Rat).SetFrac(b, bFactor).Float64()
+	if aF == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(bF-aF) / aF
+}
+
+// PriceFeed returns the address of the Chainlink price feed for the given asset. It is the
+// oracle's source for the asset, or the feed from the Chainlink table if the oracle can't be
+// queried. The boolean is false if the asset has no feed, which is the case for WETH9 since its
+// price is 1 by definition.
+func (c *Client) PriceFeed(ctx context.Context, asset common.Address) (common.Address, bool, error) {
+	if asset == c.WETH9Address() {
+		return common.Address{}, false, nil
+	}
+	oracle, _, err := c.priceOracle(ctx)
+	if err == nil {
+		var source common.Address
+		source, err = oracle.GetSourceOfAsset(&bind.CallOpts{Context: ctx}, asset)
+		if err == nil {
+			c.sources.Store(asset, source)
+			return source, source != (common.Address{}), nil
+		}
+	}
+	if feed, ok := chainlinkFeed(asset); ok {
+		return feed, true, nil
+	}
+	return common.Address{}, false, fmt.Errorf("getting price source for %v: %w", asset, err)
+}
+
+// tokenName returns the name of the given token. Tokens that don't implement the optional `name`
+// method (or return it as bytes32, like MKR) are named from the Chainlink table, if listed.
+func (c *Client) tokenName(ctx context.Context, asset common.Address) string {
+	token, err := c.Token(asset)
+	if err == nil {
+		if name, err := token.Name(&bind.CallOpts{Context: ctx}); err == nil && name != "" {
+			return name
+		}
+	}
+	return priceFeeds[asset.Hex()].name
+}
@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"simulated"
+)
+
+func TestPriceDivergence(t *testing.T) {
+	// 0.0005 ETH in wei, as reported by the AAVE oracle.
+	oracle := big.NewInt(5e14)
+	for _, tc := range []struct {
+		price, factor *big.Int
+		want          float64
+	}{
+		// The same price from a Chainlink feed with 18 decimals.
+		{big.NewInt(5e14), big.NewInt(1e18), 0},
+		// A feed with 8 decimals reporting 10% more.
+		{big.NewInt(55000), big.NewInt(1e8), 0.1},
+	} {
+		got := priceDivergence(oracle, oracleFactor, tc.price, tc.factor)
+		if got < tc.want-1e-9 || got > tc.want+1e-9 {
+			t.Errorf("priceDivergence(%v, %v, %v, %v) = %v, want %v",
+				oracle, oracleFactor, tc.price, tc.factor, got, tc.want)
+		}
+	}
+	if got := priceDivergence(big.NewInt(0), oracleFactor, oracle, oracleFactor); !math.IsInf(got, 1) {
+		t.Errorf("priceDivergence with a zero price = %v, want +Inf", got)
+	}
+}
+
+func TestPriceOfRounds(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	// Lists the Dai feed of the chain in the Chainlink table with an hour-long heartbeat.
+	dai, feed := chain.DAI.Asset.Address.Hex(), chain.DAI.Feed.Address
+	priceFeeds[dai] = aggregatorEntry{name: "Dai", aggregator: &feed, heartbeat: time.Hour}
+	defer delete(priceFeeds, dai)
+	c, err := NewClientWithBackend(chain.Params, chain.Backend())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	price, double := simulated.DAIPrice, new(big.Int).Mul(simulated.DAIPrice, big.NewInt(2))
+	for _, tc := range []struct {
+		desc              string
+		round, answeredIn int64
+		answer            *big.Int
+		updated           time.Time
+		// prev is the answer of the previous round, which has no data if nil.
+		prev *big.Int
+		// oracle is the price of the AAVE oracle, which is also the price returned.
+		oracle  *big.Int
+		wantErr bool
+	}{
+		{"fresh", 2, 2, price, now, price, price, false},
+		{"within the grace period", 2, 2, price, now.Add(-time.Hour - time.Minute), price, price, false},
+		{"stale", 2, 2, price, now.Add(-2 * time.Hour), price, price, true},
+		{"not positive", 2, 2, new(big.Int), now, price, price, true},
+		{"incomplete", 2, 2, price, time.Unix(0, 0), price, price, true},
+		{"carried over", 3, 2, price, now, price, price, true},
+		{"jump from the previous round", 4, 4, double, now, price, price, true},
+		{"small move from the previous round", 5, 5, price, now, new(big.Int).Add(price, big.NewInt(1)), price,
+			false},
+		// A crash the oracle follows is accepted, and later rounds are checked against it.
+		{"jump confirmed by the oracle", 6, 6, double, now, price, double, false},
+		{"round after the confirmed jump", 7, 7, double, now, nil, double, false},
+		{"no previous round", 100, 100, double, now, nil, price, false},
+	} {
+		if err := chain.Market.Oracle.Returns(ctx, "getAssetPrice", []interface{}{chain.DAI.Asset.Address},
+			tc.oracle); err != nil {
+			t.Fatal(err)
+		}
+		updated := big.NewInt(tc.updated.Unix())
+		if err := chain.DAI.Feed.Returns(ctx, "latestRoundData", nil, big.NewInt(tc.round), tc.answer,
+			updated, updated, big.NewInt(tc.answeredIn)); err != nil {
+			t.Fatal(err)
+		}
+		if tc.prev != nil {
+			prev := big.NewInt(tc.round - 1)
+			if err := chain.DAI.Feed.Returns(ctx, "getRoundData", []interface{}{prev}, prev, tc.prev, updated,
+				updated, prev); err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, _, err := c.PriceOf(ctx, dai)
+		var roundErr *RoundError
+		if tc.wantErr {
+			if !errors.As(err, &roundErr) {
+				t.Errorf("%s: PriceOf(Dai) = _, _, %v, want a *RoundError", tc.desc, err)
+			}
+			continue
+		}
+		if err != nil || got.Cmp(tc.oracle) != 0 {
+			t.Errorf("%s: PriceOf(Dai) = %v, _, %v, want %v, _, nil", tc.desc, got, err, tc.oracle)
+		}
+	}
+
+	// The decimals of the feed are only read once.
+	if err := chain.DAI.Feed.Reverts(ctx, "decimals", nil, "gone"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.chainlinkPrice(ctx, dai, feed); err != nil {
+		t.Errorf("chainlinkPrice(Dai) with decimals reverting = _, _, %v, want _, _, nil", err)
+	}
+}
+
+func TestPriceOfUnlistedFeed(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	c, err := NewClientWithBackend(chain.Params, chain.Backend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Dai of the chain isn't in the Chainlink table, so its feed is the oracle's source and is
+	// held to the default heartbeat.
+	dai := chain.DAI.Asset.Address.Hex()
+	now := time.Now()
+	price := simulated.DAIPrice
+	for _, tc := range []struct {
+		desc    string
+		round   int64
+		answer  *big.Int
+		updated time.Time
+		wantErr bool
+	}{
+		{"fresh", 2, price, now, false},
+		{"stale", 3, price, now.Add(-c.FeedHeartbeat() - time.Hour), true},
+		{"not positive", 4, big.NewInt(-1), now, true},
+	} {
+		updated := big.NewInt(tc.updated.Unix())
+		if err := chain.DAI.Feed.Returns(ctx, "latestRoundData", nil, big.NewInt(tc.round), tc.answer, updated,
+			updated, big.NewInt(tc.round)); err != nil {
+			t.Fatal(err)
+		}
+		_, _, err := c.PriceOf(ctx, dai)
+		var roundErr *RoundError
+		if tc.wantErr != errors.As(err, &roundErr) {
+			t.Errorf("%s: PriceOf(Dai) = _, _, %v, want a *RoundError: %t", tc.desc, err, tc.wantErr)
+		}
+	}
+}
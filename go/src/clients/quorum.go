@@ -0,0 +1,128 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"aaveoracle"
+	"aggregator"
+	"erc20"
+)
+
+// QuorumCaller is a backend that can require several nodes to agree on a call. `*pool.Pool`
+// implements it.
+type QuorumCaller interface {
+	QuorumCall(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
+	// Quorum is the number of nodes that must agree.
+	Quorum() int
+}
+
+// quorumContract makes the contract calls of bindings through a quorum of nodes.
+type quorumContract struct {
+	Backend
+	q QuorumCaller
+}
+
+// CallContract ignores `blockNumber` since the quorum chooses the block all nodes answer at.
+func (c *quorumContract) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte,
+	error) {
+	return c.q.QuorumCall(ctx, call)
+}
+
+// ErrUnconfirmed is returned by `ConfirmLoan` when the loan's ratio computed from the values a
+// quorum of nodes agree on doesn't reach the threshold.
+var ErrUnconfirmed = errors.New("quorum doesn't confirm the ratio")
+
+// ConfirmLoan checks that a quorum of nodes agree on the token balances and the prices of the loan,
+// which decide whether it's repaid, and that the ratio computed from them reaches `threshold`, in
+// units of 1/10000. This way a single faulty node can't trigger a repayment. It does nothing unless
+// the client's backend requires a quorum of more than one node.
+func (c *Client) ConfirmLoan(ctx context.Context, loan *Loan, threshold uint16) error {
+	q, ok := c.eth.(QuorumCaller)
+	if !ok || q.Quorum() <= 1 {
+		return nil
+	}
+	caller := &quorumContract{Backend: c.eth, q: q}
+	opts := &bind.CallOpts{Context: ctx}
+	amounts, err := loan.amounts(func(token common.Address) (*big.Int, error) {
+		t, err := erc20.NewErc20Caller(token, caller)
+		if err != nil {
+			return nil, fmt.Errorf("creating token %v: %w", token, err)
+		}
+		return t.BalanceOf(opts, loan.User)
+	}, func(r *Reserve) (*big.Int, *big.Int, error) {
+		return c.quorumPrice(ctx, caller, r)
+	})
+	if err != nil {
+		return fmt.Errorf("confirming loan of %v: %w", loan.User, err)
+	}
+	if ratio := amounts.Ratio(); ratio < threshold {
+		return fmt.Errorf("%w: ratio of %v is %d, below the threshold of %d", ErrUnconfirmed, loan.User, ratio,
+			threshold)
+	}
+	return nil
+}
+
+// quorumPrice returns the price of the reserve asset and the factor to divide it by from the
+// values a quorum of nodes agree on. The latest round of the asset's Chainlink feed must pass
+// `checkRound` or be confirmed by the AAVE oracle (see `confirmRound`). Assets without a feed are
+// priced by the AAVE oracle.
+func (c *Client) quorumPrice(ctx context.Context, caller *quorumContract, r *Reserve) (*big.Int, *big.Int,
+	error) {
+	opts := &bind.CallOpts{Context: ctx}
+	feed, ok, err := c.PriceFeed(ctx, r.Asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		if r.Asset == c.WETH9Address() {
+			return big.NewInt(1), big.NewInt(1), nil
+		}
+		price, err := c.quorumOraclePrice(ctx, caller, r)
+		return price, oracleFactor, err
+	}
+	agg, err := aggregator.NewAggregator(feed, caller)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating aggregator %v: %w", feed, err)
+	}
+	decimals, err := agg.Decimals(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("confirming %v price decimals from %v: %w", r, feed, err)
+	}
+	data, err := agg.LatestRoundData(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("confirming %v price from %v: %w", r, feed, err)
+	}
+	price, factor, err := c.roundPrice(ctx, r.Asset.Hex(), feed, agg, decimals, data)
+	if err != nil {
+		if oraclePrice, oracleErr := c.quorumOraclePrice(ctx, caller, r); oracleErr == nil &&
+			c.confirmRound(r.Asset.Hex(), oraclePrice, err) {
+			return oraclePrice, oracleFactor, nil
+		}
+	}
+	return price, factor, err
+}
+
+// quorumOraclePrice returns the AAVE oracle's price of the reserve asset that a quorum of nodes
+// agree on.
+func (c *Client) quorumOraclePrice(ctx context.Context, caller *quorumContract, r *Reserve) (*big.Int, error) {
+	_, oracleAddr, err := c.priceOracle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := aaveoracle.NewAaveoracleCaller(oracleAddr, caller)
+	if err != nil {
+		return nil, fmt.Errorf("creating price oracle %v: %w", oracleAddr, err)
+	}
+	price, err := oracle.GetAssetPrice(&bind.CallOpts{Context: ctx}, r.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("confirming %v oracle price: %w", r, err)
+	}
+	return checkOraclePrice(r.Asset, price)
+}
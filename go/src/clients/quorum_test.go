@@ -0,0 +1,125 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"pool"
+	"simulated"
+)
+
+// corrupting is a node returning corrupt results for calls to `target`.
+type corrupting struct {
+	pool.Backend
+	target common.Address
+}
+
+func (c *corrupting) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte,
+	error) {
+	out, err := c.Backend.CallContract(ctx, call, blockNumber)
+	if err == nil && call.To != nil && *call.To == c.target && len(out) > 0 {
+		out = append([]byte(nil), out...)
+		out[len(out)-1]++
+	}
+	return out, err
+}
+
+// lying is a node doubling the amounts returned by calls to `target`.
+type lying struct {
+	pool.Backend
+	target common.Address
+}
+
+func (l *lying) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	out, err := l.Backend.CallContract(ctx, call, blockNumber)
+	if err == nil && call.To != nil && *call.To == l.target && len(out) == 32 {
+		out = common.BigToHash(new(big.Int).Lsh(new(big.Int).SetBytes(out), 1)).Bytes()
+	}
+	return out, err
+}
+
+func TestConfirmLoan(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+	// The loan's ratio is 0.5.
+	for _, tc := range []struct {
+		desc      string
+		quorum    int
+		target    common.Address
+		threshold uint16
+		wantErr   error
+	}{
+		{"agreeing nodes", 2, common.Address{}, 5000, nil},
+		{"agreeing nodes below the threshold", 2, common.Address{}, 5001, ErrUnconfirmed},
+		{"corrupt balance", 2, chain.Market.WETH.AToken.Address, 5000, pool.ErrDisagreement},
+		{"corrupt price", 2, chain.DAI.Feed.Address, 5000, pool.ErrDisagreement},
+		{"no quorum", 1, chain.Market.WETH.AToken.Address, 6000, nil},
+	} {
+		p := pool.New(pool.Config{Quorum: tc.quorum},
+			pool.Endpoint{Name: "honest", Backend: chain.Backend()},
+			pool.Endpoint{Name: "corrupt", Backend: &corrupting{Backend: chain.Backend(), target: tc.target}})
+		c, err := NewClientWithBackend(chain.Params, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loan, err := c.Loan(ctx, chain.User.Address())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.ConfirmLoan(ctx, loan, tc.threshold); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: ConfirmLoan(...) = %v, want %v", tc.desc, err, tc.wantErr)
+		}
+	}
+}
+
+func TestConfirmLoanOutvotesLyingNode(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+	liar := &lying{Backend: chain.Backend(), target: chain.DAI.VariableDebt.Address}
+
+	// Read from the lying node alone, the debt doubles and the loan crosses the threshold.
+	c, err := NewClientWithBackend(chain.Params, liar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loan, err := c.Loan(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	amounts, err := loan.Data(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ratio := amounts.Ratio(); ratio != 10000 {
+		t.Fatalf("ratio read from the lying node = %d, want 10000", ratio)
+	}
+
+	// The two honest nodes agree on the actual ratio of 0.5, so the repayment is refused.
+	p := pool.New(pool.Config{Quorum: 2},
+		pool.Endpoint{Name: "honest", Backend: chain.Backend()},
+		pool.Endpoint{Name: "also honest", Backend: chain.Backend()},
+		pool.Endpoint{Name: "lying", Backend: liar})
+	if c, err = NewClientWithBackend(chain.Params, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ConfirmLoan(ctx, loan, 8000); !errors.Is(err, ErrUnconfirmed) {
+		t.Errorf("ConfirmLoan(...) = %v, want %v", err, ErrUnconfirmed)
+	}
+}
@@ -7,54 +7,169 @@ package delegation
 import (
 	"bytes"
 	"fmt"
+	"log"
+	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core"
+
+	"env"
+)
+
+const (
+	// DefaultVersion and DefaultSalt are the domain values the repayment contract is deployed with
+	// unless others are configured.
+	DefaultVersion = "1"
+	DefaultSalt    = "SU%N6gmumvj.A{@B,SdWXtVgg(Bof9SA"
+
+	domainName = "AAVE Liquidation Protection Bot"
 )
 
+var (
+	// packArgs is the layout of the delegation passed to the repayment contract.
+	packArgs abi.Arguments
+)
+
+func init() {
+	uintT, err := abi.NewType("uint256", "", nil)
+	if err != nil {
+		log.Fatalf("Error creating uint256 ABI type: %v", err)
+	}
+	bytesT, err := abi.NewType("bytes", "", nil)
+	if err != nil {
+		log.Fatalf("Error creating bytes ABI type: %v", err)
+	}
+	packArgs = abi.Arguments{
+		{Name: "deadline", Type: uintT},
+		{Name: "nonce", Type: uintT},
+		{Name: "maxCollateral", Type: uintT},
+		{Name: "signature", Type: bytesT},
+	}
+}
+
+// Domain configures the EIP-712 domain of certificates. It must match the domain the repayment
+// contract verifies against.
+type Domain struct {
+	ChainID *big.Int
+	// VerifyingContract is the repayment contract, so certificates don't verify in any other
+	// deployment.
+	VerifyingContract common.Address
+	Version           string
+	Salt              string
+}
+
+// DomainFor returns the domain configured by the given parameters, with defaults for unset
+// values.
+func DomainFor(params env.Params) Domain {
+	d := Domain{
+		ChainID:           params.ChainID(),
+		VerifyingContract: params.RepaymentExecutor(),
+		Version:           params.DelegationVersion(),
+		Salt:              params.DelegationSalt(),
+	}
+	if d.Version == "" {
+		d.Version = DefaultVersion
+	}
+	if d.Salt == "" {
+		d.Salt = DefaultSalt
+	}
+	return d
+}
+
+// Terms limit what a certificate allows the delegate to do.
+type Terms struct {
+	// Deadline is the Unix time in seconds after which the certificate is no longer valid.
+	Deadline uint64
+	// Nonce identifies the certificate for revocation. Revoking a nonce, through the bot or the
+	// repayment contract's `revoke`, revokes all certificates with the same or lower nonces.
+	Nonce uint64
+	// MaxCollateral is the maximum value of collateral in wei the delegate may sell in one
+	// repayment. The repayment contract checks it at the AAVE oracle price. Zero (or nil) means
+	// there is no limit.
+	MaxCollateral *big.Int
+}
+
+// Expired returns true if the deadline has passed at `now`.
+func (t *Terms) Expired(now time.Time) bool {
+	return uint64(now.Unix()) > t.Deadline
+}
+
+// AllowsCollateral returns true if the delegate may sell collateral worth `value` wei.
+func (t *Terms) AllowsCollateral(value *big.Int) bool {
+	return t.MaxCollateral == nil || t.MaxCollateral.Sign() == 0 || value.Cmp(t.MaxCollateral) <= 0
+}
+
 type Certificate struct {
 	delegate common.Address
+	terms    Terms
 	data     *core.TypedData
 	hash     common.Hash
 }
 
-// New generates a new certificate for the given delegate.
-func New(delegate common.Address) (*Certificate, error) {
+// New generates a new certificate for the given delegate in the given domain.
+func New(delegate common.Address, domain Domain, terms Terms) (*Certificate, error) {
+	if terms.MaxCollateral == nil {
+		terms.MaxCollateral = new(big.Int)
+	}
+	data, hash, err := newTypedData(domain, "Delegate", []core.Type{
+		{Name: "delegate", Type: "address"},
+		{Name: "deadline", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "maxCollateral", Type: "uint256"},
+	}, core.TypedDataMessage{
+		"delegate":      delegate.Hex(),
+		"deadline":      new(big.Int).SetUint64(terms.Deadline).String(),
+		"nonce":         new(big.Int).SetUint64(terms.Nonce).String(),
+		"maxCollateral": terms.MaxCollateral.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating cert for %v: %w", delegate, err)
+	}
+	return &Certificate{
+		delegate: delegate,
+		terms:    terms,
+		data:     data,
+		hash:     hash,
+	}, nil
+}
+
+// newTypedData builds typed data with a single message type in the given domain and computes its
+// hash.
+func newTypedData(domain Domain, primaryType string, fields []core.Type,
+	message core.TypedDataMessage) (*core.TypedData, common.Hash, error) {
+	if domain.ChainID == nil {
+		return nil, common.Hash{}, fmt.Errorf("no chain ID")
+	}
 	data := &core.TypedData{
 		Types: core.Types{
-			"Delegate": []core.Type{
-				{Name: "delegate", Type: "address"},
-			},
+			primaryType: fields,
 			"EIP712Domain": []core.Type{
 				{Name: "name", Type: "string"},
 				{Name: "version", Type: "string"},
 				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
 				{Name: "salt", Type: "string"},
 			},
 		},
-		PrimaryType: "Delegate",
+		PrimaryType: primaryType,
 		Domain: core.TypedDataDomain{
-			Name:    "AAVE Liquidation Protection Bot",
-			Version: "1",
-			ChainId: math.NewHexOrDecimal256(1337), // Mainnet
-			Salt:    "SU%N6gmumvj.A{@B,SdWXtVgg(Bof9SA",
-		},
-		Message: core.TypedDataMessage{
-			"delegate": delegate.Hex(),
+			Name:              domainName,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(new(big.Int).Set(domain.ChainID)),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+			Salt:              domain.Salt,
 		},
+		Message: message,
 	}
-
 	hash, err := computeHash(data)
 	if err != nil {
-		return nil, fmt.Errorf("generating cert for %v: %w", delegate, err)
+		return nil, common.Hash{}, err
 	}
-	return &Certificate{
-		delegate: delegate,
-		data:     data,
-		hash:     hash,
-	}, nil
+	return data, hash, nil
 }
 
 // TypedData returns the typed data needing a signature.
@@ -67,6 +182,49 @@ func (c *Certificate) Hash() common.Hash {
 	return c.hash
 }
 
+// Terms returns the terms of the certificate.
+func (c *Certificate) Terms() Terms {
+	return c.terms
+}
+
+// Verify returns the address that produced the signature `sig` of the certificate. Both the
+// 27/28 recovery IDs produced by wallets and the raw 0/1 ones are accepted.
+func (c *Certificate) Verify(sig []byte) (common.Address, error) {
+	return recoverSigner(c.hash, sig)
+}
+
+// Pack encodes the terms along with the user's signature `sig` for the repayment contract, which
+// verifies them on execution.
+func (c *Certificate) Pack(sig []byte) ([]byte, error) {
+	packed, err := packArgs.Pack(new(big.Int).SetUint64(c.terms.Deadline),
+		new(big.Int).SetUint64(c.terms.Nonce), c.terms.MaxCollateral, sig)
+	if err != nil {
+		return nil, fmt.Errorf("packing delegation: %w", err)
+	}
+	return packed, nil
+}
+
+func recoverSigner(hash common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature has %d bytes, want %d", len(sig), crypto.SignatureLength)
+	}
+	// crypto.Ecrecover expects 0, 1 instead of 27 or 28. The signature is copied so the caller's
+	// isn't modified.
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	rpk, err := crypto.Ecrecover(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("recovering signer: %w", err)
+	}
+	pubKey, err := crypto.UnmarshalPubkey(rpk)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("unmarshalling public key: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
 func computeHash(td *core.TypedData) (common.Hash, error) {
 	domainHash, err := td.HashStruct("EIP712Domain", td.Domain.Map())
 	if err != nil {
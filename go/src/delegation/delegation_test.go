@@ -0,0 +1,104 @@
+package delegation
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := crypto.PubkeyToAddress(key.PublicKey)
+	bot := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	executor := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	cert, err := New(bot, Domain{ChainID: big.NewInt(1), VerifyingContract: executor, Version: DefaultVersion,
+		Salt: DefaultSalt}, Terms{})
+	if err != nil {
+		t.Fatalf("New(...) = _, %v, want _, nil", err)
+	}
+	sig, err := crypto.Sign(cert.Hash().Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wallets produce recovery IDs of 27 or 28.
+	sig[64] += 27
+
+	signer, err := cert.Verify(sig)
+	if err != nil || signer != user {
+		t.Fatalf("Verify(...) = %v, %v, want %v, nil", signer, err, user)
+	}
+	if sig[64] < 27 {
+		t.Errorf("Verify modified the signature")
+	}
+	if _, err := cert.Verify(sig[:64]); err == nil {
+		t.Errorf("Verify(...) of a short signature = _, nil, want error")
+	}
+
+	// Certificates for other chains, contracts or contract domains don't verify as the user.
+	for _, d := range []Domain{
+		{ChainID: big.NewInt(137), VerifyingContract: executor, Version: DefaultVersion, Salt: DefaultSalt},
+		{ChainID: big.NewInt(1), VerifyingContract: bot, Version: DefaultVersion, Salt: DefaultSalt},
+		{ChainID: big.NewInt(1), VerifyingContract: executor, Version: "2", Salt: DefaultSalt},
+	} {
+		other, err := New(bot, d, Terms{})
+		if err != nil {
+			t.Fatalf("New(...) = _, %v, want _, nil", err)
+		}
+		if signer, err := other.Verify(sig); err == nil && signer == user {
+			t.Errorf("signature verified for domain %+v", d)
+		}
+	}
+}
+
+func TestTerms(t *testing.T) {
+	now := time.Unix(1000, 0)
+	terms := Terms{Deadline: 1000, MaxCollateral: big.NewInt(5)}
+	if terms.Expired(now) {
+		t.Errorf("Expired at the deadline = true, want false")
+	}
+	if !terms.Expired(now.Add(time.Second)) {
+		t.Errorf("Expired after the deadline = false, want true")
+	}
+	if !terms.AllowsCollateral(big.NewInt(5)) || terms.AllowsCollateral(big.NewInt(6)) {
+		t.Errorf("AllowsCollateral doesn't match the limit of 5")
+	}
+	if unlimited := (Terms{}); !unlimited.AllowsCollateral(big.NewInt(1e18)) {
+		t.Errorf("AllowsCollateral without a limit = false, want true")
+	}
+}
+
+func TestRevocation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := crypto.PubkeyToAddress(key.PublicKey)
+	bot := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	domain := Domain{ChainID: big.NewInt(1), Version: DefaultVersion, Salt: DefaultSalt}
+	rev, err := NewRevocation(bot, domain, 3)
+	if err != nil {
+		t.Fatalf("NewRevocation(...) = _, %v, want _, nil", err)
+	}
+	sig, err := crypto.Sign(rev.hash.Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer, err := rev.Verify(sig); err != nil || signer != user {
+		t.Fatalf("Verify(...) = %v, %v, want %v, nil", signer, err, user)
+	}
+
+	// A revocation signature can't be used as a certificate signature.
+	cert, err := New(bot, domain, Terms{Nonce: 3})
+	if err != nil {
+		t.Fatalf("New(...) = _, %v, want _, nil", err)
+	}
+	if signer, err := cert.Verify(sig); err == nil && signer == user {
+		t.Errorf("revocation signature verified as a certificate")
+	}
+}
@@ -0,0 +1,54 @@
+package delegation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// Revocation is a message revoking all certificates for a delegate up to a nonce. Users sign it to
+// stop the delegate from acting on their behalf.
+type Revocation struct {
+	delegate common.Address
+	nonce    uint64
+	data     *core.TypedData
+	hash     common.Hash
+}
+
+// NewRevocation generates a revocation of the certificates for `delegate` with nonces up to and
+// including `nonce`.
+func NewRevocation(delegate common.Address, domain Domain, nonce uint64) (*Revocation, error) {
+	data, hash, err := newTypedData(domain, "Revoke", []core.Type{
+		{Name: "delegate", Type: "address"},
+		{Name: "nonce", Type: "uint256"},
+	}, core.TypedDataMessage{
+		"delegate": delegate.Hex(),
+		"nonce":    new(big.Int).SetUint64(nonce).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating revocation for %v: %w", delegate, err)
+	}
+	return &Revocation{
+		delegate: delegate,
+		nonce:    nonce,
+		data:     data,
+		hash:     hash,
+	}, nil
+}
+
+// TypedData returns the typed data needing a signature.
+func (r *Revocation) TypedData() *core.TypedData {
+	return r.data
+}
+
+// Nonce returns the highest revoked nonce.
+func (r *Revocation) Nonce() uint64 {
+	return r.nonce
+}
+
+// Verify returns the address that produced the signature `sig` of the revocation.
+func (r *Revocation) Verify(sig []byte) (common.Address, error) {
+	return recoverSigner(r.hash, sig)
+}
@@ -2,27 +2,79 @@
 package env
 
 import (
-	"github.com/ethereum/go-ethereum/common"
-)
-
-const (
-	ethURI = "ws://localhost:8545"
-	// The keys here are stable values used by local hardhat nodes.
-	botKey  = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
-	userKey = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
-)
+	"math/big"
+	"time"
 
-var (
-	lendingPoolAddress = common.HexToAddress("0x7d2768dE32b0b80b7a3454c06BdAc94A69DDc7A9")
-	weth9Address       = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
-	daiAddress         = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Params encapsulates environmental parameters.
 type Params interface {
+	// ETHURI is the primary node URI. It's the first of `ETHURIs`.
 	ETHURI() string
+	ETHURIs() []string
+	// RPCQuorum is the number of nodes among `ETHURIs` that must agree on the balances and prices
+	// of a loan before it's repaid. It's 1 unless set.
+	RPCQuorum() int
+	ChainID() *big.Int
+	// Signer selects how the bot signs: "key" with `BotKey`, "keystore" with the key file at
+	// `KeystorePath` unlocked by `KeystorePassphrase`, or "external" with the Clef compatible
+	// signer at `SignerURL` signing for `BotAddress`.
+	Signer() string
 	BotKey() string
+	KeystorePath() string
+	KeystorePassphrase() string
+	SignerURL() string
+	BotAddress() common.Address
 	LendingPoolAddress() common.Address
+	// MulticallAddress is the Multicall contract through which loans are read in a few calls per
+	// block. A zero address reads them one call at a time.
+	MulticallAddress() common.Address
+	// MulticallBatchSize is the most contract calls made in one Multicall call, which keeps batches
+	// under the node's eth_call gas cap.
+	MulticallBatchSize() int
+
+	// DelegationVersion and DelegationSalt configure the EIP-712 domain of delegation
+	// certificates. The repayment contract is deployed with them. Empty values use the contract's
+	// defaults.
+	DelegationVersion() string
+	DelegationSalt() string
+	// RepaymentExecutor is the deployed repayment contract, which is the verifying contract of
+	// the domain. Certificates only verify in the contract they were signed for.
+	RepaymentExecutor() common.Address
+
+	// MaxPriceImpact is the largest fraction of value a collateral swap may lose compared to oracle
+	// prices, such as 0.03 for 3%.
+	MaxPriceImpact() float64
+	// MaxRoundDeviation is the largest fraction a Chainlink answer may move from the feed's previous
+	// round before it's rejected as anomalous, such as 0.2 for 20%.
+	MaxRoundDeviation() float64
+	// FeedHeartbeat is the heartbeat assumed for Chainlink feeds the bot has no heartbeat for. Older
+	// rounds are stale.
+	FeedHeartbeat() time.Duration
+	// DryRun makes the service simulate and log repayments instead of broadcasting them.
+	DryRun() bool
+	// Confirmations is the number of blocks, including the one with a transaction, to wait for
+	// before considering it final.
+	Confirmations() uint64
+	// GasStrategy selects how transaction fees are chosen: "fee-history", "suggested" or "static".
+	GasStrategy() string
+	// GasPrice is the gas price in wei of the "static" gas strategy, or nil for other strategies.
+	GasPrice() *big.Int
+	// MaxFeePerGas is the most any transaction pays per gas in wei.
+	MaxFeePerGas() *big.Int
+	// MinBotBalance is the bot balance in wei below which new registrations are refused.
+	MinBotBalance() *big.Int
+	// BotBalanceAlerts are the bot balances in wei below which alerts are raised.
+	BotBalanceAlerts() []*big.Int
+
+	// ListenAddress is the address the service listens on, such as ":3000".
+	ListenAddress() string
+	// UIRoot is the root path to the statically served UI files.
+	UIRoot() string
+	// StorePath is the file registrations are persisted to so that monitoring resumes after a
+	// restart.
+	StorePath() string
 
 	// TODO(greatfilter): the parameters below are only used in testing. Factor this out?
 	UserKey() string
@@ -30,32 +82,183 @@ type Params interface {
 	DaiAddress() common.Address
 }
 
-type localTestNet struct{}
+// config is the validated form of a profile.
+type config struct {
+	ethURIs            []string
+	rpcQuorum          int
+	chainID            *big.Int
+	signer             string
+	botKey             string
+	keystorePath       string
+	keystorePassphrase string
+	signerURL          string
+	botAddress         common.Address
+	lendingPool        common.Address
+	multicall          common.Address
+	multicallBatchSize int
+	delegationVersion  string
+	delegationSalt     string
+	repaymentExecutor  common.Address
+	maxPriceImpact     float64
+	maxRoundDeviation  float64
+	feedHeartbeat      time.Duration
+	dryRun             bool
+	confirmations      uint64
+	gasStrategy        string
+	gasPrice           *big.Int
+	maxFeePerGas       *big.Int
+	minBotBalance      *big.Int
+	botBalanceAlerts   []*big.Int
+	listenAddress      string
+	uiRoot             string
+	storePath          string
+	userKey            string
+	weth9              common.Address
+	dai                common.Address
+}
 
+// LocalTestNet returns the parameters of a local hardhat node forking mainnet, which is the
+// built-in "local-fork" profile.
 func LocalTestNet() Params {
-	return &localTestNet{}
+	p := builtinProfiles[LocalFork]
+	c, err := p.validate()
+	if err != nil {
+		panic(err) // The built-in profile is complete.
+	}
+	return c
+}
+
+func (c *config) ETHURI() string {
+	return c.ethURIs[0]
+}
+
+func (c *config) ETHURIs() []string {
+	return c.ethURIs
+}
+
+func (c *config) RPCQuorum() int {
+	return c.rpcQuorum
+}
+
+func (c *config) ChainID() *big.Int {
+	return new(big.Int).Set(c.chainID)
+}
+
+func (c *config) Signer() string {
+	return c.signer
+}
+
+func (c *config) BotKey() string {
+	return c.botKey
+}
+
+func (c *config) KeystorePath() string {
+	return c.keystorePath
+}
+
+func (c *config) KeystorePassphrase() string {
+	return c.keystorePassphrase
+}
+
+func (c *config) SignerURL() string {
+	return c.signerURL
+}
+
+func (c *config) BotAddress() common.Address {
+	return c.botAddress
+}
+
+func (c *config) LendingPoolAddress() common.Address {
+	return c.lendingPool
+}
+
+func (c *config) MulticallAddress() common.Address {
+	return c.multicall
+}
+
+func (c *config) MulticallBatchSize() int {
+	return c.multicallBatchSize
+}
+
+func (c *config) DelegationVersion() string {
+	return c.delegationVersion
+}
+
+func (c *config) DelegationSalt() string {
+	return c.delegationSalt
+}
+
+func (c *config) RepaymentExecutor() common.Address {
+	return c.repaymentExecutor
+}
+
+func (c *config) MaxPriceImpact() float64 {
+	return c.maxPriceImpact
+}
+
+func (c *config) MaxRoundDeviation() float64 {
+	return c.maxRoundDeviation
+}
+
+func (c *config) FeedHeartbeat() time.Duration {
+	return c.feedHeartbeat
+}
+
+func (c *config) DryRun() bool {
+	return c.dryRun
+}
+
+func (c *config) Confirmations() uint64 {
+	return c.confirmations
+}
+
+func (c *config) GasStrategy() string {
+	return c.gasStrategy
+}
+
+func (c *config) GasPrice() *big.Int {
+	if c.gasPrice == nil {
+		return nil
+	}
+	return new(big.Int).Set(c.gasPrice)
+}
+
+func (c *config) MaxFeePerGas() *big.Int {
+	return new(big.Int).Set(c.maxFeePerGas)
+}
+
+func (c *config) MinBotBalance() *big.Int {
+	return new(big.Int).Set(c.minBotBalance)
+}
+
+func (c *config) BotBalanceAlerts() []*big.Int {
+	ret := make([]*big.Int, len(c.botBalanceAlerts))
+	for i, a := range c.botBalanceAlerts {
+		ret[i] = new(big.Int).Set(a)
+	}
+	return ret
 }
 
-func (_ *localTestNet) ETHURI() string {
-	return "ws://localhost:8545"
+func (c *config) ListenAddress() string {
+	return c.listenAddress
 }
 
-func (_ *localTestNet) BotKey() string {
-	return "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+func (c *config) UIRoot() string {
+	return c.uiRoot
 }
 
-func (_ *localTestNet) UserKey() string {
-	return "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+func (c *config) StorePath() string {
+	return c.storePath
 }
 
-func (_ *localTestNet) LendingPoolAddress() common.Address {
-	return lendingPoolAddress
+func (c *config) UserKey() string {
+	return c.userKey
 }
 
-func (_ *localTestNet) WETH9Address() common.Address {
-	return weth9Address
+func (c *config) WETH9Address() common.Address {
+	return c.weth9
 }
 
-func (_ *localTestNet) DaiAddress() common.Address {
-	return daiAddress
+func (c *config) DaiAddress() common.Address {
+	return c.dai
 }
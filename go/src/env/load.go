@@ -0,0 +1,626 @@
+package env
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/yaml.v2"
+)
+
+// Gas strategies.
+const (
+	// GasFeeHistory derives fees from recent blocks, scaled by the urgency of transactions.
+	GasFeeHistory = "fee-history"
+	// GasSuggested uses the node's suggestions.
+	GasSuggested = "suggested"
+	// GasStatic uses `GasPrice` for every transaction.
+	GasStatic = "static"
+)
+
+// Bot signers.
+const (
+	// SignerKey signs with the private key in `BotKey`. It's meant for local nodes.
+	SignerKey = "key"
+	// SignerKeystore signs with the encrypted key file at `KeystorePath`.
+	SignerKeystore = "keystore"
+	// SignerExternal signs through a Clef compatible signer at `SignerURL`.
+	SignerExternal = "external"
+)
+
+// Names of the built-in profiles.
+const (
+	Mainnet   = "mainnet"
+	Polygon   = "polygon"
+	LocalFork = "local-fork"
+)
+
+const (
+	// envPrefix prefixes the environment variables overriding profile fields, such as
+	// AAVE_BOT_LENDING_POOL for `lending-pool`.
+	envPrefix = "AAVE_BOT_"
+	// ProfileVar selects the profile if `Load` isn't given one.
+	ProfileVar = envPrefix + "PROFILE"
+
+	defaultListenAddress  = ":3000"
+	defaultUIRoot         = "ui/dist"
+	defaultStorePath      = "registrations.json"
+	defaultMaxPriceImpact = 0.03
+	defaultMaxDeviation   = 0.2
+	defaultFeedHeartbeat  = 24 * time.Hour
+	defaultConfirmations  = 1
+	defaultGasStrategy    = GasFeeHistory
+	defaultMaxFeeGwei     = 500
+	defaultMinBotBalance  = 0.05
+
+	// defaultMulticallBatchSize keeps a batch of balance and price reads well under the 50M gas cap
+	// geth puts on eth_call by default.
+	defaultMulticallBatchSize = 500
+)
+
+// defaultBotBalanceAlerts are the bot balances in ETH below which alerts are raised.
+var defaultBotBalanceAlerts = []float64{0.5, 0.1}
+
+// profile holds the parameters as written in a config file. Values are kept as strings until
+// validation so errors can name the offending field.
+type profile struct {
+	ETHURIs []string `yaml:"eth-uris" toml:"eth-uris"`
+	// RPCQuorum is the number of nodes among `ETHURIs` that must agree on a loan before it's
+	// repaid.
+	RPCQuorum uint64 `yaml:"rpc-quorum" toml:"rpc-quorum"`
+	ChainID   uint64 `yaml:"chain-id" toml:"chain-id"`
+	// Signer is one of "key", "keystore" and "external". It defaults to "keystore" if a keystore
+	// is set and to "key" otherwise.
+	Signer       string `yaml:"signer" toml:"signer"`
+	BotKey       string `yaml:"bot-key" toml:"bot-key"`
+	KeystorePath string `yaml:"keystore" toml:"keystore"`
+	// KeystorePassphrase unlocks the keystore. It's only read from the environment so it doesn't
+	// end up in config files.
+	KeystorePassphrase string `yaml:"-" toml:"-"`
+	// SignerURL is the endpoint of the external signer and BotAddress the account it signs for.
+	SignerURL   string `yaml:"signer-url" toml:"signer-url"`
+	BotAddress  string `yaml:"bot-address" toml:"bot-address"`
+	LendingPool string `yaml:"lending-pool" toml:"lending-pool"`
+	// Multicall is the Multicall2 or Multicall3 contract loans are read through.
+	Multicall string `yaml:"multicall" toml:"multicall"`
+	// MulticallBatchSize is the most calls made through `Multicall` at once.
+	MulticallBatchSize uint64 `yaml:"multicall-batch-size" toml:"multicall-batch-size"`
+	// The EIP-712 domain of delegation certificates.
+	DelegationVersion string `yaml:"delegation-version" toml:"delegation-version"`
+	DelegationSalt    string `yaml:"delegation-salt" toml:"delegation-salt"`
+	RepaymentExecutor string `yaml:"repayment-executor" toml:"repayment-executor"`
+	// MaxPriceImpact is the fraction of value a collateral swap may lose compared to oracle prices.
+	MaxPriceImpact float64 `yaml:"max-price-impact" toml:"max-price-impact"`
+	// MaxRoundDeviation is the fraction a Chainlink answer may move from the previous round.
+	MaxRoundDeviation float64 `yaml:"max-round-deviation" toml:"max-round-deviation"`
+	// FeedHeartbeatSeconds is the heartbeat of Chainlink feeds the bot has no heartbeat for.
+	FeedHeartbeatSeconds uint64 `yaml:"feed-heartbeat-seconds" toml:"feed-heartbeat-seconds"`
+	// DryRun simulates repayments instead of broadcasting them.
+	DryRun bool `yaml:"dry-run" toml:"dry-run"`
+	// Confirmations is the number of blocks to wait for before considering a transaction final.
+	Confirmations uint64 `yaml:"confirmations" toml:"confirmations"`
+	// GasStrategy is one of "fee-history", "suggested" and "static".
+	GasStrategy string `yaml:"gas-strategy" toml:"gas-strategy"`
+	// GasPriceGwei is the gas price of the "static" strategy.
+	GasPriceGwei uint64 `yaml:"gas-price-gwei" toml:"gas-price-gwei"`
+	// MaxFeeGwei is the most any transaction pays per gas, including replacements.
+	MaxFeeGwei uint64 `yaml:"max-fee-gwei" toml:"max-fee-gwei"`
+	// MinBotBalanceETH is the bot balance below which new registrations are refused.
+	MinBotBalanceETH float64 `yaml:"min-bot-balance-eth" toml:"min-bot-balance-eth"`
+	// BotBalanceAlertsETH are the bot balances below which alerts are raised.
+	BotBalanceAlertsETH []float64 `yaml:"bot-balance-alerts-eth" toml:"bot-balance-alerts-eth"`
+
+	ListenAddress string `yaml:"listen-address" toml:"listen-address"`
+	UIRoot        string `yaml:"ui-root" toml:"ui-root"`
+	// StorePath is the JSON file registrations are persisted to.
+	StorePath string `yaml:"store-path" toml:"store-path"`
+	UserKey   string `yaml:"user-key" toml:"user-key"`
+	// WETH9 is the asset prices are denominated in. Its price is 1 by definition.
+	WETH9 string `yaml:"weth9" toml:"weth9"`
+	Dai   string `yaml:"dai" toml:"dai"`
+}
+
+// file is the layout of a config file: named profiles and the one to use by default.
+type file struct {
+	Profile  string              `yaml:"profile" toml:"profile"`
+	Profiles map[string]*profile `yaml:"profiles" toml:"profiles"`
+}
+
+// multicall3 is the address of the Multicall3 contract, which is deployed at the same address on
+// every chain.
+const multicall3 = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+var (
+	// builtinProfiles provide defaults for well-known networks. Config files and environment
+	// variables override them field by field.
+	builtinProfiles = map[string]profile{
+		Mainnet: {
+			ChainID:     1,
+			LendingPool: "0x7d2768dE32b0b80b7a3454c06BdAc94A69DDc7A9",
+			Multicall:   multicall3,
+			WETH9:       "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+			Dai:         "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+		},
+		Polygon: {
+			ChainID:     137,
+			LendingPool: "0x8dFf5E27EA6b7AC08EbFdf9eB090F32ee9a30fcf",
+			Multicall:   multicall3,
+			WETH9:       "0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619",
+			Dai:         "0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063",
+		},
+		LocalFork: {
+			ETHURIs: []string{"ws://localhost:8545"},
+			// Hardhat defaults to 31337, which causes errors with forked mainnet.
+			ChainID: 1337,
+			// The keys here are stable values used by local hardhat nodes.
+			BotKey:      "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+			UserKey:     "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+			LendingPool: "0x7d2768dE32b0b80b7a3454c06BdAc94A69DDc7A9",
+			Multicall:   multicall3,
+			WETH9:       "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2",
+			Dai:         "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+		},
+	}
+)
+
+// FieldError reports an invalid or missing profile field.
+type FieldError struct {
+	// Field is the name of the field as written in config files, such as "lending-pool".
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Load builds parameters for the named profile. The profile starts from the built-in profile of
+// that name, if any, is overridden by the profile in the YAML or TOML file at `path`, if given, and
+// then by AAVE_BOT_* environment variables. The profile defaults to the AAVE_BOT_PROFILE variable,
+// then the file's default profile, then "local-fork".
+func Load(path, name string) (Params, error) {
+	var f file
+	if path != "" {
+		var err error
+		if f, err = readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range []string{name, os.Getenv(ProfileVar), f.Profile, LocalFork} {
+		if n != "" {
+			name = n
+			break
+		}
+	}
+
+	p, builtin := builtinProfiles[name]
+	fp, inFile := f.Profiles[name]
+	if !builtin && !inFile {
+		return nil, fmt.Errorf("unknown profile %q", name)
+	}
+	if inFile {
+		p.merge(fp)
+	}
+	if err := p.override(os.Getenv); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+	c, err := p.validate()
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// readFile parses a config file. Files ending in .toml are TOML and anything else is YAML.
+// Unknown fields are errors so typos don't silently fall back to defaults.
+func readFile(path string) (file, error) {
+	var f file
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return f, fmt.Errorf("reading config: %w", err)
+	}
+	if strings.ToLower(filepath.Ext(path)) == ".toml" {
+		md, err := toml.Decode(string(data), &f)
+		if err != nil {
+			return f, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return f, fmt.Errorf("parsing %s: unknown fields %v", path, undecoded)
+		}
+		return f, nil
+	}
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return f, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// merge overrides the fields of `p` with the fields set in `o`.
+func (p *profile) merge(o *profile) {
+	if o == nil {
+		return
+	}
+	if len(o.ETHURIs) > 0 {
+		p.ETHURIs = o.ETHURIs
+	}
+	if o.ChainID != 0 {
+		p.ChainID = o.ChainID
+	}
+	if o.MaxPriceImpact != 0 {
+		p.MaxPriceImpact = o.MaxPriceImpact
+	}
+	if o.MaxRoundDeviation != 0 {
+		p.MaxRoundDeviation = o.MaxRoundDeviation
+	}
+	if o.DryRun {
+		p.DryRun = true
+	}
+	if o.MinBotBalanceETH != 0 {
+		p.MinBotBalanceETH = o.MinBotBalanceETH
+	}
+	if len(o.BotBalanceAlertsETH) > 0 {
+		p.BotBalanceAlertsETH = o.BotBalanceAlertsETH
+	}
+	for _, f := range []struct{ dst, src *uint64 }{
+		{&p.RPCQuorum, &o.RPCQuorum},
+		{&p.MulticallBatchSize, &o.MulticallBatchSize},
+		{&p.FeedHeartbeatSeconds, &o.FeedHeartbeatSeconds},
+		{&p.Confirmations, &o.Confirmations},
+		{&p.GasPriceGwei, &o.GasPriceGwei},
+		{&p.MaxFeeGwei, &o.MaxFeeGwei},
+	} {
+		if *f.src != 0 {
+			*f.dst = *f.src
+		}
+	}
+	for _, f := range []struct{ dst, src *string }{
+		{&p.Signer, &o.Signer},
+		{&p.BotKey, &o.BotKey},
+		{&p.KeystorePath, &o.KeystorePath},
+		{&p.SignerURL, &o.SignerURL},
+		{&p.BotAddress, &o.BotAddress},
+		{&p.LendingPool, &o.LendingPool},
+		{&p.Multicall, &o.Multicall},
+		{&p.DelegationVersion, &o.DelegationVersion},
+		{&p.DelegationSalt, &o.DelegationSalt},
+		{&p.RepaymentExecutor, &o.RepaymentExecutor},
+		{&p.GasStrategy, &o.GasStrategy},
+		{&p.ListenAddress, &o.ListenAddress},
+		{&p.UIRoot, &o.UIRoot},
+		{&p.StorePath, &o.StorePath},
+		{&p.UserKey, &o.UserKey},
+		{&p.WETH9, &o.WETH9},
+		{&p.Dai, &o.Dai},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+}
+
+// override overrides the fields of `p` with the environment variables returned by `getenv`. The
+// variable of a field is its upper-cased name with dashes replaced by underscores and the AAVE_BOT_
+// prefix. ETH URIs are comma separated.
+func (p *profile) override(getenv func(string) string) error {
+	if v := getenv(envPrefix + "ETH_URIS"); v != "" {
+		p.ETHURIs = strings.Split(v, ",")
+	}
+	if v := getenv(envPrefix + "CHAIN_ID"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return &FieldError{"chain-id", fmt.Errorf("%q from %sCHAIN_ID is not a number", v, envPrefix)}
+		}
+		p.ChainID = id
+	}
+	if v := getenv(envPrefix + "MAX_PRICE_IMPACT"); v != "" {
+		impact, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return &FieldError{"max-price-impact", fmt.Errorf("%q from %sMAX_PRICE_IMPACT is not a number", v,
+				envPrefix)}
+		}
+		p.MaxPriceImpact = impact
+	}
+	if v := getenv(envPrefix + "MAX_ROUND_DEVIATION"); v != "" {
+		deviation, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return &FieldError{"max-round-deviation", fmt.Errorf("%q from %sMAX_ROUND_DEVIATION is not a number",
+				v, envPrefix)}
+		}
+		p.MaxRoundDeviation = deviation
+	}
+	if v := getenv(envPrefix + "MIN_BOT_BALANCE_ETH"); v != "" {
+		balance, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return &FieldError{"min-bot-balance-eth", fmt.Errorf("%q from %sMIN_BOT_BALANCE_ETH is not a number", v,
+				envPrefix)}
+		}
+		p.MinBotBalanceETH = balance
+	}
+	if v := getenv(envPrefix + "BOT_BALANCE_ALERTS_ETH"); v != "" {
+		p.BotBalanceAlertsETH = nil
+		for _, a := range strings.Split(v, ",") {
+			balance, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+			if err != nil {
+				return &FieldError{"bot-balance-alerts-eth", fmt.Errorf("%q from %sBOT_BALANCE_ALERTS_ETH is not a number",
+					a, envPrefix)}
+			}
+			p.BotBalanceAlertsETH = append(p.BotBalanceAlertsETH, balance)
+		}
+	}
+	if v := getenv(envPrefix + "DRY_RUN"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			return &FieldError{"dry-run", fmt.Errorf("%q from %sDRY_RUN is not a boolean", v, envPrefix)}
+		}
+		p.DryRun = dryRun
+	}
+	for _, f := range []struct {
+		field, name string
+		dst         *uint64
+	}{
+		{"rpc-quorum", "RPC_QUORUM", &p.RPCQuorum},
+		{"multicall-batch-size", "MULTICALL_BATCH_SIZE", &p.MulticallBatchSize},
+		{"feed-heartbeat-seconds", "FEED_HEARTBEAT_SECONDS", &p.FeedHeartbeatSeconds},
+		{"confirmations", "CONFIRMATIONS", &p.Confirmations},
+		{"gas-price-gwei", "GAS_PRICE_GWEI", &p.GasPriceGwei},
+		{"max-fee-gwei", "MAX_FEE_GWEI", &p.MaxFeeGwei},
+	} {
+		if v := getenv(envPrefix + f.name); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return &FieldError{f.field, fmt.Errorf("%q from %s%s is not a number", v, envPrefix, f.name)}
+			}
+			*f.dst = n
+		}
+	}
+	for _, f := range []struct {
+		name string
+		dst  *string
+	}{
+		{"SIGNER", &p.Signer},
+		{"BOT_KEY", &p.BotKey},
+		{"KEYSTORE", &p.KeystorePath},
+		{"KEYSTORE_PASSPHRASE", &p.KeystorePassphrase},
+		{"SIGNER_URL", &p.SignerURL},
+		{"BOT_ADDRESS", &p.BotAddress},
+		{"LENDING_POOL", &p.LendingPool},
+		{"MULTICALL", &p.Multicall},
+		{"DELEGATION_VERSION", &p.DelegationVersion},
+		{"DELEGATION_SALT", &p.DelegationSalt},
+		{"REPAYMENT_EXECUTOR", &p.RepaymentExecutor},
+		{"GAS_STRATEGY", &p.GasStrategy},
+		{"LISTEN_ADDRESS", &p.ListenAddress},
+		{"UI_ROOT", &p.UIRoot},
+		{"STORE_PATH", &p.StorePath},
+		{"USER_KEY", &p.UserKey},
+		{"WETH9", &p.WETH9},
+		{"DAI", &p.Dai},
+	} {
+		if v := getenv(envPrefix + f.name); v != "" {
+			*f.dst = v
+		}
+	}
+	return nil
+}
+
+// validate checks the profile and converts it into parameters.
+func (p *profile) validate() (*config, error) {
+	c := &config{
+		chainID:            new(big.Int).SetUint64(p.ChainID),
+		signer:             p.Signer,
+		botKey:             p.BotKey,
+		keystorePath:       p.KeystorePath,
+		keystorePassphrase: p.KeystorePassphrase,
+		signerURL:          p.SignerURL,
+		delegationVersion:  p.DelegationVersion,
+		delegationSalt:     p.DelegationSalt,
+		maxPriceImpact:     p.MaxPriceImpact,
+		maxRoundDeviation:  p.MaxRoundDeviation,
+		feedHeartbeat:      time.Duration(p.FeedHeartbeatSeconds) * time.Second,
+		dryRun:             p.DryRun,
+		confirmations:      p.Confirmations,
+		gasStrategy:        p.GasStrategy,
+		listenAddress:      p.ListenAddress,
+		uiRoot:             p.UIRoot,
+		storePath:          p.StorePath,
+		userKey:            p.UserKey,
+	}
+	if len(p.ETHURIs) == 0 {
+		return nil, &FieldError{"eth-uris", fmt.Errorf("at least one URI is required")}
+	}
+	for _, u := range p.ETHURIs {
+		u = strings.TrimSpace(u)
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, &FieldError{"eth-uris", err}
+		}
+		switch parsed.Scheme {
+		case "ws", "wss", "http", "https":
+		default:
+			return nil, &FieldError{"eth-uris", fmt.Errorf("%q is not a ws(s) or http(s) URI", u)}
+		}
+		c.ethURIs = append(c.ethURIs, u)
+	}
+	c.rpcQuorum = 1
+	if p.RPCQuorum > uint64(len(c.ethURIs)) {
+		return nil, &FieldError{"rpc-quorum", fmt.Errorf("%d is more than the %d ETH URIs", p.RPCQuorum,
+			len(c.ethURIs))}
+	} else if p.RPCQuorum > 0 {
+		c.rpcQuorum = int(p.RPCQuorum)
+	}
+	if p.ChainID == 0 {
+		return nil, &FieldError{"chain-id", fmt.Errorf("required")}
+	}
+	if c.signer == "" {
+		c.signer = SignerKey
+		if p.KeystorePath != "" {
+			c.signer = SignerKeystore
+		}
+	}
+	switch c.signer {
+	case SignerKey:
+		if p.BotKey == "" {
+			return nil, &FieldError{"bot-key", fmt.Errorf("required by the key signer")}
+		}
+	case SignerKeystore:
+		if p.KeystorePath == "" {
+			return nil, &FieldError{"keystore", fmt.Errorf("required by the keystore signer")}
+		}
+	case SignerExternal:
+		if p.SignerURL == "" {
+			return nil, &FieldError{"signer-url", fmt.Errorf("required by the external signer")}
+		}
+		if _, err := url.Parse(p.SignerURL); err != nil {
+			return nil, &FieldError{"signer-url", err}
+		}
+		if p.BotAddress == "" {
+			return nil, &FieldError{"bot-address", fmt.Errorf("required by the external signer")}
+		}
+	default:
+		return nil, &FieldError{"signer", fmt.Errorf("unknown signer %q", c.signer)}
+	}
+	for _, k := range []struct{ field, key string }{{"bot-key", p.BotKey}, {"user-key", p.UserKey}} {
+		if k.key == "" {
+			continue
+		}
+		if _, err := crypto.HexToECDSA(k.key); err != nil {
+			// The error doesn't include the key, which must not end up in logs.
+			return nil, &FieldError{k.field, fmt.Errorf("not a hex private key")}
+		}
+	}
+	if p.KeystorePath != "" {
+		if _, err := os.Stat(p.KeystorePath); err != nil {
+			return nil, &FieldError{"keystore", err}
+		}
+	}
+
+	for _, a := range []struct {
+		field, value string
+		dst          *common.Address
+		required     bool
+	}{
+		{"lending-pool", p.LendingPool, &c.lendingPool, true},
+		{"multicall", p.Multicall, &c.multicall, false},
+		{"weth9", p.WETH9, &c.weth9, true},
+		{"dai", p.Dai, &c.dai, false},
+		{"repayment-executor", p.RepaymentExecutor, &c.repaymentExecutor, false},
+		{"bot-address", p.BotAddress, &c.botAddress, false},
+	} {
+		if a.value == "" {
+			if a.required {
+				return nil, &FieldError{a.field, fmt.Errorf("required")}
+			}
+			continue
+		}
+		if !common.IsHexAddress(a.value) {
+			return nil, &FieldError{a.field, fmt.Errorf("%q is not a hex address", a.value)}
+		}
+		*a.dst = common.HexToAddress(a.value)
+	}
+	c.multicallBatchSize = defaultMulticallBatchSize
+	if p.MulticallBatchSize > 0 {
+		c.multicallBatchSize = int(p.MulticallBatchSize)
+	}
+
+	if c.maxPriceImpact == 0 {
+		c.maxPriceImpact = defaultMaxPriceImpact
+	} else if c.maxPriceImpact < 0 || c.maxPriceImpact >= 1 {
+		return nil, &FieldError{"max-price-impact", fmt.Errorf("%v is not a fraction between 0 and 1",
+			c.maxPriceImpact)}
+	}
+	if c.maxRoundDeviation == 0 {
+		c.maxRoundDeviation = defaultMaxDeviation
+	} else if c.maxRoundDeviation < 0 || c.maxRoundDeviation >= 1 {
+		return nil, &FieldError{"max-round-deviation", fmt.Errorf("%v is not a fraction between 0 and 1",
+			c.maxRoundDeviation)}
+	}
+	if c.feedHeartbeat == 0 {
+		c.feedHeartbeat = defaultFeedHeartbeat
+	}
+	if c.confirmations == 0 {
+		c.confirmations = defaultConfirmations
+	}
+	if c.gasStrategy == "" {
+		c.gasStrategy = defaultGasStrategy
+	}
+	switch c.gasStrategy {
+	case GasFeeHistory, GasSuggested:
+	case GasStatic:
+		if p.GasPriceGwei == 0 {
+			return nil, &FieldError{"gas-price-gwei", fmt.Errorf("required by the static gas strategy")}
+		}
+		c.gasPrice = gwei(p.GasPriceGwei)
+	default:
+		return nil, &FieldError{"gas-strategy", fmt.Errorf("unknown strategy %q", c.gasStrategy)}
+	}
+	maxFee := p.MaxFeeGwei
+	if maxFee == 0 {
+		maxFee = defaultMaxFeeGwei
+	}
+	c.maxFeePerGas = gwei(maxFee)
+	if c.gasPrice != nil && c.gasPrice.Cmp(c.maxFeePerGas) > 0 {
+		return nil, &FieldError{"gas-price-gwei", fmt.Errorf("%d is above max-fee-gwei %d", p.GasPriceGwei, maxFee)}
+	}
+	minBalance := p.MinBotBalanceETH
+	if minBalance == 0 {
+		minBalance = defaultMinBotBalance
+	} else if minBalance < 0 {
+		return nil, &FieldError{"min-bot-balance-eth", fmt.Errorf("%v is negative", minBalance)}
+	}
+	c.minBotBalance = ether(minBalance)
+	alerts := p.BotBalanceAlertsETH
+	if len(alerts) == 0 {
+		alerts = defaultBotBalanceAlerts
+	}
+	for _, a := range alerts {
+		if a <= 0 {
+			return nil, &FieldError{"bot-balance-alerts-eth", fmt.Errorf("%v is not positive", a)}
+		}
+		c.botBalanceAlerts = append(c.botBalanceAlerts, ether(a))
+	}
+	if c.listenAddress == "" {
+		c.listenAddress = defaultListenAddress
+	}
+	if c.uiRoot == "" {
+		c.uiRoot = defaultUIRoot
+	}
+	if c.storePath == "" {
+		c.storePath = defaultStorePath
+	}
+	// The store is created on the first registration, so only its directory has to exist.
+	if info, err := os.Stat(c.storePath); err == nil && info.IsDir() {
+		return nil, &FieldError{"store-path", fmt.Errorf("%s is a directory", c.storePath)}
+	}
+	if info, err := os.Stat(filepath.Dir(c.storePath)); err != nil {
+		return nil, &FieldError{"store-path", err}
+	} else if !info.IsDir() {
+		return nil, &FieldError{"store-path", fmt.Errorf("%s is not a directory", filepath.Dir(c.storePath))}
+	}
+	return c, nil
+}
+
+// ether converts ETH to wei, rounding down. The conversion goes through the shortest decimal form
+// of `eth` so amounts such as 0.1 are exact.
+func ether(eth float64) *big.Int {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(eth, 'f', -1, 64))
+	r.Mul(r, new(big.Rat).SetInt(big.NewInt(1e18)))
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// gwei converts gwei to wei.
+func gwei(n uint64) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(n), big.NewInt(1e9))
+}
@@ -0,0 +1,179 @@
+package env
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProfiles(t *testing.T) {
+	yamlPath := writeConfig(t, "config.yaml", `
+profile: mainnet
+profiles:
+  mainnet:
+    eth-uris: [wss://mainnet.example, https://fallback.example]
+    rpc-quorum: 2
+    multicall-batch-size: 200
+    bot-key: ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80
+    listen-address: ":8080"
+`)
+	tomlPath := writeConfig(t, "config.toml", `
+[profiles.polygon]
+eth-uris = ["wss://polygon.example"]
+bot-key = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+`)
+
+	p, err := Load(yamlPath, "")
+	if err != nil {
+		t.Fatalf("Load(%q, \"\") = _, %v, want _, nil", yamlPath, err)
+	}
+	if got, want := p.ETHURI(), "wss://mainnet.example"; got != want {
+		t.Errorf("ETHURI() = %q, want %q", got, want)
+	}
+	if got := len(p.ETHURIs()); got != 2 {
+		t.Errorf("len(ETHURIs()) = %d, want 2", got)
+	}
+	if got := p.RPCQuorum(); got != 2 {
+		t.Errorf("RPCQuorum() = %d, want 2", got)
+	}
+	if got := p.MulticallBatchSize(); got != 200 {
+		t.Errorf("MulticallBatchSize() = %d, want 200", got)
+	}
+	if got := p.ChainID().Int64(); got != 1 {
+		t.Errorf("ChainID() = %d, want the built-in 1", got)
+	}
+	if got, want := p.ListenAddress(), ":8080"; got != want {
+		t.Errorf("ListenAddress() = %q, want %q", got, want)
+	}
+
+	p, err = Load(tomlPath, Polygon)
+	if err != nil {
+		t.Fatalf("Load(%q, %q) = _, %v, want _, nil", tomlPath, Polygon, err)
+	}
+	if got, want := p.LendingPoolAddress(), common.HexToAddress(builtinProfiles[Polygon].LendingPool); got != want {
+		t.Errorf("LendingPoolAddress() = %v, want %v", got, want)
+	}
+	if got, want := p.MulticallAddress(), common.HexToAddress(multicall3); got != want {
+		t.Errorf("MulticallAddress() = %v, want %v", got, want)
+	}
+	if got := p.MulticallBatchSize(); got != defaultMulticallBatchSize {
+		t.Errorf("MulticallBatchSize() = %d, want the default %d", got, defaultMulticallBatchSize)
+	}
+	if got, want := p.UIRoot(), defaultUIRoot; got != want {
+		t.Errorf("UIRoot() = %q, want the default %q", got, want)
+	}
+	if got, want := p.StorePath(), defaultStorePath; got != want {
+		t.Errorf("StorePath() = %q, want the default %q", got, want)
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	pool := "0x0000000000000000000000000000000000000001"
+	os.Setenv(envPrefix+"LENDING_POOL", pool)
+	os.Setenv(envPrefix+"CHAIN_ID", "31337")
+	os.Setenv(envPrefix+"DRY_RUN", "true")
+	os.Setenv(envPrefix+"BOT_BALANCE_ALERTS_ETH", "1, 0.25")
+	defer os.Unsetenv(envPrefix + "BOT_BALANCE_ALERTS_ETH")
+	defer os.Unsetenv(envPrefix + "LENDING_POOL")
+	defer os.Unsetenv(envPrefix + "CHAIN_ID")
+	defer os.Unsetenv(envPrefix + "DRY_RUN")
+
+	p, err := Load("", LocalFork)
+	if err != nil {
+		t.Fatalf("Load(\"\", %q) = _, %v, want _, nil", LocalFork, err)
+	}
+	if got, want := p.LendingPoolAddress(), common.HexToAddress(pool); got != want {
+		t.Errorf("LendingPoolAddress() = %v, want %v", got, want)
+	}
+	if got := p.ChainID().Int64(); got != 31337 {
+		t.Errorf("ChainID() = %d, want 31337", got)
+	}
+	if !p.DryRun() {
+		t.Errorf("DryRun() = false, want true")
+	}
+	if got := p.MaxPriceImpact(); got != defaultMaxPriceImpact {
+		t.Errorf("MaxPriceImpact() = %v, want the default %v", got, defaultMaxPriceImpact)
+	}
+	if got := p.MaxRoundDeviation(); got != defaultMaxDeviation {
+		t.Errorf("MaxRoundDeviation() = %v, want the default %v", got, defaultMaxDeviation)
+	}
+	if got := p.FeedHeartbeat(); got != defaultFeedHeartbeat {
+		t.Errorf("FeedHeartbeat() = %v, want the default %v", got, defaultFeedHeartbeat)
+	}
+	if got := p.BotBalanceAlerts(); len(got) != 2 || got[0].Cmp(big.NewInt(1e18)) != 0 ||
+		got[1].Cmp(big.NewInt(25e16)) != 0 {
+		t.Errorf("BotBalanceAlerts() = %v, want [1e18 25e16]", got)
+	}
+	if got := p.MinBotBalance(); got.Cmp(big.NewInt(5e16)) != 0 {
+		t.Errorf("MinBotBalance() = %v, want the default 5e16", got)
+	}
+}
+
+func TestLoadErrorsNameField(t *testing.T) {
+	for _, tc := range []struct {
+		desc, profile, config, field string
+	}{
+		{"bad address", LocalFork, "profiles:\n  local-fork:\n    weth9: 0x12\n", "weth9"},
+		{"bad repayment executor", LocalFork, "profiles:\n  local-fork:\n    repayment-executor: 0x12\n",
+			"repayment-executor"},
+		{"bad scheme", LocalFork, "profiles:\n  local-fork:\n    eth-uris: [ftp://node]\n", "eth-uris"},
+		{"quorum above URIs", LocalFork, "profiles:\n  local-fork:\n    rpc-quorum: 2\n", "rpc-quorum"},
+		{"missing URIs", Mainnet,
+			"profiles:\n  mainnet:\n    bot-key: ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80\n", "eth-uris"},
+		{"missing key", Mainnet, "profiles:\n  mainnet:\n    eth-uris: [wss://node]\n", "bot-key"},
+		{"bad key", LocalFork, "profiles:\n  local-fork:\n    bot-key: nope\n", "bot-key"},
+		{"unknown signer", LocalFork, "profiles:\n  local-fork:\n    signer: hsm\n", "signer"},
+		{"external without URL", LocalFork, "profiles:\n  local-fork:\n    signer: external\n", "signer-url"},
+		{"external without address", LocalFork,
+			"profiles:\n  local-fork:\n    signer: external\n    signer-url: http://localhost:8550\n", "bot-address"},
+		{"missing keystore", LocalFork, "profiles:\n  local-fork:\n    keystore: /nonexistent/key.json\n", "keystore"},
+		{"missing store directory", LocalFork,
+			"profiles:\n  local-fork:\n    store-path: /nonexistent/registrations.json\n", "store-path"},
+		{"store is a directory", LocalFork, "profiles:\n  local-fork:\n    store-path: /\n", "store-path"},
+		{"unknown gas strategy", LocalFork, "profiles:\n  local-fork:\n    gas-strategy: cheap\n", "gas-strategy"},
+		{"static without price", LocalFork, "profiles:\n  local-fork:\n    gas-strategy: static\n", "gas-price-gwei"},
+		{"price above max fee", LocalFork,
+			"profiles:\n  local-fork:\n    gas-strategy: static\n    gas-price-gwei: 600\n", "gas-price-gwei"},
+		{"negative balance floor", LocalFork, "profiles:\n  local-fork:\n    min-bot-balance-eth: -1\n",
+			"min-bot-balance-eth"},
+		{"zero balance alert", LocalFork, "profiles:\n  local-fork:\n    bot-balance-alerts-eth: [1, 0]\n",
+			"bot-balance-alerts-eth"},
+		{"bad price impact", LocalFork, "profiles:\n  local-fork:\n    max-price-impact: 3\n", "max-price-impact"},
+		{"negative round deviation", LocalFork, "profiles:\n  local-fork:\n    max-round-deviation: -0.1\n",
+			"max-round-deviation"},
+	} {
+		path := writeConfig(t, "config.yaml", tc.config)
+		_, err := Load(path, tc.profile)
+		var fe *FieldError
+		if !errors.As(err, &fe) || fe.Field != tc.field {
+			t.Errorf("%s: Load(...) = _, %v, want an error for field %s", tc.desc, err, tc.field)
+		}
+	}
+
+	path := writeConfig(t, "config.yaml", "profiles:\n  local-fork:\n    lending-pol: 0x01\n")
+	if _, err := Load(path, LocalFork); err == nil {
+		t.Errorf("Load(...) with an unknown field = _, nil, want error")
+	}
+	if _, err := Load("", "moon"); err == nil {
+		t.Errorf("Load(\"\", \"moon\") = _, nil, want error")
+	}
+}
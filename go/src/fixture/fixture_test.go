@@ -34,7 +34,7 @@ func TestFixture(t *testing.T) {
 	}
 
 	// Deploys the contract.
-	rep, repAddr, err := repayment.Deploy(ctx, client)
+	rep, repAddr, err := repayment.Deploy(ctx, client, nil)
 	if err != nil {
 		t.Fatalf("deploying repayment contract failed: %v", err)
 	}
@@ -49,23 +49,22 @@ func TestFixture(t *testing.T) {
 		t.Fatalf("Error setting up loan: %v", err)
 	}
 
-	loan, err := client.Loan(ctx, user.Address)
+	loan, err := client.Loan(ctx, user.Address())
 	if err != nil {
-		t.Fatalf("clients.Loan(ctx, %v) = _, %v, want _, nil", user.Address, err)
-	}
-
-	// Creates the certificate.
-	cert, err := delegation.New(client.BotAddress())
-	if err != nil {
-		t.Fatalf("delegation.New(%v) = _, %v, want _, nil", client.BotAddress(), err)
+		t.Fatalf("clients.Loan(ctx, %v) = _, %v, want _, nil", user.Address(), err)
 	}
 
+	// The contract was deployed above, so the parameters don't configure it.
+	domain := delegation.DomainFor(params)
+	domain.VerifyingContract = repAddr
 	s, err := service.New(service.Deps{
 		Client:  client,
 		RepAddr: repAddr,
 		Rep:     rep,
 		Root:    "../../../ui/dist",
-		Cert:    cert,
+		Domain:  domain,
+		// Each run starts a fresh node, so registrations from earlier runs must not resume.
+		Store: service.NewMemoryStore(),
 	})
 	if err != nil {
 		t.Fatalf("service.New(...) = _, %v, want _, nil", err)
@@ -75,7 +74,7 @@ func TestFixture(t *testing.T) {
 	go s.Run()
 
 	for {
-		debt, err := loan.DebtAmount(ctx, client)
+		debt, err := loan.DebtAmount(ctx, client, loan.Debt[0])
 		if err != nil {
 			t.Fatalf("loan.DebtAmount(...) = _, %v, want _, nil", err)
 		}
@@ -0,0 +1,223 @@
+// Package funds watches the balance of the bot, which pays the gas of repayments.
+//
+// A `Watcher` compares the balance against the gas needed to repay every loan at risk, raises
+// alerts when it drops below configured thresholds and tells the service when it's too low to take
+// on new registrations.
+package funds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"gas"
+)
+
+// Defaults of `Config`.
+const (
+	// DefaultRepaymentGas is a conservative estimate of the gas of a repayment: a flash loan, a
+	// repayment, a withdrawal and a swap.
+	DefaultRepaymentGas = 1500000
+	DefaultInterval     = time.Minute
+)
+
+// ErrLowFunds is returned by `Admit` when the bot balance is below the floor.
+var ErrLowFunds = errors.New("bot balance is too low")
+
+// Backend reads account balances. `*ethclient.Client` implements it.
+type Backend interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Config configures a `Watcher`. Zero values use the defaults.
+type Config struct {
+	// Floor is the balance below which new registrations are refused. Nil has no floor.
+	Floor *big.Int
+	// Alerts are the balances below which alerts are raised, once per crossing.
+	Alerts []*big.Int
+	// RepaymentGas is the gas budgeted for each repayment.
+	RepaymentGas uint64
+	// Interval is how often `Run` checks the balance.
+	Interval time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.RepaymentGas == 0 {
+		c.RepaymentGas = DefaultRepaymentGas
+	}
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	return c
+}
+
+// Status is the outcome of a balance check.
+type Status struct {
+	Address common.Address
+	Balance *big.Int
+	// AtRisk is the number of loans that may need repaying soon.
+	AtRisk int
+	// FeePerGas is the most an urgent transaction pays per gas.
+	FeePerGas *big.Int
+	// Required is the cost of repaying every loan at risk at `FeePerGas`.
+	Required *big.Int
+	Floor    *big.Int
+	Checked  time.Time
+}
+
+// Covered reports whether the balance pays for repaying every loan at risk.
+func (s *Status) Covered() bool {
+	return s.Balance.Cmp(s.Required) >= 0
+}
+
+// AboveFloor reports whether the balance is enough to take on new registrations.
+func (s *Status) AboveFloor() bool {
+	return s.Floor == nil || s.Balance.Cmp(s.Floor) >= 0
+}
+
+// Watcher checks the balance of the bot.
+type Watcher struct {
+	backend Backend
+	fees    gas.Strategy
+	address common.Address
+	atRisk  func() int
+	cfg     Config
+
+	mu     sync.Mutex
+	status *Status
+	// below records the alerts in `cfg.Alerts` whose balance the bot is below, so each crossing is
+	// only reported once.
+	below map[int]bool
+	// short records whether the balance didn't cover the loans at risk on the last check.
+	short bool
+}
+
+// New returns a watcher of the balance of `address`. `atRisk` returns the number of loans that may
+// need repaying soon and `fees` prices their urgent transactions.
+func New(backend Backend, fees gas.Strategy, address common.Address, atRisk func() int, cfg Config) *Watcher {
+	cfg = cfg.withDefaults()
+	alerts := append([]*big.Int(nil), cfg.Alerts...)
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Cmp(alerts[j]) > 0 })
+	cfg.Alerts = alerts
+	return &Watcher{
+		backend: backend,
+		fees:    fees,
+		address: address,
+		atRisk:  atRisk,
+		cfg:     cfg,
+		below:   map[int]bool{},
+	}
+}
+
+// Check reads the balance, estimates what repaying the loans at risk costs and raises alerts.
+func (w *Watcher) Check(ctx context.Context) (*Status, error) {
+	balance, err := w.backend.BalanceAt(ctx, w.address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting balance of %v: %w", w.address, err)
+	}
+	fees, err := w.fees.Fees(ctx, gas.Urgent)
+	if err != nil {
+		return nil, fmt.Errorf("getting urgent fees: %w", err)
+	}
+	status := &Status{
+		Address:   w.address,
+		Balance:   balance,
+		AtRisk:    w.atRisk(),
+		FeePerGas: fees.Max(),
+		Floor:     w.cfg.Floor,
+		Checked:   time.Now(),
+	}
+	status.Required = new(big.Int).Mul(status.FeePerGas, new(big.Int).SetUint64(w.cfg.RepaymentGas))
+	status.Required.Mul(status.Required, big.NewInt(int64(status.AtRisk)))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.status = status
+	w.alert(status)
+	return status, nil
+}
+
+// alert logs alerts for the thresholds crossed since the last check.
+func (w *Watcher) alert(s *Status) {
+	for i, threshold := range w.cfg.Alerts {
+		below := s.Balance.Cmp(threshold) < 0
+		if below && !w.below[i] {
+			log.Printf("ALERT: bot %v balance %s ETH dropped below %s ETH", s.Address.Hex(), FormatETH(s.Balance),
+				FormatETH(threshold))
+		} else if !below && w.below[i] {
+			log.Printf("Bot %v balance %s ETH is back above %s ETH", s.Address.Hex(), FormatETH(s.Balance),
+				FormatETH(threshold))
+		}
+		w.below[i] = below
+	}
+	short := !s.Covered()
+	if short && !w.short {
+		log.Printf("ALERT: bot %v balance %s ETH doesn't cover %s ETH to repay %d loans at risk",
+			s.Address.Hex(), FormatETH(s.Balance), FormatETH(s.Required), s.AtRisk)
+	}
+	w.short = short
+}
+
+// Status returns the outcome of the last check, or nil if there was none.
+func (w *Watcher) Status() *Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+// Recent returns the outcome of the last check if it's no older than `Interval`, and checks again
+// otherwise.
+func (w *Watcher) Recent(ctx context.Context) (*Status, error) {
+	if status := w.Status(); status != nil && time.Since(status.Checked) <= w.cfg.Interval {
+		return status, nil
+	}
+	return w.Check(ctx)
+}
+
+// Admit returns an error wrapping `ErrLowFunds` if the balance is below the floor.
+func (w *Watcher) Admit(ctx context.Context) error {
+	status, err := w.Recent(ctx)
+	if err != nil {
+		return err
+	}
+	if !status.AboveFloor() {
+		return fmt.Errorf("%w: %s ETH is below the floor of %s ETH", ErrLowFunds, FormatETH(status.Balance),
+			FormatETH(status.Floor))
+	}
+	return nil
+}
+
+// Run checks the balance every `Interval` until `ctx` is done.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.Check(ctx); err != nil {
+			log.Printf("Error checking bot funds: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsInsufficientFunds reports whether `err` is a node rejecting a transaction the sender can't pay
+// for. Nodes only report it as a message.
+func IsInsufficientFunds(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "insufficient funds")
+}
+
+// FormatETH formats an amount of wei in ETH.
+func FormatETH(wei *big.Int) string {
+	return new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(6)
+}
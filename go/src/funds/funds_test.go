@@ -0,0 +1,105 @@
+package funds
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"gas"
+)
+
+type fakeBackend struct {
+	mu      sync.Mutex
+	balance *big.Int
+	calls   int
+}
+
+func (b *fakeBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls++
+	return new(big.Int).Set(b.balance), nil
+}
+
+func (b *fakeBackend) set(wei int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.balance = big.NewInt(wei)
+}
+
+func newTestWatcher(b *fakeBackend, atRisk int) *Watcher {
+	fees := &gas.Static{GasPrice: big.NewInt(10)}
+	return New(b, fees, common.HexToAddress("0x01"), func() int { return atRisk }, Config{
+		Floor:        big.NewInt(1000),
+		Alerts:       []*big.Int{big.NewInt(5000), big.NewInt(20000)},
+		RepaymentGas: 100,
+	})
+}
+
+func TestCheck(t *testing.T) {
+	b := &fakeBackend{balance: big.NewInt(2500)}
+	w := newTestWatcher(b, 3)
+
+	status, err := w.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check(...) = _, %v, want _, nil", err)
+	}
+	// 3 loans * 100 gas * 10 wei.
+	if got := status.Required.Int64(); got != 3000 {
+		t.Errorf("Required = %d, want 3000", got)
+	}
+	if status.Covered() {
+		t.Errorf("Covered() = true for a balance of 2500, want false")
+	}
+	if !status.AboveFloor() {
+		t.Errorf("AboveFloor() = false for a balance of 2500, want true")
+	}
+	if !w.below[0] || !w.below[1] || !w.short {
+		t.Errorf("alerts below = %v, short = %v, want both thresholds and short", w.below, w.short)
+	}
+
+	b.set(10000)
+	if _, err := w.Check(context.Background()); err != nil {
+		t.Fatalf("Check(...) = _, %v, want _, nil", err)
+	}
+	// Thresholds are sorted from highest to lowest.
+	if !w.below[0] || w.below[1] || w.short {
+		t.Errorf("alerts below = %v, short = %v, want only the 20000 threshold", w.below, w.short)
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	b := &fakeBackend{balance: big.NewInt(500)}
+	w := newTestWatcher(b, 0)
+	ctx := context.Background()
+
+	if err := w.Admit(ctx); !errors.Is(err, ErrLowFunds) {
+		t.Errorf("Admit(...) below the floor = %v, want ErrLowFunds", err)
+	}
+	b.set(1500)
+	if err := w.Admit(ctx); !errors.Is(err, ErrLowFunds) {
+		t.Errorf("Admit(...) right after a check = %v, want the cached ErrLowFunds", err)
+	}
+	if b.calls != 1 {
+		t.Errorf("BalanceAt called %d times, want once", b.calls)
+	}
+
+	w.status.Checked = time.Now().Add(-2 * DefaultInterval)
+	if err := w.Admit(ctx); err != nil {
+		t.Errorf("Admit(...) above the floor = %v, want nil", err)
+	}
+}
+
+func TestIsInsufficientFunds(t *testing.T) {
+	if !IsInsufficientFunds(errors.New("sending repayment: insufficient funds for gas * price + value")) {
+		t.Errorf("IsInsufficientFunds(...) = false for a node error, want true")
+	}
+	if IsInsufficientFunds(errors.New("nonce too low")) || IsInsufficientFunds(nil) {
+		t.Errorf("IsInsufficientFunds(...) = true for other errors, want false")
+	}
+}
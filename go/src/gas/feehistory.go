@@ -0,0 +1,101 @@
+package gas
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultBlocks is the number of recent blocks `FeeHistory` considers by default.
+const DefaultBlocks = 10
+
+var (
+	// tipPercentiles are the percentiles of the tips paid in recent blocks that transactions of each
+	// urgency match.
+	tipPercentiles = []float64{10, 50, 90}
+	// baseFeeRises are the number of consecutive base fee rises of 12.5% that transactions of each
+	// urgency can withstand before they're priced out.
+	baseFeeRises = []int{1, 3, 6}
+)
+
+// RPCCaller performs raw JSON-RPC calls. `*rpc.Client` implements it.
+type RPCCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// FeeHistory derives EIP-1559 fees from recent blocks using eth_feeHistory, scaled by urgency.
+// The tip matches a percentile of the tips in recent blocks, higher for more urgent transactions,
+// and the fee cap covers the base fee rising for more blocks as urgency grows.
+type FeeHistory struct {
+	RPC RPCCaller
+	// Blocks is the number of recent blocks considered. Zero uses `DefaultBlocks`.
+	Blocks int
+	// Fallback is used on chains without a base fee or nodes without eth_feeHistory.
+	Fallback Strategy
+}
+
+// feeHistory is the result of eth_feeHistory.
+type feeHistory struct {
+	OldestBlock *hexutil.Big `json:"oldestBlock"`
+	// BaseFee has one more entry than the number of blocks, which is the base fee of the next block.
+	BaseFee []*hexutil.Big   `json:"baseFeePerGas"`
+	Reward  [][]*hexutil.Big `json:"reward"`
+}
+
+func (f *FeeHistory) Fees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	if urgency < Low || urgency > Urgent {
+		return nil, fmt.Errorf("unknown %v", urgency)
+	}
+	blocks := f.Blocks
+	if blocks == 0 {
+		blocks = DefaultBlocks
+	}
+	var h feeHistory
+	if err := f.RPC.CallContext(ctx, &h, "eth_feeHistory", hexutil.Uint(blocks), "latest",
+		tipPercentiles); err != nil {
+		if f.Fallback == nil {
+			return nil, fmt.Errorf("retrieving fee history: %w", err)
+		}
+		log.Printf("Falling back from fee history: %v", err)
+		return f.Fallback.Fees(ctx, urgency)
+	}
+	if len(h.BaseFee) == 0 || h.BaseFee[len(h.BaseFee)-1].ToInt().Sign() == 0 {
+		if f.Fallback == nil {
+			return nil, fmt.Errorf("no base fee in fee history")
+		}
+		return f.Fallback.Fees(ctx, urgency)
+	}
+	return feesFromHistory(&h, urgency), nil
+}
+
+// feesFromHistory computes the fees for `urgency` from a fee history.
+func feesFromHistory(h *feeHistory, urgency Urgency) *Fees {
+	var tips []*big.Int
+	for _, rewards := range h.Reward {
+		if int(urgency) < len(rewards) && rewards[urgency] != nil {
+			tips = append(tips, rewards[urgency].ToInt())
+		}
+	}
+	tip := median(tips)
+
+	// Each full block raises the base fee by 12.5%.
+	feeCap := new(big.Int).Set(h.BaseFee[len(h.BaseFee)-1].ToInt())
+	for i := 0; i < baseFeeRises[urgency]; i++ {
+		feeCap.Add(feeCap, new(big.Int).Quo(feeCap, big.NewInt(8)))
+	}
+	return &Fees{GasFeeCap: feeCap.Add(feeCap, tip), GasTipCap: tip}
+}
+
+// median returns the median of `values`, or zero if there are none.
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sorted := append([]*big.Int(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
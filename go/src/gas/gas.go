@@ -0,0 +1,152 @@
+// Package gas chooses gas prices for bot transactions.
+//
+// A `Strategy` returns the fees of a transaction given its urgency. Fees are EIP-1559 fee caps and
+// priority tips on chains with a base fee and plain gas prices otherwise.
+package gas
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Urgency ranks how quickly a transaction needs to be mined.
+type Urgency int
+
+const (
+	// Low is for transactions that can wait, such as maintenance.
+	Low Urgency = iota
+	// Normal is for transactions that should be mined within a few blocks.
+	Normal
+	// Urgent is for transactions that must be mined in the next block, such as repayments of loans
+	// close to liquidation.
+	Urgent
+)
+
+func (u Urgency) String() string {
+	switch u {
+	case Low:
+		return "low"
+	case Normal:
+		return "normal"
+	case Urgent:
+		return "urgent"
+	}
+	return fmt.Sprintf("urgency(%d)", int(u))
+}
+
+// Gwei is 10^9 wei.
+var Gwei = big.NewInt(1e9)
+
+// Fees are the gas prices of a transaction. Either `GasPrice` is set for a legacy transaction or
+// `GasFeeCap` and `GasTipCap` are for an EIP-1559 transaction.
+type Fees struct {
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+// Apply sets the fees on transaction options.
+func (f *Fees) Apply(txr *bind.TransactOpts) {
+	txr.GasPrice, txr.GasFeeCap, txr.GasTipCap = f.GasPrice, f.GasFeeCap, f.GasTipCap
+}
+
+// Max returns the most the transaction pays per gas.
+func (f *Fees) Max() *big.Int {
+	if f.GasPrice != nil {
+		return f.GasPrice
+	}
+	return f.GasFeeCap
+}
+
+func (f *Fees) String() string {
+	if f.GasPrice != nil {
+		return fmt.Sprintf("gas price %v", f.GasPrice)
+	}
+	return fmt.Sprintf("fee cap %v, tip %v", f.GasFeeCap, f.GasTipCap)
+}
+
+// Strategy chooses transaction fees.
+type Strategy interface {
+	Fees(ctx context.Context, urgency Urgency) (*Fees, error)
+}
+
+// Static always returns the same fees regardless of urgency.
+type Static Fees
+
+func (s *Static) Fees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	f := Fees(*s)
+	return &f, nil
+}
+
+// HeaderReader returns block headers. `*ethclient.Client` implements it.
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// SuggestionBackend suggests gas prices. `*ethclient.Client` implements it.
+type SuggestionBackend interface {
+	HeaderReader
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+}
+
+// Suggested uses the node's suggestions regardless of urgency. The fee cap allows the base fee to
+// double before the transaction is mined, like go-ethereum's bindings do.
+type Suggested struct {
+	Backend SuggestionBackend
+}
+
+func (s *Suggested) Fees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	head, err := s.Backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		price, err := s.Backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("suggesting gas price: %w", err)
+		}
+		return &Fees{GasPrice: price}, nil
+	}
+	tip, err := s.Backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("suggesting gas tip: %w", err)
+	}
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+	return &Fees{GasFeeCap: feeCap.Add(feeCap, tip), GasTipCap: tip}, nil
+}
+
+// Capped limits the fees of another strategy to a maximum per gas.
+type Capped struct {
+	Strategy Strategy
+	// MaxFeePerGas is the most a transaction may pay per gas.
+	MaxFeePerGas *big.Int
+}
+
+func (c *Capped) Fees(ctx context.Context, urgency Urgency) (*Fees, error) {
+	f, err := c.Strategy.Fees(ctx, urgency)
+	if err != nil {
+		return nil, err
+	}
+	return capFees(f, c.MaxFeePerGas), nil
+}
+
+// capFees returns `f` with every price limited to `max`.
+func capFees(f *Fees, max *big.Int) *Fees {
+	return &Fees{
+		GasPrice:  capPrice(f.GasPrice, max),
+		GasFeeCap: capPrice(f.GasFeeCap, max),
+		GasTipCap: capPrice(f.GasTipCap, max),
+	}
+}
+
+func capPrice(price, max *big.Int) *big.Int {
+	if price == nil || price.Cmp(max) <= 0 {
+		return price
+	}
+	return new(big.Int).Set(max)
+}
@@ -0,0 +1,139 @@
+package gas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), Gwei)
+}
+
+// fakeRPC answers eth_feeHistory with `history` encoded as JSON, or fails with `err`.
+type fakeRPC struct {
+	history string
+	err     error
+}
+
+func (f *fakeRPC) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	if method != "eth_feeHistory" {
+		return errors.New("unexpected method " + method)
+	}
+	return json.Unmarshal([]byte(f.history), result)
+}
+
+// fakeNode suggests 2 gwei tips and 30 gwei prices with the given base fee.
+type fakeNode struct {
+	baseFee *big.Int
+}
+
+func (n *fakeNode) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: n.baseFee}, nil
+}
+
+func (n *fakeNode) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return gwei(30), nil
+}
+
+func (n *fakeNode) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return gwei(2), nil
+}
+
+// history has 3 blocks with the next base fee at 64 gwei and tips at the 10th, 50th and 90th
+// percentiles of 1/2/5, 1/3/6 and 1/2/4 gwei.
+const history = `{
+	"oldestBlock": "0x10",
+	"baseFeePerGas": ["0xba43b7400", "0xdf8475800", "0xee6b28000", "0xee6b28000"],
+	"gasUsedRatio": [0.9, 0.8, 0.5],
+	"reward": [
+		["0x3b9aca00", "0x77359400", "0x12a05f200"],
+		["0x3b9aca00", "0xb2d05e00", "0x165a0bc00"],
+		["0x3b9aca00", "0x77359400", "0xee6b2800"]
+	]
+}`
+
+func TestFeeHistory(t *testing.T) {
+	f := &FeeHistory{RPC: &fakeRPC{history: history}}
+	for _, tc := range []struct {
+		urgency     Urgency
+		tip, feeCap *big.Int
+	}{
+		// 64 gwei after one rise of 12.5% is 72 gwei.
+		{Low, gwei(1), gwei(73)},
+		// After three rises, 91.125 gwei.
+		{Normal, gwei(2), new(big.Int).Add(big.NewInt(91125e6), gwei(2))},
+		// After six rises, 129.746337890625 gwei, rounded down at each step.
+		{Urgent, gwei(5), new(big.Int).Add(big.NewInt(129746337890), gwei(5))},
+	} {
+		fees, err := f.Fees(context.Background(), tc.urgency)
+		if err != nil {
+			t.Fatalf("Fees(%v) = _, %v, want _, nil", tc.urgency, err)
+		}
+		if fees.GasPrice != nil || fees.GasTipCap.Cmp(tc.tip) != 0 || fees.GasFeeCap.Cmp(tc.feeCap) != 0 {
+			t.Errorf("Fees(%v) = %v, want fee cap %v, tip %v", tc.urgency, fees, tc.feeCap, tc.tip)
+		}
+	}
+}
+
+func TestFeeHistoryFallback(t *testing.T) {
+	ctx := context.Background()
+	fallback := &Suggested{Backend: &fakeNode{}}
+
+	// Nodes without eth_feeHistory.
+	f := &FeeHistory{RPC: &fakeRPC{err: errors.New("method not found")}, Fallback: fallback}
+	fees, err := f.Fees(ctx, Urgent)
+	if err != nil || fees.GasPrice == nil || fees.GasPrice.Cmp(gwei(30)) != 0 {
+		t.Errorf("Fees(...) without eth_feeHistory = %v, %v, want the suggested gas price", fees, err)
+	}
+
+	// Chains without a base fee.
+	f = &FeeHistory{RPC: &fakeRPC{history: `{"oldestBlock": "0x1", "baseFeePerGas": ["0x0", "0x0"]}`},
+		Fallback: fallback}
+	if fees, err = f.Fees(ctx, Urgent); err != nil || fees.GasPrice == nil {
+		t.Errorf("Fees(...) without a base fee = %v, %v, want the suggested gas price", fees, err)
+	}
+
+	f.Fallback = nil
+	if _, err := f.Fees(ctx, Urgent); err == nil {
+		t.Errorf("Fees(...) without a base fee or fallback = _, nil, want error")
+	}
+}
+
+func TestSuggested(t *testing.T) {
+	s := &Suggested{Backend: &fakeNode{baseFee: gwei(50)}}
+	fees, err := s.Fees(context.Background(), Normal)
+	if err != nil {
+		t.Fatalf("Fees(...) = _, %v, want _, nil", err)
+	}
+	if fees.GasTipCap.Cmp(gwei(2)) != 0 || fees.GasFeeCap.Cmp(gwei(102)) != 0 {
+		t.Errorf("Fees(...) = %v, want fee cap 102 gwei, tip 2 gwei", fees)
+	}
+}
+
+func TestCapped(t *testing.T) {
+	ctx := context.Background()
+	c := &Capped{
+		Strategy:     &Static{GasFeeCap: gwei(300), GasTipCap: gwei(5)},
+		MaxFeePerGas: gwei(200),
+	}
+	fees, err := c.Fees(ctx, Urgent)
+	if err != nil {
+		t.Fatalf("Fees(...) = _, %v, want _, nil", err)
+	}
+	if fees.GasFeeCap.Cmp(gwei(200)) != 0 || fees.GasTipCap.Cmp(gwei(5)) != 0 {
+		t.Errorf("Fees(...) = %v, want fee cap 200 gwei, tip 5 gwei", fees)
+	}
+
+	c.Strategy = &Static{GasPrice: gwei(250)}
+	if fees, err = c.Fees(ctx, Urgent); err != nil || fees.Max().Cmp(gwei(200)) != 0 {
+		t.Errorf("Fees(...) = %v, %v, want gas price 200 gwei", fees, err)
+	}
+}
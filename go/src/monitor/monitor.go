@@ -0,0 +1,468 @@
+// Package monitor watches Lending Pool and Chainlink events and signals which loans need to be
+// re-evaluated.
+//
+// All registrations share a single set of subscriptions. Events are collected into a set of
+// affected users and the users are notified once per block, so a user touched by several events
+// in a block is only re-evaluated once. The loans of the users notified in a block are read together
+// through Multicall, in batches of a configured size, and handed over with the notifications.
+//
+// The event loop only records what changed. Loan lookups and reads happen in a separate goroutine,
+// so the loop keeps draining the subscriptions, which the node drops if they back up.
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"clients"
+	"lendingpool"
+)
+
+const (
+	// chainlinkABI covers the parts of the Chainlink proxy and aggregator contracts that are not in
+	// the generated `aggregator` binding.
+	chainlinkABI = `[
+{"inputs":[],"name":"aggregator","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+{"anonymous":false,"inputs":[{"indexed":true,"internalType":"int256","name":"current","type":"int256"},{"indexed":true,"internalType":"uint256","name":"roundId","type":"uint256"},{"indexed":false,"internalType":"uint256","name":"updatedAt","type":"uint256"}],"name":"AnswerUpdated","type":"event"}
+]`
+
+	// resubscribeDelay is the wait before resubscribing after a subscription fails.
+	resubscribeDelay = 5 * time.Second
+	// sweepInterval is how often all users are re-evaluated regardless of events. This covers
+	// interest accrual and any events missed while resubscribing.
+	sweepInterval = time.Minute
+)
+
+var (
+	chainlink abi.ABI
+)
+
+func init() {
+	var err error
+	chainlink, err = abi.JSON(strings.NewReader(chainlinkABI))
+	if err != nil {
+		log.Fatalf("Error parsing Chainlink ABI: %v", err)
+	}
+}
+
+// Monitor tracks registered users and notifies them when events may have changed their loans.
+type Monitor struct {
+	client *clients.Client
+
+	mu sync.Mutex
+	// users maps watched user addresses to the notification channels of their subscriptions, keyed
+	// by the receive end handed to the subscriber.
+	users map[common.Address]map[<-chan *clients.LoanState]chan *clients.LoanState
+	// dirty contains users to notify on the next block.
+	dirty map[common.Address]bool
+	// changed contains the assets whose reserves or prices changed since the last block. The users
+	// whose loans use them are marked before the next flush.
+	changed map[common.Address]bool
+	// resolve is set when the price feeds of the watched loans should be looked up before the next
+	// flush.
+	resolve bool
+	// wake signals the reader that a block was mined.
+	wake chan struct{}
+	// feeds maps Chainlink aggregator addresses (the contracts behind the price feed proxies, which
+	// emit the events) to the assets they price.
+	feeds map[common.Address]common.Address
+	// resolved contains the assets whose aggregators have been looked up.
+	resolved map[common.Address]bool
+}
+
+// New creates a new Monitor. It doesn't watch anything until `Run` is called.
+func New(client *clients.Client) *Monitor {
+	return &Monitor{
+		client:   client,
+		users:    make(map[common.Address]map[<-chan *clients.LoanState]chan *clients.LoanState),
+		dirty:    make(map[common.Address]bool),
+		changed:  make(map[common.Address]bool),
+		wake:     make(chan struct{}, 1),
+		feeds:    make(map[common.Address]common.Address),
+		resolved: make(map[common.Address]bool),
+	}
+}
+
+// Watch starts watching the given user. The returned channel receives the state of the user's loan
+// whenever it should be re-evaluated, starting with an initial notification. Notifications are
+// coalesced, so a slow reader only misses outdated states. A nil state means the loan wasn't read
+// with the others and the reader should read it. Each call subscribes anew, so a user can be
+// watched more than once, such as while a new certificate replaces the monitored one.
+func (m *Monitor) Watch(user common.Address) <-chan *clients.LoanState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs, ok := m.users[user]
+	if !ok {
+		subs = make(map[<-chan *clients.LoanState]chan *clients.LoanState)
+		m.users[user] = subs
+	}
+	ch := make(chan *clients.LoanState, 1)
+	subs[ch] = ch
+	notify(ch, nil)
+	return ch
+}
+
+// Unwatch ends the subscription of the given user that returned `updates`. The user stays watched
+// as long as other subscriptions remain.
+func (m *Monitor) Unwatch(user common.Address, updates <-chan *clients.LoanState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subs := m.users[user]
+	delete(subs, updates)
+	if len(subs) == 0 {
+		delete(m.users, user)
+		delete(m.dirty, user)
+	}
+}
+
+// notify sends the state to the channel, replacing a pending one. It must be called with `mu` held.
+func notify(ch chan *clients.LoanState, state *clients.LoanState) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- state
+}
+
+// Run subscribes to events and dispatches notifications until `ctx` is done. Failed subscriptions
+// are retried.
+func (m *Monitor) Run(ctx context.Context) {
+	sweep := time.NewTicker(sweepInterval)
+	defer sweep.Stop()
+	go m.readLoop(ctx)
+	for {
+		err := m.subscribe(ctx, sweep.C)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Monitor subscriptions failed, resubscribing in %v: %v", resubscribeDelay, err)
+		// Anything could have happened while unsubscribed.
+		m.markAll()
+		m.wakeReader()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// subscribe processes events until a subscription fails or `ctx` is done.
+func (m *Monitor) subscribe(ctx context.Context, sweep <-chan time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	eth := m.client.ETH()
+	lp := m.client.LendingPool()
+	opts := &bind.WatchOpts{Context: ctx}
+
+	heads := make(chan *types.Header)
+	borrows := make(chan *lendingpool.LendingpoolBorrow)
+	repays := make(chan *lendingpool.LendingpoolRepay)
+	deposits := make(chan *lendingpool.LendingpoolDeposit)
+	withdrawals := make(chan *lendingpool.LendingpoolWithdraw)
+	liquidations := make(chan *lendingpool.LendingpoolLiquidationCall)
+	reserveUpdates := make(chan *lendingpool.LendingpoolReserveDataUpdated)
+	answers := make(chan types.Log)
+
+	var subs []event.Subscription
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+	for _, s := range []struct {
+		desc      string
+		subscribe func() (event.Subscription, error)
+	}{
+		{"new heads", func() (event.Subscription, error) { return eth.SubscribeNewHead(ctx, heads) }},
+		{"borrows", func() (event.Subscription, error) { return lp.WatchBorrow(opts, borrows, nil, nil, nil) }},
+		{"repays", func() (event.Subscription, error) { return lp.WatchRepay(opts, repays, nil, nil, nil) }},
+		{"deposits", func() (event.Subscription, error) { return lp.WatchDeposit(opts, deposits, nil, nil, nil) }},
+		{"withdrawals", func() (event.Subscription, error) {
+			return lp.WatchWithdraw(opts, withdrawals, nil, nil, nil)
+		}},
+		{"liquidations", func() (event.Subscription, error) {
+			return lp.WatchLiquidationCall(opts, liquidations, nil, nil, nil)
+		}},
+		{"reserve updates", func() (event.Subscription, error) {
+			return lp.WatchReserveDataUpdated(opts, reserveUpdates, nil)
+		}},
+		{"price updates", func() (event.Subscription, error) {
+			// Filters by topic only since the set of aggregators grows as users register.
+			return eth.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+				Topics: [][]common.Hash{{chainlink.Events["AnswerUpdated"].ID}},
+			}, answers)
+		}},
+	} {
+		sub, err := s.subscribe()
+		if err != nil {
+			return fmt.Errorf("subscribing to %s: %w", s.desc, err)
+		}
+		subs = append(subs, sub)
+	}
+	errs := make(chan error, len(subs))
+	for _, sub := range subs {
+		go func(sub event.Subscription) {
+			if err, ok := <-sub.Err(); ok {
+				errs <- err
+			}
+		}(sub)
+	}
+
+	m.resolveNext()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errs:
+			return err
+		case <-heads:
+			m.wakeReader()
+		case <-sweep:
+			m.resolveNext()
+			m.markAll()
+		case e := <-borrows:
+			m.userChanged(e.OnBehalfOf)
+		case e := <-repays:
+			m.userChanged(e.User)
+		case e := <-deposits:
+			m.userChanged(e.OnBehalfOf)
+		case e := <-withdrawals:
+			m.userChanged(e.User)
+		case e := <-liquidations:
+			m.userChanged(e.User)
+		case e := <-reserveUpdates:
+			m.assetChanged(e.Reserve)
+		case l := <-answers:
+			m.priceChanged(l.Address)
+		}
+	}
+}
+
+// wakeReader signals the reader to flush the marked users. Signals sent while the reader is busy
+// are coalesced.
+func (m *Monitor) wakeReader() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// readLoop flushes the marked users each time it's woken until `ctx` is done.
+func (m *Monitor) readLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+		}
+		m.mu.Lock()
+		resolve := m.resolve
+		m.resolve = false
+		m.mu.Unlock()
+		if resolve {
+			m.resolveAll(ctx)
+		}
+		m.markChanged(ctx)
+		m.flush(ctx)
+	}
+}
+
+// userChanged marks a user whose positions changed.
+func (m *Monitor) userChanged(user common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[user]; !ok {
+		return
+	}
+	// The user may have added or removed reserves.
+	m.client.ForgetLoan(user)
+	m.dirty[user] = true
+}
+
+// assetChanged records a change of the given asset. The users whose loans use it are marked before
+// the next flush.
+func (m *Monitor) assetChanged(asset common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.changed[asset] = true
+}
+
+// priceChanged records a change of the asset priced by the given aggregator.
+func (m *Monitor) priceChanged(aggregator common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if asset, ok := m.feeds[aggregator]; ok {
+		m.changed[asset] = true
+	}
+}
+
+// markChanged marks all users whose loans use the changed assets and clears the changes.
+func (m *Monitor) markChanged(ctx context.Context) {
+	m.mu.Lock()
+	changed := m.changed
+	m.changed = make(map[common.Address]bool)
+	m.mu.Unlock()
+	if len(changed) == 0 {
+		return
+	}
+	for _, user := range m.watched() {
+		loan, err := m.client.Loan(ctx, user)
+		if err != nil {
+			// Re-evaluating the user surfaces the error. The failed lookup is dropped so it's retried.
+			m.client.ForgetLoan(user)
+			m.mark(user)
+			continue
+		}
+		for asset := range changed {
+			if loan.Uses(asset) {
+				m.mark(user)
+				break
+			}
+		}
+	}
+}
+
+// resolveNext has the price feeds of the watched loans looked up before the next flush, which
+// attributes the events of feeds of newly watched loans.
+func (m *Monitor) resolveNext() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resolve = true
+}
+
+// resolveAll resolves the price feeds of all watched loans.
+func (m *Monitor) resolveAll(ctx context.Context) {
+	for _, user := range m.watched() {
+		if loan, err := m.client.Loan(ctx, user); err == nil {
+			m.resolveFeeds(ctx, loan)
+		}
+	}
+}
+
+// resolveFeeds looks up the aggregators behind the price feeds of the loan's assets so their
+// events can be attributed.
+func (m *Monitor) resolveFeeds(ctx context.Context, loan *clients.Loan) {
+	for _, rs := range [][]*clients.Reserve{loan.Collateral, loan.Debt} {
+		for _, r := range rs {
+			m.mu.Lock()
+			done := m.resolved[r.Asset]
+			m.mu.Unlock()
+			if done {
+				continue
+			}
+			proxy, ok, err := m.client.PriceFeed(ctx, r.Asset)
+			if err != nil {
+				log.Printf("Error finding price feed of %v: %v", r, err)
+				continue
+			}
+			if !ok {
+				m.mu.Lock()
+				m.resolved[r.Asset] = true
+				m.mu.Unlock()
+				continue
+			}
+			feed := bind.NewBoundContract(proxy, chainlink, m.client.ETH(), m.client.ETH(), m.client.ETH())
+			var out []interface{}
+			if err := feed.Call(&bind.CallOpts{Context: ctx}, &out, "aggregator"); err != nil {
+				log.Printf("Error resolving aggregator of %v: %v", r, err)
+				continue
+			}
+			m.mu.Lock()
+			m.feeds[out[0].(common.Address)] = r.Asset
+			m.resolved[r.Asset] = true
+			m.mu.Unlock()
+		}
+	}
+}
+
+func (m *Monitor) mark(user common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[user]; ok {
+		m.dirty[user] = true
+	}
+}
+
+func (m *Monitor) markAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for user := range m.users {
+		m.dirty[user] = true
+	}
+}
+
+func (m *Monitor) watched() []common.Address {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]common.Address, 0, len(m.users))
+	for user := range m.users {
+		ret = append(ret, user)
+	}
+	return ret
+}
+
+// flush reads the loans of all marked users and notifies them.
+func (m *Monitor) flush(ctx context.Context) {
+	users := m.takeDirty()
+	m.notifyAll(users, m.read(ctx, users))
+}
+
+// takeDirty returns the marked users and clears the marks.
+func (m *Monitor) takeDirty() []common.Address {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]common.Address, 0, len(m.dirty))
+	for user := range m.dirty {
+		ret = append(ret, user)
+		delete(m.dirty, user)
+	}
+	return ret
+}
+
+// read reads the states of the users' loans in batched Multicall calls. It returns nil if the
+// client has no Multicall contract or a call fails, and leaves out users whose loans can't be
+// looked up, so they read their own and surface the error.
+func (m *Monitor) read(ctx context.Context, users []common.Address) map[common.Address]*clients.LoanState {
+	if len(users) == 0 || m.client.MulticallAddress() == (common.Address{}) {
+		return nil
+	}
+	var loans []*clients.Loan
+	for _, user := range users {
+		loan, err := m.client.Loan(ctx, user)
+		if err != nil {
+			m.client.ForgetLoan(user)
+			continue
+		}
+		loans = append(loans, loan)
+	}
+	states, err := m.client.ReadLoans(ctx, loans)
+	if err != nil {
+		log.Printf("Error reading %d loans together: %v", len(loans), err)
+		return nil
+	}
+	return states
+}
+
+// notifyAll notifies the users that are still watched with the states of their loans.
+func (m *Monitor) notifyAll(users []common.Address, states map[common.Address]*clients.LoanState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, user := range users {
+		for _, ch := range m.users[user] {
+			notify(ch, states[user])
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package monitor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"clients"
+	"simulated"
+)
+
+func pending(ch <-chan *clients.LoanState) int {
+	n := 0
+	for {
+		select {
+		case <-ch:
+			n++
+		default:
+			return n
+		}
+	}
+}
+
+func TestNotificationsAreCoalesced(t *testing.T) {
+	m := New(nil)
+	// Flushes without reading loans, which needs a client.
+	flush := func() { m.notifyAll(m.takeDirty(), nil) }
+	user := common.HexToAddress("0x01")
+	updates := m.Watch(user)
+	if n := pending(updates); n != 1 {
+		t.Fatalf("got %d initial notifications, want 1", n)
+	}
+
+	// Several events in the same block result in a single notification.
+	m.mark(user)
+	m.markAll()
+	m.mark(user)
+	if n := pending(updates); n != 0 {
+		t.Fatalf("got %d notifications before the block, want 0", n)
+	}
+	flush()
+	flush()
+	if n := pending(updates); n != 1 {
+		t.Fatalf("got %d notifications after the block, want 1", n)
+	}
+
+	m.Unwatch(user, updates)
+	m.mark(user)
+	flush()
+	if n := pending(updates); n != 0 {
+		t.Errorf("got %d notifications after Unwatch, want 0", n)
+	}
+}
+
+func TestUnwatchKeepsOtherSubscriptions(t *testing.T) {
+	m := New(nil)
+	flush := func() { m.notifyAll(m.takeDirty(), nil) }
+	user := common.HexToAddress("0x01")
+	older := m.Watch(user)
+	newer := m.Watch(user)
+	pending(older)
+	pending(newer)
+
+	// The older subscription ending, such as when a new certificate replaces its registration, doesn't
+	// stop notifications to the newer one.
+	m.Unwatch(user, older)
+	m.mark(user)
+	flush()
+	if n := pending(newer); n != 1 {
+		t.Errorf("got %d notifications after unwatching another subscription, want 1", n)
+	}
+	if n := pending(older); n != 0 {
+		t.Errorf("got %d notifications after Unwatch, want 0", n)
+	}
+
+	m.Unwatch(user, newer)
+	if users := m.watched(); len(users) != 0 {
+		t.Errorf("watching %v after all subscriptions ended, want none", users)
+	}
+}
+
+func TestEventsDontReadLoans(t *testing.T) {
+	// Without a client, any lookup in the event loop would panic.
+	m := New(nil)
+	user := common.HexToAddress("0x01")
+	asset, aggregator := common.HexToAddress("0x02"), common.HexToAddress("0x03")
+	m.feeds[aggregator] = asset
+	m.Watch(user)
+
+	m.assetChanged(asset)
+	m.priceChanged(aggregator)
+	m.priceChanged(common.HexToAddress("0x04"))
+	if len(m.changed) != 1 || !m.changed[asset] {
+		t.Errorf("changed = %v, want only %v", m.changed, asset.Hex())
+	}
+	// The users of changed assets are only marked by the reader, which looks up their loans.
+	if len(m.dirty) != 0 {
+		t.Errorf("dirty = %v, want none before the reader runs", m.dirty)
+	}
+}
+
+func TestNotificationsCarryLatestState(t *testing.T) {
+	m := New(nil)
+	user := common.HexToAddress("0x01")
+	updates := m.Watch(user)
+	older, newer := &clients.LoanState{Block: 1}, &clients.LoanState{Block: 2}
+	m.notifyAll([]common.Address{user}, map[common.Address]*clients.LoanState{user: older})
+	m.notifyAll([]common.Address{user}, map[common.Address]*clients.LoanState{user: newer})
+	if got := <-updates; got != newer {
+		t.Errorf("notification = %+v, want the state of block 2", got)
+	}
+	if n := pending(updates); n != 0 {
+		t.Errorf("got %d more notifications, want 0", n)
+	}
+}
+
+func TestFlushReadsLoans(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chain := simulated.New(t)
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	debt := new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))
+	if err := chain.Borrow(ctx, collateral, debt); err != nil {
+		t.Fatal(err)
+	}
+	client, err := clients.NewClientWithBackend(chain.Params, chain.Backend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(client)
+	user := chain.User.Address()
+	updates := m.Watch(user)
+	if state := <-updates; state != nil {
+		t.Errorf("initial notification = %+v, want nil", state)
+	}
+	go m.Run(ctx)
+
+	// Mines blocks until the monitor has subscribed and reads the marked user on a new head.
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		m.mark(user)
+		if err := chain.Market.SetPrice(ctx, chain.DAI, simulated.DAIPrice); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case state := <-updates:
+			if state == nil || state.Err != nil || state.Account.Ratio() != 5000 {
+				t.Fatalf("notification = %+v, want the state of the loan at ratio 5000", state)
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no notification after new heads")
+		}
+	}
+}
@@ -0,0 +1,461 @@
+// Package pool spreads the bot's chain access over several nodes so protection doesn't stop when
+// one of them does.
+//
+// A `Pool` sends each call to the first healthy node in the configured order and fails over to the
+// next one when a node can't be reached. Nodes are checked periodically and are unhealthy while they
+// fail or lag behind the highest block seen. Subscriptions move to another node when theirs fails.
+// Reads that decide a repayment can require a quorum of nodes to agree with `QuorumCall`.
+package pool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"gas"
+)
+
+// Defaults of `Config`.
+const (
+	DefaultInterval = 15 * time.Second
+	DefaultMaxLag   = 3
+	DefaultTimeout  = 10 * time.Second
+)
+
+// ErrDisagreement is wrapped by the errors of `QuorumCall` when nodes return different results.
+var ErrDisagreement = errors.New("nodes disagree")
+
+// Backend is the access to a single node. It's the same as `clients.Backend`, which a `Pool`
+// implements over several of them.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Endpoint is a node of a pool.
+type Endpoint struct {
+	// Name identifies the node in logs. Node URIs often contain API keys, so they're not used as is.
+	Name    string
+	Backend Backend
+	// RPC makes raw JSON-RPC calls to the node, such as eth_feeHistory. It can be nil.
+	RPC gas.RPCCaller
+}
+
+// Config configures a `Pool`. Zero values use the defaults.
+type Config struct {
+	// Quorum is the number of nodes that must agree on the result of `QuorumCall`. Zero is 1.
+	Quorum int
+	// Interval is how often `Run` checks the nodes.
+	Interval time.Duration
+	// MaxLag is the number of blocks a node can be behind the highest block seen and stay healthy.
+	MaxLag uint64
+	// Timeout bounds the checks of the nodes and resubscriptions.
+	Timeout time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.Quorum == 0 {
+		c.Quorum = 1
+	}
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.MaxLag == 0 {
+		c.MaxLag = DefaultMaxLag
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	return c
+}
+
+// Pool is a `Backend` over several nodes.
+type Pool struct {
+	cfg   Config
+	nodes []*node
+	// closers close the connections of the nodes dialed by the pool.
+	closers []func()
+}
+
+// node is an endpoint with its health.
+type node struct {
+	Endpoint
+
+	mu      sync.Mutex
+	healthy bool
+}
+
+// New returns a pool of the endpoints, in order of preference. They're healthy until checked.
+func New(cfg Config, endpoints ...Endpoint) *Pool {
+	p := &Pool{cfg: cfg.withDefaults()}
+	for _, e := range endpoints {
+		p.nodes = append(p.nodes, &node{Endpoint: e, healthy: true})
+	}
+	return p
+}
+
+// Dial connects to the nodes at `uris`, in order of preference, and checks them. Nodes that can't be
+// dialed are left out, so the bot starts as long as one of them can be.
+func Dial(ctx context.Context, uris []string, cfg Config) (*Pool, error) {
+	var endpoints []Endpoint
+	var closers []func()
+	for _, uri := range uris {
+		name := nodeName(uri)
+		rpcClient, err := rpc.DialContext(ctx, uri)
+		if err != nil {
+			log.Printf("ALERT: can't dial node %s, leaving it out: %v", name, err)
+			continue
+		}
+		endpoints = append(endpoints, Endpoint{Name: name, Backend: ethclient.NewClient(rpcClient), RPC: rpcClient})
+		closers = append(closers, rpcClient.Close)
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("none of the %d nodes can be dialed", len(uris))
+	}
+	p := New(cfg, endpoints...)
+	p.closers = closers
+	p.Check(ctx)
+	return p, nil
+}
+
+// nodeName returns the host of the node at `uri`, leaving out credentials and API keys in its path.
+func nodeName(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return "node"
+	}
+	return u.Host
+}
+
+// Close closes the connections of the nodes dialed by the pool.
+func (p *Pool) Close() {
+	for _, c := range p.closers {
+		c()
+	}
+}
+
+// Quorum returns the number of nodes that must agree on the result of `QuorumCall`.
+func (p *Pool) Quorum() int {
+	return p.cfg.Quorum
+}
+
+// Check gets the block number of each node and updates their health.
+func (p *Pool) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+	heads := make([]uint64, len(p.nodes))
+	errs := make([]error, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, n := range p.nodes {
+		wg.Add(1)
+		go func(i int, n *node) {
+			defer wg.Done()
+			heads[i], errs[i] = n.Backend.BlockNumber(ctx)
+		}(i, n)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for i := range p.nodes {
+		if errs[i] == nil && heads[i] > highest {
+			highest = heads[i]
+		}
+	}
+	for i, n := range p.nodes {
+		err := errs[i]
+		if err == nil && heads[i]+p.cfg.MaxLag < highest {
+			err = fmt.Errorf("at block %d, more than %d behind %d", heads[i], p.cfg.MaxLag, highest)
+		}
+		n.mu.Lock()
+		wasHealthy := n.healthy
+		n.healthy = err == nil
+		n.mu.Unlock()
+		switch {
+		case err != nil && wasHealthy:
+			log.Printf("ALERT: node %s is unhealthy: %v", n.Name, err)
+		case err == nil && !wasHealthy:
+			log.Printf("Node %s is healthy again at block %d", n.Name, heads[i])
+		}
+	}
+}
+
+// Run checks the nodes every `Interval` until `ctx` is done.
+func (p *Pool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		p.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// healthyNodes returns the healthy nodes in order of preference.
+func (p *Pool) healthyNodes() []*node {
+	var ret []*node
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		if n.healthy {
+			ret = append(ret, n)
+		}
+		n.mu.Unlock()
+	}
+	return ret
+}
+
+// candidates returns the nodes to try a call on: the healthy ones in order of preference, then the
+// others as a last resort.
+func (p *Pool) candidates() []*node {
+	var healthy, others []*node
+	for _, n := range p.nodes {
+		n.mu.Lock()
+		if n.healthy {
+			healthy = append(healthy, n)
+		} else {
+			others = append(others, n)
+		}
+		n.mu.Unlock()
+	}
+	return append(healthy, others...)
+}
+
+// fail marks the node unhealthy until its next successful check.
+func (p *Pool) fail(n *node, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.healthy {
+		log.Printf("ALERT: node %s failed, failing over: %v", n.Name, err)
+	}
+	n.healthy = false
+}
+
+// answered reports whether `err` is an answer of the node, such as a revert or a missing receipt,
+// rather than a failure to reach it. Answers are returned as is instead of asking another node.
+// Nodes answer with JSON-RPC errors, and in-process backends revert with the EVM's error.
+func answered(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) || errors.Is(err, ethereum.NotFound) || errors.Is(err, vm.ErrExecutionReverted)
+}
+
+// do runs `f` on the candidate nodes until one of them answers.
+func (p *Pool) do(ctx context.Context, f func(n *node) error) error {
+	var err error
+	for _, n := range p.candidates() {
+		if err = f(n); err == nil || answered(err) || ctx.Err() != nil {
+			return err
+		}
+		p.fail(n, err)
+	}
+	return fmt.Errorf("all nodes failed: %w", err)
+}
+
+// QuorumCall runs `call` on healthy nodes at the latest block they all have, and returns the result
+// once `Quorum` of them agree. It fails if fewer nodes answer, or wraps `ErrDisagreement` if they
+// return different results, such as when one of them is compromised or serves a fork. A node
+// answering with an error, such as a revert, is left out of the vote like a node that failed, and
+// the error is returned if no quorum is reached without it.
+func (p *Pool) QuorumCall(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	var nodes []*node
+	var block uint64
+	for _, n := range p.healthyNodes() {
+		head, err := n.Backend.BlockNumber(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			p.fail(n, err)
+			continue
+		}
+		if len(nodes) == 0 || head < block {
+			block = head
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) < p.cfg.Quorum {
+		return nil, fmt.Errorf("%d healthy nodes, fewer than the quorum of %d", len(nodes), p.cfg.Quorum)
+	}
+
+	var want []byte
+	var first *node
+	var answerErrs []error
+	agreed := 0
+	for _, n := range nodes {
+		out, err := n.Backend.CallContract(ctx, call, new(big.Int).SetUint64(block))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			if answered(err) {
+				answerErrs = append(answerErrs, fmt.Errorf("node %s: %w", n.Name, err))
+			} else {
+				p.fail(n, err)
+			}
+			continue
+		}
+		if first == nil {
+			want, first = out, n
+		} else if !bytes.Equal(out, want) {
+			return nil, fmt.Errorf("%w: %s and %s return different results at block %d", ErrDisagreement,
+				first.Name, n.Name, block)
+		}
+		if agreed++; agreed == p.cfg.Quorum {
+			for _, err := range answerErrs {
+				log.Printf("ALERT: outvoted at block %d: %v", block, err)
+			}
+			return want, nil
+		}
+	}
+	if len(answerErrs) > 0 {
+		return nil, fmt.Errorf("%d nodes agreed at block %d, fewer than the quorum of %d: %w", agreed, block,
+			p.cfg.Quorum, answerErrs[len(answerErrs)-1])
+	}
+	return nil, fmt.Errorf("%d nodes answered at block %d, fewer than the quorum of %d", agreed, block,
+		p.cfg.Quorum)
+}
+
+// CallContext makes a raw JSON-RPC call on the first node that supports them. It lets a pool serve
+// the fee history gas strategy.
+func (p *Pool) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return p.do(ctx, func(n *node) error {
+		if n.RPC == nil {
+			return fmt.Errorf("node %s doesn't take raw calls", n.Name)
+		}
+		return n.RPC.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (p *Pool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := p.do(ctx, func(n *node) (err error) {
+		code, err = n.Backend.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (p *Pool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out []byte
+	err := p.do(ctx, func(n *node) (err error) {
+		out, err = n.Backend.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var head *types.Header
+	err := p.do(ctx, func(n *node) (err error) {
+		head, err = n.Backend.HeaderByNumber(ctx, number)
+		return err
+	})
+	return head, err
+}
+
+func (p *Pool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := p.do(ctx, func(n *node) (err error) {
+		code, err = n.Backend.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := p.do(ctx, func(n *node) (err error) {
+		nonce, err = n.Backend.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (p *Pool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := p.do(ctx, func(n *node) (err error) {
+		price, err = n.Backend.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (p *Pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tip *big.Int
+	err := p.do(ctx, func(n *node) (err error) {
+		tip, err = n.Backend.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (p *Pool) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := p.do(ctx, func(n *node) (err error) {
+		gas, err = n.Backend.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction sends the transaction through the first node that can be reached. Sending it
+// again through another node is harmless since it's the same signed transaction.
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return p.do(ctx, func(n *node) error {
+		return n.Backend.SendTransaction(ctx, tx)
+	})
+}
+
+func (p *Pool) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := p.do(ctx, func(n *node) (err error) {
+		logs, err = n.Backend.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := p.do(ctx, func(n *node) (err error) {
+		receipt, err = n.Backend.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (p *Pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := p.do(ctx, func(n *node) (err error) {
+		balance, err = n.Backend.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return balance, err
+}
+
+func (p *Pool) BlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := p.do(ctx, func(n *node) (err error) {
+		number, err = n.Backend.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
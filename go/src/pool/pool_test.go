@@ -0,0 +1,314 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/event"
+
+	"erc20"
+	"simulated"
+)
+
+var errDown = errors.New("connection refused")
+
+// flaky is a node of a simulated chain that can go down, lag or corrupt the results of calls.
+type flaky struct {
+	Backend
+
+	mu      sync.Mutex
+	down    bool
+	lag     uint64
+	corrupt bool
+	// reverts makes calls revert, like on a node serving a fork where the contract differs.
+	reverts bool
+	// kill ends the subscriptions of the node when closed.
+	kill chan struct{}
+}
+
+func newFlaky(chain *simulated.Chain) *flaky {
+	return &flaky{Backend: chain.Backend(), kill: make(chan struct{})}
+}
+
+func (f *flaky) set(change func(f *flaky)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f)
+}
+
+func (f *flaky) state() (bool, uint64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.down, f.lag, f.corrupt
+}
+
+func (f *flaky) BlockNumber(ctx context.Context) (uint64, error) {
+	down, lag, _ := f.state()
+	if down {
+		return 0, errDown
+	}
+	n, err := f.Backend.BlockNumber(ctx)
+	return n - lag, err
+}
+
+func (f *flaky) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if down, _, _ := f.state(); down {
+		return nil, errDown
+	}
+	return f.Backend.BalanceAt(ctx, account, blockNumber)
+}
+
+func (f *flaky) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	down, _, corrupt := f.state()
+	if down {
+		return nil, errDown
+	}
+	f.mu.Lock()
+	reverts := f.reverts
+	f.mu.Unlock()
+	if reverts {
+		return nil, vm.ErrExecutionReverted
+	}
+	out, err := f.Backend.CallContract(ctx, call, blockNumber)
+	if err == nil && corrupt && len(out) > 0 {
+		out = append([]byte(nil), out...)
+		out[len(out)-1]++
+	}
+	return out, err
+}
+
+func (f *flaky) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if down, _, _ := f.state(); down {
+		return nil, errDown
+	}
+	heads := make(chan *types.Header)
+	sub, err := f.Backend.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case h := <-heads:
+				select {
+				case ch <- h:
+				case <-quit:
+					return nil
+				}
+			case <-f.kill:
+				return errDown
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// poolTest is a pool of two nodes of a simulated chain where the user has 10 ETH of collateral.
+type poolTest struct {
+	chain        *simulated.Chain
+	first, other *flaky
+	p            *Pool
+}
+
+func newPoolTest(t *testing.T, cfg Config) *poolTest {
+	chain := simulated.New(t)
+	if err := chain.Market.SetCollateral(context.Background(), chain.User.Address(), chain.Market.WETH,
+		simulated.Funds); err != nil {
+		t.Fatal(err)
+	}
+	first, other := newFlaky(chain), newFlaky(chain)
+	return &poolTest{
+		chain: chain,
+		first: first,
+		other: other,
+		p:     New(cfg, Endpoint{Name: "first", Backend: first}, Endpoint{Name: "other", Backend: other}),
+	}
+}
+
+func (pt *poolTest) healthy() []string {
+	var names []string
+	for _, n := range pt.p.healthyNodes() {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+func TestFailover(t *testing.T) {
+	ctx := context.Background()
+	pt := newPoolTest(t, Config{})
+	user := pt.chain.User.Address()
+	pt.first.set(func(f *flaky) { f.down = true })
+	balance, err := pt.p.BalanceAt(ctx, user, nil)
+	if err != nil || balance.Cmp(simulated.Funds) != 0 {
+		t.Fatalf("BalanceAt(%v) with the first node down = %v, %v, want %v, nil", user.Hex(), balance, err,
+			simulated.Funds)
+	}
+	if got := pt.healthy(); len(got) != 1 || got[0] != "other" {
+		t.Errorf("healthy nodes after failing over = %v, want [other]", got)
+	}
+
+	// The first node stays unhealthy until it's checked again.
+	pt.first.set(func(f *flaky) { f.down = false })
+	pt.p.Check(ctx)
+	if got := pt.healthy(); len(got) != 2 || got[0] != "first" {
+		t.Errorf("healthy nodes after the first recovered = %v, want [first other]", got)
+	}
+
+	pt.first.set(func(f *flaky) { f.down = true })
+	pt.other.set(func(f *flaky) { f.down = true })
+	if _, err := pt.p.BalanceAt(ctx, user, nil); !errors.Is(err, errDown) {
+		t.Errorf("BalanceAt(%v) with all nodes down = _, %v, want %v", user.Hex(), err, errDown)
+	}
+}
+
+func TestCheckLag(t *testing.T) {
+	pt := newPoolTest(t, Config{MaxLag: 2})
+	pt.other.set(func(f *flaky) { f.lag = 2 })
+	pt.p.Check(context.Background())
+	if got := pt.healthy(); len(got) != 2 {
+		t.Errorf("healthy nodes with the other 2 blocks behind = %v, want both", got)
+	}
+	pt.other.set(func(f *flaky) { f.lag = 3 })
+	pt.p.Check(context.Background())
+	if got := pt.healthy(); len(got) != 1 || got[0] != "first" {
+		t.Errorf("healthy nodes with the other 3 blocks behind = %v, want [first]", got)
+	}
+}
+
+func TestAnswersDontFailOver(t *testing.T) {
+	ctx := context.Background()
+	pt := newPoolTest(t, Config{})
+	// The mock has no response for `totalSupply`, so the call reverts without a reason.
+	token, err := erc20.NewErc20Caller(pt.chain.DAI.Asset.Address, pt.p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := token.TotalSupply(nil); err == nil {
+		t.Fatalf("TotalSupply() = _, nil, want a revert")
+	}
+	if got := pt.healthy(); len(got) != 2 {
+		t.Errorf("healthy nodes after a revert = %v, want both", got)
+	}
+	if err := pt.chain.DAI.Asset.Reverts(ctx, "totalSupply", nil, "paused"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := token.TotalSupply(nil); err == nil {
+		t.Fatalf("TotalSupply() = _, nil, want a revert")
+	}
+	if got := pt.healthy(); len(got) != 2 {
+		t.Errorf("healthy nodes after a revert with a reason = %v, want both", got)
+	}
+}
+
+func TestQuorumCall(t *testing.T) {
+	ctx := context.Background()
+	pt := newPoolTest(t, Config{Quorum: 2})
+	aToken := pt.chain.Market.WETH.AToken.Address
+	balanceOf := func() (*big.Int, error) {
+		token, err := erc20.NewErc20Caller(aToken, &quorumCaller{pt.p})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token.BalanceOf(&bind.CallOpts{Context: ctx}, pt.chain.User.Address())
+	}
+	if balance, err := balanceOf(); err != nil || balance.Cmp(simulated.Funds) != 0 {
+		t.Errorf("balanceOf through a quorum = %v, %v, want %v, nil", balance, err, simulated.Funds)
+	}
+
+	pt.other.set(func(f *flaky) { f.corrupt = true })
+	if _, err := balanceOf(); !errors.Is(err, ErrDisagreement) {
+		t.Errorf("balanceOf through a quorum with a corrupt node = _, %v, want %v", err, ErrDisagreement)
+	}
+
+	pt.other.set(func(f *flaky) { f.corrupt, f.down = false, true })
+	if _, err := balanceOf(); err == nil {
+		t.Errorf("balanceOf through a quorum of 2 with 1 node up = _, nil, want an error")
+	}
+}
+
+func TestQuorumCallOutvotesRevertingNode(t *testing.T) {
+	ctx := context.Background()
+	pt := newPoolTest(t, Config{})
+	forked := newFlaky(pt.chain)
+	forked.set(func(f *flaky) { f.reverts = true })
+	balanceOf := func(quorum int) (*big.Int, error) {
+		p := New(Config{Quorum: quorum}, Endpoint{Name: "forked", Backend: forked},
+			Endpoint{Name: "first", Backend: pt.first}, Endpoint{Name: "other", Backend: pt.other})
+		token, err := erc20.NewErc20Caller(pt.chain.Market.WETH.AToken.Address, &quorumCaller{p})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token.BalanceOf(&bind.CallOpts{Context: ctx}, pt.chain.User.Address())
+	}
+	if balance, err := balanceOf(2); err != nil || balance.Cmp(simulated.Funds) != 0 {
+		t.Errorf("balanceOf through a quorum of 2 with 1 of 3 nodes reverting = %v, %v, want %v, nil", balance,
+			err, simulated.Funds)
+	}
+	if _, err := balanceOf(3); !errors.Is(err, vm.ErrExecutionReverted) {
+		t.Errorf("balanceOf through a quorum of 3 with 1 of 3 nodes reverting = _, %v, want %v", err,
+			vm.ErrExecutionReverted)
+	}
+}
+
+// quorumCaller makes the calls of bindings through `QuorumCall`.
+type quorumCaller struct {
+	*Pool
+}
+
+func (q *quorumCaller) CallContract(ctx context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	return q.QuorumCall(ctx, call)
+}
+
+func TestResubscribe(t *testing.T) {
+	ctx := context.Background()
+	pt := newPoolTest(t, Config{})
+	heads := make(chan *types.Header, 16)
+	sub, err := pt.p.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		t.Fatalf("SubscribeNewHead(...) = _, %v, want _, nil", err)
+	}
+	defer sub.Unsubscribe()
+
+	close(pt.first.kill)
+	// Waits for the subscription to move before mining a block.
+	for deadline := time.Now().Add(5 * time.Second); len(pt.healthy()) != 1; {
+		if time.Now().After(deadline) {
+			t.Fatalf("the first node wasn't failed over")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := pt.chain.Market.SetPrice(ctx, pt.chain.DAI, simulated.DAIPrice); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-heads:
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no new head after moving the subscription")
+	}
+
+	// Once no node takes the subscription, it fails.
+	close(pt.other.kill)
+	pt.first.set(func(f *flaky) { f.down = true })
+	pt.other.set(func(f *flaky) { f.down = true })
+	select {
+	case err := <-sub.Err():
+		if !errors.Is(err, errDown) {
+			t.Errorf("subscription error = %v, want %v", err, errDown)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("subscription didn't fail with all nodes down")
+	}
+}
@@ -0,0 +1,102 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// subscribeFunc subscribes on a node.
+type subscribeFunc func(ctx context.Context, b Backend) (ethereum.Subscription, error)
+
+// subscription follows a subscription from node to node as they fail. It only fails itself when no
+// node takes the subscription. Events emitted while moving may be missed.
+type subscription struct {
+	err  chan error
+	quit chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+func (s *subscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *subscription) Unsubscribe() {
+	s.once.Do(func() { close(s.quit) })
+	<-s.done
+}
+
+// subscribe subscribes with `f` on the first candidate node that takes it, and moves the
+// subscription to another node when it fails.
+func (p *Pool) subscribe(ctx context.Context, desc string, f subscribeFunc) (ethereum.Subscription, error) {
+	n, sub, err := p.subscribeAny(ctx, f)
+	if err != nil {
+		return nil, fmt.Errorf("subscribing to %s: %w", desc, err)
+	}
+	s := &subscription{
+		err:  make(chan error, 1),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(s.done)
+		defer close(s.err)
+		for {
+			select {
+			case <-s.quit:
+				sub.Unsubscribe()
+				return
+			case err, ok := <-sub.Err():
+				sub.Unsubscribe()
+				if !ok {
+					err = errors.New("subscription ended")
+				}
+				p.fail(n, fmt.Errorf("%s subscription: %w", desc, err))
+				ctx, cancel := context.WithTimeout(context.Background(), p.cfg.Timeout)
+				next, nextSub, err := p.subscribeAny(ctx, f)
+				cancel()
+				if err != nil {
+					s.err <- fmt.Errorf("resubscribing to %s: %w", desc, err)
+					return
+				}
+				log.Printf("Moved %s subscription from node %s to %s", desc, n.Name, next.Name)
+				n, sub = next, nextSub
+			}
+		}
+	}()
+	return s, nil
+}
+
+// subscribeAny subscribes with `f` on the first candidate node that takes it.
+func (p *Pool) subscribeAny(ctx context.Context, f subscribeFunc) (*node, ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	var subscribed *node
+	err := p.do(ctx, func(n *node) (err error) {
+		sub, err = f(ctx, n.Backend)
+		subscribed = n
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return subscribed, sub, nil
+}
+
+func (p *Pool) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return p.subscribe(ctx, "new heads", func(ctx context.Context, b Backend) (ethereum.Subscription, error) {
+		return b.SubscribeNewHead(ctx, ch)
+	})
+}
+
+func (p *Pool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (
+	ethereum.Subscription, error) {
+	return p.subscribe(ctx, "logs", func(ctx context.Context, b Backend) (ethereum.Subscription, error) {
+		return b.SubscribeFilterLogs(ctx, query, ch)
+	})
+}
@@ -2,29 +2,78 @@ package repayment
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	"clients"
+	"delegation"
+	"gas"
+	"swap"
 )
 
-// Deploy deploys the contract using the bot account.
-func Deploy(ctx context.Context, c *clients.Client) (*Repayment, common.Address, error) {
+// Deploy deploys the contract using the bot account, which owns it, and allows swapping collateral
+// through `quoters`. If `quoters` is nil, `swap.Defaults` are allowed. The contract uses the market
+// of the configured Lending Pool and verifies certificates in the domain configured by the client's
+// parameters, with itself as the verifying contract.
+func Deploy(ctx context.Context, c *clients.Client, quoters []swap.Quoter) (*Repayment, common.Address, error) {
 	var addr common.Address
 	var r *Repayment
-	if err := c.ExecuteAsBot(ctx, "deploying protection contract",
+	domain := delegation.DomainFor(c)
+	provider, err := c.LendingPool().GetAddressesProvider(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, common.BytesToAddress(nil), fmt.Errorf("getting addresses provider: %w", err)
+	}
+	if _, err := c.ExecuteAsBot(ctx, gas.Normal, "deploying protection contract",
 		func(txr *bind.TransactOpts) (*types.Transaction, error) {
 			var tx *types.Transaction
 			var err error
 			// Gas limit estimation fails for deployment. It crashes trying to resolve a transaction
 			// recipient, but there's none for deployment.
 			txr.GasLimit = uint64(9500000)
-			addr, tx, r, err = DeployRepayment(txr, c.ETH())
+			addr, tx, r, err = DeployRepayment(txr, c.ETH(), provider, domain.Version, domain.Salt)
 			return tx, err
 		}); err != nil {
 		return nil, common.BytesToAddress(nil), err
 	}
+	// The contract must repay loans in the market the bot monitors.
+	pool, err := r.LENDINGPOOL(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, common.BytesToAddress(nil), fmt.Errorf("getting the contract's Lending Pool: %w", err)
+	}
+	if pool != c.LendingPoolAddress() {
+		return nil, common.BytesToAddress(nil), fmt.Errorf("contract %v uses Lending Pool %v, not the configured %v",
+			addr, pool, c.LendingPoolAddress())
+	}
+	if quoters == nil {
+		quoters = swap.Defaults(c.ChainID())
+	}
+	if err := Allow(ctx, c, r, quoters); err != nil {
+		return nil, common.BytesToAddress(nil), err
+	}
 	return r, addr, nil
 }
+
+// Allow lets the contract swap collateral through the routers of `quoters` and approve their
+// spenders. The contract only calls the aggregator contracts its owner allowed, which is the bot
+// for contracts it deployed.
+func Allow(ctx context.Context, c *clients.Client, r *Repayment, quoters []swap.Quoter) error {
+	var routers, spenders []common.Address
+	for _, q := range quoters {
+		routers = append(routers, q.Router())
+		spenders = append(spenders, q.Spender())
+	}
+	if _, err := c.ExecuteAsBot(ctx, gas.Normal, "allowing swap routers",
+		func(txr *bind.TransactOpts) (*types.Transaction, error) {
+			return r.SetRouters(txr, routers, true)
+		}); err != nil {
+		return err
+	}
+	_, err := c.ExecuteAsBot(ctx, gas.Normal, "allowing swap spenders",
+		func(txr *bind.TransactOpts) (*types.Transaction, error) {
+			return r.SetSpenders(txr, spenders, true)
+		})
+	return err
+}
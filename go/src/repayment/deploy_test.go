@@ -0,0 +1,48 @@
+package repayment
+
+import (
+	"context"
+	"testing"
+
+	"clients"
+	"simulated"
+	"swap"
+)
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	c, err := clients.NewClientWithBackend(chain.Params, chain.Backend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, addr, err := Deploy(ctx, c, []swap.Quoter{chain.Router})
+	if err != nil {
+		t.Fatalf("Deploy(...) = _, _, %v, want _, _, nil", err)
+	}
+	code, err := c.ETH().CodeAt(ctx, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) == 0 {
+		t.Errorf("no code at the deployed address %v", addr.Hex())
+	}
+	// The contract calls the Lending Pool of the chain's market.
+	pool, err := r.LENDINGPOOL(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool != simulated.LendingPool {
+		t.Errorf("LENDINGPOOL() = %v, want %v", pool.Hex(), simulated.LendingPool.Hex())
+	}
+	// The bot owns the contract and allowed the aggregator.
+	if owner, err := r.Owner(nil); err != nil || owner != chain.Bot.Address() {
+		t.Errorf("Owner() = %v, %v, want %v, nil", owner.Hex(), err, chain.Bot.Address().Hex())
+	}
+	if ok, err := r.Routers(nil, chain.Router.Router()); err != nil || !ok {
+		t.Errorf("Routers(%v) = %t, %v, want true, nil", chain.Router.Router().Hex(), ok, err)
+	}
+	if ok, err := r.Spenders(nil, chain.Router.Spender()); err != nil || !ok {
+		t.Errorf("Spenders(%v) = %t, %v, want true, nil", chain.Router.Spender().Hex(), ok, err)
+	}
+}
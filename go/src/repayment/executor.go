@@ -27,19 +27,19 @@ var (
 )
 
 // RepaymentABI is the input ABI used to generate the binding from.
-const RepaymentABI = "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ADDRESSES_PROVIDER\",\"outputs\":[{\"internalType\":\"contractILendingPoolAddressesProvider\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"LENDING_POOL\",\"outputs\":[{\"internalType\":\"contractILendingPool\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_botDelegationSignature\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"_sDebtToken\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_vDebtToken\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_dAsset\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_packedParams\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_packedParamsSignature\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_assets\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_amounts\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_premiums\",\"type\":\"uint256[]\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_params\",\"type\":\"bytes\"}],\"name\":\"executeOperation\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+const RepaymentABI = "[{\"inputs\":[{\"internalType\":\"contract ILendingPoolAddressesProvider\",\"name\":\"_provider\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"_version\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_salt\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"name\":\"Revoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"router\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"RouterAllowed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"SpenderAllowed\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ADDRESSES_PROVIDER\",\"outputs\":[{\"internalType\":\"contract ILendingPoolAddressesProvider\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"LENDING_POOL\",\"outputs\":[{\"internalType\":\"contract ILendingPool\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_botDelegation\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"_sDebtToken\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_vDebtToken\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_dAsset\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_packedParams\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"_packedParamsSignature\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_assets\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_amounts\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"_premiums\",\"type\":\"uint256[]\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_params\",\"type\":\"bytes\"}],\"name\":\"executeOperation\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"minNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_nonce\",\"type\":\"uint256\"}],\"name\":\"revoke\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"routers\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_routers\",\"type\":\"address[]\"},{\"internalType\":\"bool\",\"name\":\"_allowed\",\"type\":\"bool\"}],\"name\":\"setRouters\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"_spenders\",\"type\":\"address[]\"},{\"internalType\":\"bool\",\"name\":\"_allowed\",\"type\":\"bool\"}],\"name\":\"setSpenders\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"spenders\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // RepaymentBin is the compiled bytecode used for deploying new contracts.
-var RepaymentBin = "0x60e06040523480156200001157600080fd5b5073b53c1a33016b2dc2ff3653530bff1848a515c8c573ffffffffffffffffffffffffffffffffffffffff1660808173ffffffffffffffffffffffffffffffffffffffff1660601b81525050737d2768de32b0b80b7a3454c06bdac94a69ddc7a973ffffffffffffffffffffffffffffffffffffffff1660a08173ffffffffffffffffffffffffffffffffffffffff1660601b8152505060004690506200017d60405180608001604052806040518060400160405280601f81526020017f41415645204c69717569646174696f6e2050726f74656374696f6e20426f740081525081526020016040518060400160405280600181526020017f310000000000000000000000000000000000000000000000000000000000000081525081526020018381526020016040518060400160405280602081526020017f5355254e36676d756d766a2e417b40422c536457587456676728426f663953418152508152506200018b60201b60201c565b60c08181525050506200029d565b60007f613742be5859fb0eadf208d5acbaea935189bb3cdb9686525166ede1daab2eb98260000151805190602001208360200151805190602001208460400151856060015180519060200120604051602001620001ed9594939291906200022c565b604051602081830303815290604052805190602001209050919050565b620002158162000289565b82525050565b620002268162000293565b82525050565b600060a0820190506200024360008301886200020a565b6200025260208301876200020a565b6200026160408301866200020a565b6200027060608301856200021b565b6200027f60808301846200020a565b9695505050505050565b6000819050919050565b6000819050919050565b60805160601c60a05160601c60c051612cd4620002e86000398061111e52508061043e528061071d52806107ff5280610a935280610d635280610db552508060db5250612cd46000f3fe608060405234801561001057600080fd5b506004361061004c5760003560e01c80630542975c1461005157806354f5c2c81461006f578063920f5c841461008b578063b4dcfc77146100bb575b600080fd5b6100596100d9565b604051610066919061273f565b60405180910390f35b61008960048036038101906100849190611aba565b6100fd565b005b6100a560048036038101906100a09190611ba0565b6104e2565b6040516100b291906126b6565b60405180910390f35b6100c3610d61565b6040516100d0919061275a565b60405180910390f35b7f000000000000000000000000000000000000000000000000000000000000000081565b6000606060008773ffffffffffffffffffffffffffffffffffffffff166370a082318b6040518263ffffffff1660e01b815260040161013c91906124ef565b60206040518083038186803b15801561015457600080fd5b505afa158015610168573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061018c9190611d1c565b905060008773ffffffffffffffffffffffffffffffffffffffff166370a082318c6040518263ffffffff1660e01b81526004016101c991906124ef565b60206040518083038186803b1580156101e157600080fd5b505afa1580156101f5573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906102199190611d1c565b9050600081830111610260576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610257906128f5565b60405180910390fd5b80820193506040518060a001604052808c73ffffffffffffffffffffffffffffffffffffffff1681526020013373ffffffffffffffffffffffffffffffffffffffff1681526020018b8152602001878152602001868152506040516020016102c89190612935565b604051602081830303815290604052925050506060600167ffffffffffffffff811180156102f557600080fd5b506040519080825280602002602001820160405280156103245781602001602082028036833780820191505090505b509050858160008151811061033557fe5b602002602001019073ffffffffffffffffffffffffffffffffffffffff16908173ffffffffffffffffffffffffffffffffffffffff16815250506060600167ffffffffffffffff8111801561038957600080fd5b506040519080825280602002602001820160405280156103b85781602001602082028036833780820191505090505b50905083816000815181106103c957fe5b6020026020010181815250506060600167ffffffffffffffff811180156103ef57600080fd5b5060405190808252806020026020018201604052801561041e5781602001602082028036833780820191505090505b50905060008160008151811061043057fe5b6020026020010181815250507f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663ab9c4b5d30858585308a60006040518863ffffffff1660e01b81526004016104a29796959493929190612541565b600060405180830381600087803b1580156104bc57600080fd5b505af11580156104d0573d6000803e3d6000fd5b50505050505050505050505050505050565b600060018a8a9050146104f457600080fd5b60008888600081811061050357fe5b905060200201351161054a576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161054190612795565b60405180910390fd5b61055261141d565b83838101906105619190611cb1565b905061056c81610d85565b60008b8b600081811061057b57fe5b90506020020160208101906105909190611a02565b90508073ffffffffffffffffffffffffffffffffffffffff1663095ea7b3737d2768de32b0b80b7a3454c06bdac94a69ddc7a98c8c60008181106105d057fe5b905060200201356040518363ffffffff1660e01b81526004016105f49291906125cc565b602060405180830381600087803b15801561060e57600080fd5b505af1158015610622573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906106469190611c88565b610685576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161067c90612875565b60405180910390fd5b6000806106b784600001518f8f600081811061069d57fe5b90506020020160208101906106b29190611a02565b610da8565b915091508b8b60008181106106c857fe5b9050602002013581830114610712576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161070990612915565b60405180910390fd5b60008211156107f4577f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663573ade818f8f600081811061076457fe5b90506020020160208101906107799190611a02565b84600188600001516040518563ffffffff1660e01b81526004016107a0949392919061262c565b602060405180830381600087803b1580156107ba57600080fd5b505af11580156107ce573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906107f29190611d1c565b505b60008111156108d6577f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663573ade818f8f600081811061084657fe5b905060200201602081019061085b9190611a02565b83600288600001516040518563ffffffff1660e01b81526004016108829493929190612671565b602060405180830381600087803b15801561089c57600080fd5b505af11580156108b0573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906108d49190611d1c565b505b505050600080600080606085606001518060200190518101906108f99190611a2b565b945094509450945094508f8f600081811061091057fe5b90506020020160208101906109259190611a02565b73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614610992576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161098990612815565b60405180910390fd5b60008c8c60008181106109a157fe5b905060200201358f8f60008181106109b557fe5b905060200201350190508573ffffffffffffffffffffffffffffffffffffffff166323b872dd886000015130876040518463ffffffff1660e01b8152600401610a009392919061250a565b602060405180830381600087803b158015610a1a57600080fd5b505af1158015610a2e573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610a529190611c88565b610a91576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610a8890612835565b60405180910390fd5b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166369328dec8686306040518463ffffffff1660e01b8152600401610aee939291906125f5565b602060405180830381600087803b158015610b0857600080fd5b505af1158015610b1c573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610b409190611d1c565b8414610b81576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610b78906127f5565b60405180910390fd5b6000610b8e868685610f93565b905060008490508073ffffffffffffffffffffffffffffffffffffffff1663a9059cbb8a600001518585036040518363ffffffff1660e01b8152600401610bd69291906125cc565b602060405180830381600087803b158015610bf057600080fd5b505af1158015610c04573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610c289190611c88565b610c67576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610c5e90612855565b60405180910390fd5b8073ffffffffffffffffffffffffffffffffffffffff1663095ea7b3737d2768de32b0b80b7a3454c06bdac94a69ddc7a9856040518363ffffffff1660e01b8152600401610cb69291906125cc565b602060405180830381600087803b158015610cd057600080fd5b505af1158015610ce4573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610d089190611c88565b610d47576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610d3e906127d5565b60405180910390fd5b600199505050505050505050509998505050505050505050565b7f000000000000000000000000000000000000000000000000000000000000000081565b610d9c81600001518260200151836040015161111a565b610da581611210565b50565b600080610db3611478565b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166335ea6a75856040518263ffffffff1660e01b8152600401610e0c91906124ef565b6101806040518083038186803b158015610e2557600080fd5b505afa158015610e39573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610e5d9190611cf2565b9050600081610100015173ffffffffffffffffffffffffffffffffffffffff166370a08231876040518263ffffffff1660e01b8152600401610e9f91906124ef565b60206040518083038186803b158015610eb757600080fd5b505afa158015610ecb573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610eef9190611d1c565b9050600082610120015173ffffffffffffffffffffffffffffffffffffffff166370a08231886040518263ffffffff1660e01b8152600401610f3191906124ef565b60206040518083038186803b158015610f4957600080fd5b505afa158015610f5d573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610f819190611d1c565b90508181945094505050509250929050565b60008373ffffffffffffffffffffffffffffffffffffffff1663095ea7b373111111125434b319222cdbf8c261674adb56f3ae856040518363ffffffff1660e01b8152600401610fe49291906125cc565b602060405180830381600087803b158015610ffe57600080fd5b505af1158015611012573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906110369190611c88565b506000606073111111125434b319222cdbf8c261674adb56f3ae73ffffffffffffffffffffffffffffffffffffffff168460405161107491906124a1565b6000604051808303816000865af19150503d80600081146110b1576040519150601f19603f3d011682016040523d82523d6000602084013e6110b6565b606091505b5091509150816110fb576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016110f290612895565b60405180910390fd5b8080602001905181019061110f9190611d1c565b925050509392505050565b60007f000000000000000000000000000000000000000000000000000000000000000061116a60405180602001604052808673ffffffffffffffffffffffffffffffffffffffff168152506112a3565b60405160200161117b9291906124b8565b6040516020818303038152906040528051906020012090508373ffffffffffffffffffffffffffffffffffffffff166111b482846112f9565b73ffffffffffffffffffffffffffffffffffffffff161461120a576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611201906128b5565b60405180910390fd5b50505050565b60008160600151805190602001209050816020015173ffffffffffffffffffffffffffffffffffffffff166112498284608001516112f9565b73ffffffffffffffffffffffffffffffffffffffff161461129f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161129690612775565b60405180910390fd5b5050565b60007f5acce4118754599ab021c0a0f4b08f773105fab742d49f4198d03607f2968c2982600001516040516020016112dc9291906126d1565b604051602081830303815290604052805190602001209050919050565b6000604182511461133f576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611336906128d5565b60405180910390fd5b60008060006020850151925060408501519150606085015160001a9050601b8160ff16101561136f57601b810190505b601b8160ff1614806113845750601c8160ff16145b6113c3576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016113ba906127b5565b60405180910390fd5b600186828585604051600081526020016040526040516113e694939291906126fa565b6020604051602081039080840390855afa158015611408573d6000803e3d6000fd5b50505060206040510351935050505092915050565b6040518060a00160405280600073ffffffffffffffffffffffffffffffffffffffff168152602001600073ffffffffffffffffffffffffffffffffffffffff1681526020016060815260200160608152602001606081525090565b60405180610180016040528061148c61159b565b815260200160006fffffffffffffffffffffffffffffffff16815260200160006fffffffffffffffffffffffffffffffff16815260200160006fffffffffffffffffffffffffffffffff16815260200160006fffffffffffffffffffffffffffffffff16815260200160006fffffffffffffffffffffffffffffffff168152602001600064ffffffffff168152602001600073ffffffffffffffffffffffffffffffffffffffff168152602001600073ffffffffffffffffffffffffffffffffffffffff168152602001600073ffffffffffffffffffffffffffffffffffffffff168152602001600073ffffffffffffffffffffffffffffffffffffffff168152602001600060ff1681525090565b6040518060200160405280600081525090565b6000813590506115bd81612bfd565b92915050565b6000815190506115d281612bfd565b92915050565b6000815190506115e781612c14565b92915050565b60008083601f8401126115ff57600080fd5b8235905067ffffffffffffffff81111561161857600080fd5b60208301915083602082028301111561163057600080fd5b9250929050565b60008083601f84011261164957600080fd5b8235905067ffffffffffffffff81111561166257600080fd5b60208301915083602082028301111561167a57600080fd5b9250929050565b60008151905061169081612c2b565b92915050565b60008083601f8401126116a857600080fd5b8235905067ffffffffffffffff8111156116c157600080fd5b6020830191508360018202830111156116d957600080fd5b9250929050565b600082601f8301126116f157600080fd5b81356117046116ff82612984565b612957565b9150808252602083016020830185838301111561172057600080fd5b61172b838284612ba0565b50505092915050565b600082601f83011261174557600080fd5b815161175861175382612984565b612957565b9150808252602083016020830185838301111561177457600080fd5b61177f838284612baf565b50505092915050565b600060a0828403121561179a57600080fd5b6117a460a0612957565b905060006117b4848285016115ae565b60008301525060206117c8848285016115ae565b602083015250604082013567ffffffffffffffff8111156117e857600080fd5b6117f4848285016116e0565b604083015250606082013567ffffffffffffffff81111561181457600080fd5b611820848285016116e0565b606083015250608082013567ffffffffffffffff81111561184057600080fd5b61184c848285016116e0565b60808301525092915050565b60006020828403121561186a57600080fd5b6118746020612957565b90506000611884848285016119c3565b60008301525092915050565b600061018082840312156118a357600080fd5b6118ae610180612957565b905060006118be84828501611858565b60008301525060206118d2848285016119ae565b60208301525060406118e6848285016119ae565b60408301525060606118fa848285016119ae565b606083015250608061190e848285016119ae565b60808301525060a0611922848285016119ae565b60a08301525060c0611936848285016119d8565b60c08301525060e061194a848285016115c3565b60e08301525061010061195f848285016115c3565b61010083015250610120611975848285016115c3565b6101208301525061014061198b848285016115c3565b610140830152506101606119a1848285016119ed565b6101608301525092915050565b6000815190506119bd81612c42565b92915050565b6000815190506119d281612c59565b92915050565b6000815190506119e781612c70565b92915050565b6000815190506119fc81612c87565b92915050565b600060208284031215611a1457600080fd5b6000611a22848285016115ae565b91505092915050565b600080600080600060a08688031215611a4357600080fd5b6000611a51888289016115d8565b9550506020611a62888289016115d8565b9450506040611a73888289016119c3565b9350506060611a84888289016115d8565b925050608086015167ffffffffffffffff811115611aa157600080fd5b611aad88828901611734565b9150509295509295909350565b600080600080600080600060e0888a031215611ad557600080fd5b6000611ae38a828b016115ae565b975050602088013567ffffffffffffffff811115611b0057600080fd5b611b0c8a828b016116e0565b9650506040611b1d8a828b016115ae565b9550506060611b2e8a828b016115ae565b9450506080611b3f8a828b016115ae565b93505060a088013567ffffffffffffffff811115611b5c57600080fd5b611b688a828b016116e0565b92505060c088013567ffffffffffffffff811115611b8557600080fd5b611b918a828b016116e0565b91505092959891949750929550565b600080600080600080600080600060a08a8c031215611bbe57600080fd5b60008a013567ffffffffffffffff811115611bd857600080fd5b611be48c828d016115ed565b995099505060208a013567ffffffffffffffff811115611c0357600080fd5b611c0f8c828d01611637565b975097505060408a013567ffffffffffffffff811115611c2e57600080fd5b611c3a8c828d01611637565b95509550506060611c4d8c828d016115ae565b93505060808a013567ffffffffffffffff811115611c6a57600080fd5b611c768c828d01611696565b92509250509295985092959850929598565b600060208284031215611c9a57600080fd5b6000611ca884828501611681565b91505092915050565b600060208284031215611cc357600080fd5b600082013567ffffffffffffffff811115611cdd57600080fd5b611ce984828501611788565b91505092915050565b60006101808284031215611d0557600080fd5b6000611d1384828501611890565b91505092915050565b600060208284031215611d2e57600080fd5b6000611d3c848285016119c3565b91505092915050565b6000611d518383611d75565b60208301905092915050565b6000611d698383612474565b60208301905092915050565b611d7e81612a76565b82525050565b611d8d81612a76565b82525050565b6000611d9e826129d0565b611da88185612a0b565b9350611db3836129b0565b8060005b83811015611de4578151611dcb8882611d45565b9750611dd6836129f1565b925050600181019050611db7565b5085935050505092915050565b6000611dfc826129db565b611e068185612a1c565b9350611e11836129c0565b8060005b83811015611e42578151611e298882611d5d565b9750611e34836129fe565b925050600181019050611e15565b5085935050505092915050565b611e5881612a9a565b82525050565b611e6781612aa6565b82525050565b611e7e611e7982612aa6565b612be2565b82525050565b6000611e8f826129e6565b611e998185612a2d565b9350611ea9818560208601612baf565b611eb281612bec565b840191505092915050565b6000611ec8826129e6565b611ed28185612a3e565b9350611ee2818560208601612baf565b611eeb81612bec565b840191505092915050565b6000611f01826129e6565b611f0b8185612a4f565b9350611f1b818560208601612baf565b80840191505092915050565b611f3081612b22565b82525050565b611f3f81612b46565b82525050565b611f4e81612b6a565b82525050565b611f5d81612b7c565b82525050565b611f6c81612b8e565b82525050565b6000611f7f602383612a5a565b91507f7061636b656420706172616d6574657273206e6f74207369676e65642062792060008301527f626f7400000000000000000000000000000000000000000000000000000000006020830152604082019050919050565b6000611fe5600283612a6b565b91507f19010000000000000000000000000000000000000000000000000000000000006000830152600282019050919050565b6000612025601983612a5a565b91507f666c617368206c6f616e2077697468203020616d6f756e743f000000000000006000830152602082019050919050565b6000612065601283612a5a565b91507f7620776173206e6f74203237206f7220323800000000000000000000000000006000830152602082019050919050565b60006120a5602683612a5a565b91507f6661696c656420746f20617070726f766520666c617368206c6f616e2072657060008301527f61796d656e7400000000000000000000000000000000000000000000000000006020830152604082019050919050565b600061210b602683612a5a565b91507f7769746864726577206c657373207468616e207468652065787065637465642060008301527f616d6f756e7400000000000000000000000000000000000000000000000000006020830152604082019050919050565b6000612171601783612a5a565b91507f64656274206173736574206469646e2774206d617463680000000000000000006000830152602082019050919050565b60006121b1601a83612a5a565b91507f636f6c6c61746572616c207472616e73666572206661696c65640000000000006000830152602082019050919050565b60006121f1602583612a5a565b91507f7472616e7366657272696e672072656d61696e64657220746f2075736572206660008301527f61696c65640000000000000000000000000000000000000000000000000000006020830152604082019050919050565b6000612257602283612a5a565b91507f6661696c656420746f20617070726f766520746865206c656e64696e6720706f60008301527f6f6c0000000000000000000000000000000000000000000000000000000000006020830152604082019050919050565b60006122bd601183612a5a565b91507f31696e63682073776170206661696c65640000000000000000000000000000006000830152602082019050919050565b60006122fd601483612a5a565b91507f7369676e657220646964206e6f74206d617463680000000000000000000000006000830152602082019050919050565b600061233d601683612a5a565b91507f77726f6e67207369676e6174757265206c656e677468000000000000000000006000830152602082019050919050565b600061237d600e83612a5a565b91507f64656274206e6f7420666f756e640000000000000000000000000000000000006000830152602082019050919050565b60006123bd601e83612a5a565b91507f6c6f616e20616d6f756e7420646964206e6f74206d61746368206465627400006000830152602082019050919050565b600060a0830160008301516124086000860182611d75565b50602083015161241b6020860182611d75565b50604083015184820360408601526124338282611e84565b9150506060830151848203606086015261244d8282611e84565b915050608083015184820360808601526124678282611e84565b9150508091505092915050565b61247d81612afa565b82525050565b61248c81612afa565b82525050565b61249b81612b15565b82525050565b60006124ad8284611ef6565b915081905092915050565b60006124c382611fd8565b91506124cf8285611e6d565b6020820191506124df8284611e6d565b6020820191508190509392505050565b60006020820190506125046000830184611d84565b92915050565b600060608201905061251f6000830186611d84565b61252c6020830185611d84565b6125396040830184612483565b949350505050565b600060e082019050612556600083018a611d84565b81810360208301526125688189611d93565b9050818103604083015261257c8188611df1565b905081810360608301526125908187611df1565b905061259f6080830186611d84565b81810360a08301526125b18185611ebd565b90506125c060c0830184611f45565b98975050505050505050565b60006040820190506125e16000830185611d84565b6125ee6020830184612483565b9392505050565b600060608201905061260a6000830186611d84565b6126176020830185612483565b6126246040830184611d84565b949350505050565b60006080820190506126416000830187611d84565b61264e6020830186612483565b61265b6040830185611f54565b6126686060830184611d84565b95945050505050565b60006080820190506126866000830187611d84565b6126936020830186612483565b6126a06040830185611f63565b6126ad6060830184611d84565b95945050505050565b60006020820190506126cb6000830184611e4f565b92915050565b60006040820190506126e66000830185611e5e565b6126f36020830184611d84565b9392505050565b600060808201905061270f6000830187611e5e565b61271c6020830186612492565b6127296040830185611e5e565b6127366060830184611e5e565b95945050505050565b60006020820190506127546000830184611f27565b92915050565b600060208201905061276f6000830184611f36565b92915050565b6000602082019050818103600083015261278e81611f72565b9050919050565b600060208201905081810360008301526127ae81612018565b9050919050565b600060208201905081810360008301526127ce81612058565b9050919050565b600060208201905081810360008301526127ee81612098565b9050919050565b6000602082019050818103600083015261280e816120fe565b9050919050565b6000602082019050818103600083015261282e81612164565b9050919050565b6000602082019050818103600083015261284e816121a4565b9050919050565b6000602082019050818103600083015261286e816121e4565b9050919050565b6000602082019050818103600083015261288e8161224a565b9050919050565b600060208201905081810360008301526128ae816122b0565b9050919050565b600060208201905081810360008301526128ce816122f0565b9050919050565b600060208201905081810360008301526128ee81612330565b9050919050565b6000602082019050818103600083015261290e81612370565b9050919050565b6000602082019050818103600083015261292e816123b0565b9050919050565b6000602082019050818103600083015261294f81846123f0565b905092915050565b6000604051905081810181811067ffffffffffffffff8211171561297a57600080fd5b8060405250919050565b600067ffffffffffffffff82111561299b57600080fd5b601f19601f8301169050602081019050919050565b6000819050602082019050919050565b6000819050602082019050919050565b600081519050919050565b600081519050919050565b600081519050919050565b6000602082019050919050565b6000602082019050919050565b600082825260208201905092915050565b600082825260208201905092915050565b600082825260208201905092915050565b600082825260208201905092915050565b600081905092915050565b600082825260208201905092915050565b600081905092915050565b6000612a8182612ada565b9050919050565b6000612a9382612ada565b9050919050565b60008115159050919050565b6000819050919050565b60006fffffffffffffffffffffffffffffffff82169050919050565b600061ffff82169050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b600064ffffffffff82169050919050565b600060ff82169050919050565b6000612b2d82612b34565b9050919050565b6000612b3f82612ada565b9050919050565b6000612b5182612b58565b9050919050565b6000612b6382612ada565b9050919050565b6000612b7582612acc565b9050919050565b6000612b8782612afa565b9050919050565b6000612b9982612afa565b9050919050565b82818337600083830152505050565b60005b83811015612bcd578082015181840152602081019050612bb2565b83811115612bdc576000848401525b50505050565b6000819050919050565b6000601f19601f8301169050919050565b612c0681612a76565b8114612c1157600080fd5b50565b612c1d81612a88565b8114612c2857600080fd5b50565b612c3481612a9a565b8114612c3f57600080fd5b50565b612c4b81612ab0565b8114612c5657600080fd5b50565b612c6281612afa565b8114612c6d57600080fd5b50565b612c7981612b04565b8114612c8457600080fd5b50565b612c9081612b15565b8114612c9b57600080fd5b5056fea2646970667358221220e9c761ec150bd6e1e6162362ce0a30b4f044332a3b54673a4376f53455782c7b64736f6c634300060c0033"
+var RepaymentBin = "0x60e06040523480156200001157600080fd5b50336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555073b53c1a33016b2dc2ff3653530bff1848a515c8c573ffffffffffffffffffffffffffffffffffffffff1660808173ffffffffffffffffffffffffffffffffffffffff1681525050737d2768de32b0b80b7a3454c06bdac94a69ddc7a973ffffffffffffffffffffffffffffffffffffffff1660a08173ffffffffffffffffffffffffffffffffffffffff16815250506000469050620001b760405180608001604052806040518060400160405280601f81526020017f41415645204c69717569646174696f6e2050726f74656374696f6e20426f740081525081526020016040518060400160405280600181526020017f310000000000000000000000000000000000000000000000000000000000000081525081526020018381526020016040518060400160405280602081526020017f5355254e36676d756d766a2e417b40422c536457587456676728426f66395341815250815250620001c560201b60201c565b60c0818152505050620002d7565b60007f613742be5859fb0eadf208d5acbaea935189bb3cdb9686525166ede1daab2eb98260000151805190602001208360200151805190602001208460400151856060015180519060200120604051602001620002279594939291906200027a565b604051602081830303815290604052805190602001209050919050565b6000819050919050565b620002598162000244565b82525050565b6000819050919050565b62000274816200025f565b82525050565b600060a0820190506200029160008301886200024e565b620002a060208301876200024e565b620002af60408301866200024e565b620002be606083018562000269565b620002cd60808301846200024e565b9695505050505050565b60805160a05160c051613fb96200032a6000396000611b9201526000818161079601528181610b5901528181610c3301528181610f610152818161129501526116c4015260006101ff0152613fb96000f3fe608060405234801561001057600080fd5b506004361061009e5760003560e01c80638da5cb5b116100665780638da5cb5b14610159578063920f5c8414610177578063b4dcfc77146101a7578063b6c99111146101c5578063f2fde38b146101e15761009e565b80630542975c146100a35780632370a62c146100c157806354f5c2c8146100dd57806363a31bd4146100f957806380dd9a1f14610129575b600080fd5b6100ab6101fd565b6040516100b89190611f67565b60405180910390f35b6100db60048036038101906100d69190612033565b610221565b005b6100f760048036038101906100f29190612212565b6103c9565b005b610113600480360381019061010e9190612308565b61083a565b6040516101209190612344565b60405180910390f35b610143600480360381019061013e9190612308565b61085a565b6040516101509190612344565b60405180910390f35b61016161087a565b60405161016e919061236e565b60405180910390f35b610191600480360381019061018c9190612435565b61089e565b60405161019e9190612344565b60405180910390f35b6101af610f5f565b6040516101bc9190612552565b60405180910390f35b6101df60048036038101906101da9190612033565b610f83565b005b6101fb60048036038101906101f69190612308565b61112b565b005b7f000000000000000000000000000000000000000000000000000000000000000081565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146102af576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016102a6906125ca565b60405180910390fd5b60005b838390508110156103c35781600260008686858181106102d5576102d46125ea565b5b90506020020160208101906102ea9190612308565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff02191690831515021790555083838281811061034e5761034d6125ea565b5b90506020020160208101906103639190612308565b73ffffffffffffffffffffffffffffffffffffffff167f58863f0ebbc6d5514137a654a9c6ba3d90fa59b8a605dfc4c7b21aa505cbcb9c836040516103a89190612344565b60405180910390a280806103bb90612652565b9150506102b2565b50505050565b6000606060008773ffffffffffffffffffffffffffffffffffffffff166370a082318b6040518263ffffffff1660e01b8152600401610408919061236e565b602060405180830381865afa158015610425573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061044991906126c6565b905060008773ffffffffffffffffffffffffffffffffffffffff166370a082318c6040518263ffffffff1660e01b8152600401610486919061236e565b602060405180830381865afa1580156104a3573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906104c791906126c6565b9050600081836104d791906126f3565b11610517576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161050e90612773565b60405180910390fd5b60008680602001905181019061052d919061286b565b505050945050505050818361054291906126f3565b811115610584576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161057b90612989565b60405180910390fd5b6000811161059d57818361059891906126f3565b61059f565b805b94506040518060a001604052808d73ffffffffffffffffffffffffffffffffffffffff1681526020013373ffffffffffffffffffffffffffffffffffffffff1681526020018c8152602001888152602001878152506040516020016106049190612a91565b60405160208183030381529060405293505050506000600167ffffffffffffffff811115610635576106346120e7565b5b6040519080825280602002602001820160405280156106635781602001602082028036833780820191505090505b509050858160008151811061067b5761067a6125ea565b5b602002602001019073ffffffffffffffffffffffffffffffffffffffff16908173ffffffffffffffffffffffffffffffffffffffff16815250506000600167ffffffffffffffff8111156106d2576106d16120e7565b5b6040519080825280602002602001820160405280156107005781602001602082028036833780820191505090505b5090508381600081518110610718576107176125ea565b5b6020026020010181815250506000600167ffffffffffffffff811115610741576107406120e7565b5b60405190808252806020026020018201604052801561076f5781602001602082028036833780820191505090505b509050600081600081518110610788576107876125ea565b5b6020026020010181815250507f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663ab9c4b5d30858585308a60006040518863ffffffff1660e01b81526004016107fa9796959493929190612cb3565b600060405180830381600087803b15801561081457600080fd5b505af1158015610828573d6000803e3d6000fd5b50505050505050505050505050505050565b60026020528060005260406000206000915054906101000a900460ff1681565b60016020528060005260406000206000915054906101000a900460ff1681565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b600060018a8a9050146108b057600080fd5b6000888860008181106108c6576108c56125ea565b5b905060200201351161090d576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161090490612d8a565b60405180910390fd5b6000838381019061091e9190612e94565b90506109298161126b565b60008b8b600081811061093f5761093e6125ea565b5b90506020020160208101906109549190612308565b90508073ffffffffffffffffffffffffffffffffffffffff1663095ea7b3737d2768de32b0b80b7a3454c06bdac94a69ddc7a98c8c600081811061099b5761099a6125ea565b5b905060200201356040518363ffffffff1660e01b81526004016109bf929190612eec565b6020604051808303816000875af11580156109de573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610a029190612f2a565b610a41576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610a3890612fc9565b60405180910390fd5b600080610a7a84600001518f8f6000818110610a6057610a5f6125ea565b5b9050602002016020810190610a759190612308565b61128e565b915091508b8b6000818110610a9257610a916125ea565b5b905060200201358183610aa591906126f3565b1015610ae6576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610add90613035565b60405180910390fd5b8b8b6000818110610afa57610af96125ea565b5b90506020020135821115610b26578b8b6000818110610b1c57610b1b6125ea565b5b9050602002013591505b818c8c6000818110610b3b57610b3a6125ea565b5b90506020020135610b4c9190613055565b90506000821115610c28577f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663573ade818f8f6000818110610ba757610ba66125ea565b5b9050602002016020810190610bbc9190612308565b84600188600001516040518563ffffffff1660e01b8152600401610be394939291906130c4565b6020604051808303816000875af1158015610c02573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610c2691906126c6565b505b6000811115610d02577f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff1663573ade818f8f6000818110610c8157610c806125ea565b5b9050602002016020810190610c969190612308565b83600288600001516040518563ffffffff1660e01b8152600401610cbd9493929190613144565b6020604051808303816000875af1158015610cdc573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610d0091906126c6565b505b505050600087876000818110610d1b57610d1a6125ea565b5b905060200201358a8a6000818110610d3657610d356125ea565b5b90506020020135610d4791906126f3565b90506000610d7e838e8e6000818110610d6357610d626125ea565b5b9050602002016020810190610d789190612308565b84611446565b905060008d8d6000818110610d9657610d956125ea565b5b9050602002016020810190610dab9190612308565b90508073ffffffffffffffffffffffffffffffffffffffff1663a9059cbb85600001518585610dda9190613055565b6040518363ffffffff1660e01b8152600401610df7929190612eec565b6020604051808303816000875af1158015610e16573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610e3a9190612f2a565b610e79576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610e70906131fb565b60405180910390fd5b8073ffffffffffffffffffffffffffffffffffffffff1663095ea7b3737d2768de32b0b80b7a3454c06bdac94a69ddc7a9856040518363ffffffff1660e01b8152600401610ec8929190612eec565b6020604051808303816000875af1158015610ee7573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610f0b9190612f2a565b610f4a576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610f419061328d565b60405180910390fd5b60019450505050509998505050505050505050565b7f000000000000000000000000000000000000000000000000000000000000000081565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614611011576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611008906125ca565b60405180910390fd5b60005b83839050811015611125578160016000868685818110611037576110366125ea565b5b905060200201602081019061104c9190612308565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055508383828181106110b0576110af6125ea565b5b90506020020160208101906110c59190612308565b73ffffffffffffffffffffffffffffffffffffffff167f5ae067a93e2af6193017728cb7e9ef5af1d750a5beb7b16a05b54d56f6f355e38360405161110a9190612344565b60405180910390a2808061111d90612652565b915050611014565b50505050565b60008054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146111b9576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016111b0906125ca565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1603611228576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161121f906132f9565b60405180910390fd5b806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b611282816000015182602001518360400151611b29565b61128b81611c9a565b50565b60008060007f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166335ea6a75856040518263ffffffff1660e01b81526004016112ec919061236e565b61018060405180830381865afa15801561130a573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061132e919061354a565b9050600081610100015173ffffffffffffffffffffffffffffffffffffffff166370a08231876040518263ffffffff1660e01b8152600401611370919061236e565b602060405180830381865afa15801561138d573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906113b191906126c6565b9050600082610120015173ffffffffffffffffffffffffffffffffffffffff166370a08231886040518263ffffffff1660e01b81526004016113f3919061236e565b602060405180830381865afa158015611410573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061143491906126c6565b90508181945094505050509250929050565b6000806000806000806000808a6060015180602001905181019061146a919061286b565b9750975097505096509650965096508973ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16146114e7576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016114de906135c4565b60405180910390fd5b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff16611573576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161156a90613630565b60405180910390fd5b600260008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff166115ff576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016115f69061369c565b60405180910390fd5b8673ffffffffffffffffffffffffffffffffffffffff166323b872dd8c6000015130886040518463ffffffff1660e01b8152600401611640939291906136bc565b6020604051808303816000875af115801561165f573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906116839190612f2a565b6116c2576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016116b99061373f565b60405180910390fd5b7f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff166369328dec8787306040518463ffffffff1660e01b815260040161171f9392919061375f565b6020604051808303816000875af115801561173e573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061176291906126c6565b85146117a3576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161179a90613808565b60405180910390fd5b60008473ffffffffffffffffffffffffffffffffffffffff166370a08231306040518263ffffffff1660e01b81526004016117de919061236e565b602060405180830381865afa1580156117fb573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061181f91906126c6565b90508673ffffffffffffffffffffffffffffffffffffffff1663095ea7b384886040518363ffffffff1660e01b815260040161185c929190612eec565b6020604051808303816000875af115801561187b573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061189f9190612f2a565b6118de576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016118d590613874565b60405180910390fd5b60008473ffffffffffffffffffffffffffffffffffffffff168360405161190591906138d0565b6000604051808303816000865af19150503d8060008114611942576040519150601f19603f3d011682016040523d82523d6000602084013e611947565b606091505b505090508061198b576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161198290613933565b60405180910390fd5b8773ffffffffffffffffffffffffffffffffffffffff1663095ea7b38560006040518363ffffffff1660e01b81526004016119c7929190613984565b6020604051808303816000875af11580156119e6573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190611a0a9190612f2a565b611a49576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611a4090613a1f565b60405180910390fd5b6000828773ffffffffffffffffffffffffffffffffffffffff166370a08231306040518263ffffffff1660e01b8152600401611a85919061236e565b602060405180830381865afa158015611aa2573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190611ac691906126c6565b611ad09190613055565b90508b811015611b15576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611b0c90613ab1565b60405180910390fd5b809a50505050505050505050509392505050565b60008060008084806020019051810190611b439190613ad1565b935093509350935083421115611b8e576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611b8590613ba0565b60405180910390fd5b60007f0000000000000000000000000000000000000000000000000000000000000000611bf060405180608001604052808a73ffffffffffffffffffffffffffffffffffffffff16815260200188815260200187815260200186815250611d53565b604051602001611c01929190613c42565b6040516020818303038152906040528051906020012090508773ffffffffffffffffffffffffffffffffffffffff16611c3a8284611dbb565b73ffffffffffffffffffffffffffffffffffffffff1614611c90576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611c8790613cc5565b60405180910390fd5b5050505050505050565b6000816060015180519060200120604051602001611cb89190613d31565b604051602081830303815290604052805190602001209050816020015173ffffffffffffffffffffffffffffffffffffffff16611cf9828460800151611dbb565b73ffffffffffffffffffffffffffffffffffffffff1614611d4f576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611d4690613dc9565b60405180910390fd5b5050565b60007f4014101f29c2b247e485bfd2d53e133b760c1a7dccf853b41963eda690e9089e8260000151836020015184604001518560600151604051602001611d9e959493929190613df8565b604051602081830303815290604052805190602001209050919050565b60006041825114611e01576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611df890613e97565b60405180910390fd5b60008060006020850151925060408501519150606085015160001a9050601b8160ff161015611e3a57601b81611e379190613eb7565b90505b601b8160ff161480611e4f5750601c8160ff16145b611e8e576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401611e8590613f38565b60405180910390fd5b60018682858560405160008152602001604052604051611eb19493929190613f67565b6020604051602081039080840390855afa158015611ed3573d6000803e3d6000fd5b50505060206040510351935050505092915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b6000819050919050565b6000611f2d611f28611f2384611ee8565b611f08565b611ee8565b9050919050565b6000611f3f82611f12565b9050919050565b6000611f5182611f34565b9050919050565b611f6181611f46565b82525050565b6000602082019050611f7c6000830184611f58565b92915050565b6000604051905090565b600080fd5b600080fd5b600080fd5b600080fd5b600080fd5b60008083601f840112611fbb57611fba611f96565b5b8235905067ffffffffffffffff811115611fd857611fd7611f9b565b5b602083019150836020820283011115611ff457611ff3611fa0565b5b9250929050565b60008115159050919050565b61201081611ffb565b811461201b57600080fd5b50565b60008135905061202d81612007565b92915050565b60008060006040848603121561204c5761204b611f8c565b5b600084013567ffffffffffffffff81111561206a57612069611f91565b5b61207686828701611fa5565b935093505060206120898682870161201e565b9150509250925092565b600061209e82611ee8565b9050919050565b6120ae81612093565b81146120b957600080fd5b50565b6000813590506120cb816120a5565b92915050565b600080fd5b6000601f19601f8301169050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b61211f826120d6565b810181811067ffffffffffffffff8211171561213e5761213d6120e7565b5b80604052505050565b6000612151611f82565b905061215d8282612116565b919050565b600067ffffffffffffffff82111561217d5761217c6120e7565b5b612186826120d6565b9050602081019050919050565b82818337600083830152505050565b60006121b56121b084612162565b612147565b9050828152602081018484840111156121d1576121d06120d1565b5b6121dc848285612193565b509392505050565b600082601f8301126121f9576121f8611f96565b5b81356122098482602086016121a2565b91505092915050565b600080600080600080600060e0888a03121561223157612230611f8c565b5b600061223f8a828b016120bc565b975050602088013567ffffffffffffffff8111156122605761225f611f91565b5b61226c8a828b016121e4565b965050604061227d8a828b016120bc565b955050606061228e8a828b016120bc565b945050608061229f8a828b016120bc565b93505060a088013567ffffffffffffffff8111156122c0576122bf611f91565b5b6122cc8a828b016121e4565b92505060c088013567ffffffffffffffff8111156122ed576122ec611f91565b5b6122f98a828b016121e4565b91505092959891949750929550565b60006020828403121561231e5761231d611f8c565b5b600061232c848285016120bc565b91505092915050565b61233e81611ffb565b82525050565b60006020820190506123596000830184612335565b92915050565b61236881612093565b82525050565b6000602082019050612383600083018461235f565b92915050565b60008083601f84011261239f5761239e611f96565b5b8235905067ffffffffffffffff8111156123bc576123bb611f9b565b5b6020830191508360208202830111156123d8576123d7611fa0565b5b9250929050565b60008083601f8401126123f5576123f4611f96565b5b8235905067ffffffffffffffff81111561241257612411611f9b565b5b60208301915083600182028301111561242e5761242d611fa0565b5b9250929050565b600080600080600080600080600060a08a8c03121561245757612456611f8c565b5b60008a013567ffffffffffffffff81111561247557612474611f91565b5b6124818c828d01611fa5565b995099505060208a013567ffffffffffffffff8111156124a4576124a3611f91565b5b6124b08c828d01612389565b975097505060408a013567ffffffffffffffff8111156124d3576124d2611f91565b5b6124df8c828d01612389565b955095505060606124f28c828d016120bc565b93505060808a013567ffffffffffffffff81111561251357612512611f91565b5b61251f8c828d016123df565b92509250509295985092959850929598565b600061253c82611f34565b9050919050565b61254c81612531565b82525050565b60006020820190506125676000830184612543565b92915050565b600082825260208201905092915050565b7f63616c6c6572206973206e6f7420746865206f776e6572000000000000000000600082015250565b60006125b460178361256d565b91506125bf8261257e565b602082019050919050565b600060208201905081810360008301526125e3816125a7565b9050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000819050919050565b600061265d82612648565b91507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff820361268f5761268e612619565b5b600182019050919050565b6126a381612648565b81146126ae57600080fd5b50565b6000815190506126c08161269a565b92915050565b6000602082840312156126dc576126db611f8c565b5b60006126ea848285016126b1565b91505092915050565b60006126fe82612648565b915061270983612648565b925082820190508082111561272157612720612619565b5b92915050565b7f64656274206e6f7420666f756e64000000000000000000000000000000000000600082015250565b600061275d600e8361256d565b915061276882612727565b602082019050919050565b6000602082019050818103600083015261278c81612750565b9050919050565b600061279e82611ee8565b9050919050565b6127ae81612793565b81146127b957600080fd5b50565b6000815190506127cb816127a5565b92915050565b60005b838110156127ef5780820151818401526020810190506127d4565b60008484015250505050565b600061280e61280984612162565b612147565b90508281526020810184848401111561282a576128296120d1565b5b6128358482856127d1565b509392505050565b600082601f83011261285257612851611f96565b5b81516128628482602086016127fb565b91505092915050565b600080600080600080600080610100898b03121561288c5761288b611f8c565b5b600061289a8b828c016127bc565b98505060206128ab8b828c016127bc565b97505060406128bc8b828c016126b1565b96505060606128cd8b828c016127bc565b95505060806128de8b828c016126b1565b94505060a06128ef8b828c016127bc565b93505060c06129008b828c016127bc565b92505060e089015167ffffffffffffffff81111561292157612920611f91565b5b61292d8b828c0161283d565b9150509295985092959890939650565b7f6465627420616d6f756e74206578636565647320646562740000000000000000600082015250565b600061297360188361256d565b915061297e8261293d565b602082019050919050565b600060208201905081810360008301526129a281612966565b9050919050565b6129b281612093565b82525050565b600081519050919050565b600082825260208201905092915050565b60006129df826129b8565b6129e981856129c3565b93506129f98185602086016127d1565b612a02816120d6565b840191505092915050565b600060a083016000830151612a2560008601826129a9565b506020830151612a3860208601826129a9565b5060408301518482036040860152612a5082826129d4565b91505060608301518482036060860152612a6a82826129d4565b91505060808301518482036080860152612a8482826129d4565b9150508091505092915050565b60006020820190508181036000830152612aab8184612a0d565b905092915050565b600081519050919050565b600082825260208201905092915050565b6000819050602082019050919050565b6000612aeb83836129a9565b60208301905092915050565b6000602082019050919050565b6000612b0f82612ab3565b612b198185612abe565b9350612b2483612acf565b8060005b83811015612b55578151612b3c8882612adf565b9750612b4783612af7565b925050600181019050612b28565b5085935050505092915050565b600081519050919050565b600082825260208201905092915050565b6000819050602082019050919050565b612b9781612648565b82525050565b6000612ba98383612b8e565b60208301905092915050565b6000602082019050919050565b6000612bcd82612b62565b612bd78185612b6d565b9350612be283612b7e565b8060005b83811015612c13578151612bfa8882612b9d565b9750612c0583612bb5565b925050600181019050612be6565b5085935050505092915050565b600082825260208201905092915050565b6000612c3c826129b8565b612c468185612c20565b9350612c568185602086016127d1565b612c5f816120d6565b840191505092915050565b6000819050919050565b600061ffff82169050919050565b6000612c9d612c98612c9384612c6a565b611f08565b612c74565b9050919050565b612cad81612c82565b82525050565b600060e082019050612cc8600083018a61235f565b8181036020830152612cda8189612b04565b90508181036040830152612cee8188612bc2565b90508181036060830152612d028187612bc2565b9050612d11608083018661235f565b81810360a0830152612d238185612c31565b9050612d3260c0830184612ca4565b98975050505050505050565b7f666c617368206c6f616e2077697468203020616d6f756e743f00000000000000600082015250565b6000612d7460198361256d565b9150612d7f82612d3e565b602082019050919050565b60006020820190508181036000830152612da381612d67565b9050919050565b600080fd5b600080fd5b600060a08284031215612dca57612dc9612daa565b5b612dd460a0612147565b90506000612de4848285016120bc565b6000830152506020612df8848285016120bc565b602083015250604082013567ffffffffffffffff811115612e1c57612e1b612daf565b5b612e28848285016121e4565b604083015250606082013567ffffffffffffffff811115612e4c57612e4b612daf565b5b612e58848285016121e4565b606083015250608082013567ffffffffffffffff811115612e7c57612e7b612daf565b5b612e88848285016121e4565b60808301525092915050565b600060208284031215612eaa57612ea9611f8c565b5b600082013567ffffffffffffffff811115612ec857612ec7611f91565b5b612ed484828501612db4565b91505092915050565b612ee681612648565b82525050565b6000604082019050612f01600083018561235f565b612f0e6020830184612edd565b9392505050565b600081519050612f2481612007565b92915050565b600060208284031215612f4057612f3f611f8c565b5b6000612f4e84828501612f15565b91505092915050565b7f6661696c656420746f20617070726f766520746865206c656e64696e6720706f60008201527f6f6c000000000000000000000000000000000000000000000000000000000000602082015250565b6000612fb360228361256d565b9150612fbe82612f57565b604082019050919050565b60006020820190508181036000830152612fe281612fa6565b9050919050565b7f6c6f616e20616d6f756e74206578636565647320646562740000000000000000600082015250565b600061301f60188361256d565b915061302a82612fe9565b602082019050919050565b6000602082019050818103600083015261304e81613012565b9050919050565b600061306082612648565b915061306b83612648565b925082820390508181111561308357613082612619565b5b92915050565b6000819050919050565b60006130ae6130a96130a484613089565b611f08565b612648565b9050919050565b6130be81613093565b82525050565b60006080820190506130d9600083018761235f565b6130e66020830186612edd565b6130f360408301856130b5565b613100606083018461235f565b95945050505050565b6000819050919050565b600061312e61312961312484613109565b611f08565b612648565b9050919050565b61313e81613113565b82525050565b6000608082019050613159600083018761235f565b6131666020830186612edd565b6131736040830185613135565b613180606083018461235f565b95945050505050565b7f7472616e7366657272696e672072656d61696e64657220746f2075736572206660008201527f61696c6564000000000000000000000000000000000000000000000000000000602082015250565b60006131e560258361256d565b91506131f082613189565b604082019050919050565b60006020820190508181036000830152613214816131d8565b9050919050565b7f6661696c656420746f20617070726f766520666c617368206c6f616e2072657060008201527f61796d656e740000000000000000000000000000000000000000000000000000602082015250565b600061327760268361256d565b91506132828261321b565b604082019050919050565b600060208201905081810360008301526132a68161326a565b9050919050565b7f6f776e657220697320746865207a65726f206164647265737300000000000000600082015250565b60006132e360198361256d565b91506132ee826132ad565b602082019050919050565b60006020820190508181036000830152613312816132d6565b9050919050565b60006020828403121561332f5761332e612daa565b5b6133396020612147565b90506000613349848285016126b1565b60008301525092915050565b60006fffffffffffffffffffffffffffffffff82169050919050565b61337a81613355565b811461338557600080fd5b50565b60008151905061339781613371565b92915050565b600064ffffffffff82169050919050565b6133b78161339d565b81146133c257600080fd5b50565b6000815190506133d4816133ae565b92915050565b6000815190506133e9816120a5565b92915050565b600060ff82169050919050565b613405816133ef565b811461341057600080fd5b50565b600081519050613422816133fc565b92915050565b6000610180828403121561343f5761343e612daa565b5b61344a610180612147565b9050600061345a84828501613319565b600083015250602061346e84828501613388565b602083015250604061348284828501613388565b604083015250606061349684828501613388565b60608301525060806134aa84828501613388565b60808301525060a06134be84828501613388565b60a08301525060c06134d2848285016133c5565b60c08301525060e06134e6848285016133da565b60e0830152506101006134fb848285016133da565b61010083015250610120613511848285016133da565b61012083015250610140613527848285016133da565b6101408301525061016061353d84828501613413565b6101608301525092915050565b6000610180828403121561356157613560611f8c565b5b600061356f84828501613428565b91505092915050565b7f64656274206173736574206469646e2774206d61746368000000000000000000600082015250565b60006135ae60178361256d565b91506135b982613578565b602082019050919050565b600060208201905081810360008301526135dd816135a1565b9050919050565b7f7377617020726f75746572206e6f7420616c6c6f776564000000000000000000600082015250565b600061361a60178361256d565b9150613625826135e4565b602082019050919050565b600060208201905081810360008301526136498161360d565b9050919050565b7f73776170207370656e646572206e6f7420616c6c6f7765640000000000000000600082015250565b600061368660188361256d565b915061369182613650565b602082019050919050565b600060208201905081810360008301526136b581613679565b9050919050565b60006060820190506136d1600083018661235f565b6136de602083018561235f565b6136eb6040830184612edd565b949350505050565b7f636f6c6c61746572616c207472616e73666572206661696c6564000000000000600082015250565b6000613729601a8361256d565b9150613734826136f3565b602082019050919050565b600060208201905081810360008301526137588161371c565b9050919050565b6000606082019050613774600083018661235f565b6137816020830185612edd565b61378e604083018461235f565b949350505050565b7f7769746864726577206c657373207468616e207468652065787065637465642060008201527f616d6f756e740000000000000000000000000000000000000000000000000000602082015250565b60006137f260268361256d565b91506137fd82613796565b604082019050919050565b60006020820190508181036000830152613821816137e5565b9050919050565b7f6661696c656420746f20617070726f7665207468652073776170000000000000600082015250565b600061385e601a8361256d565b915061386982613828565b602082019050919050565b6000602082019050818103600083015261388d81613851565b9050919050565b600081905092915050565b60006138aa826129b8565b6138b48185613894565b93506138c48185602086016127d1565b80840191505092915050565b60006138dc828461389f565b915081905092915050565b7f73776170206661696c6564000000000000000000000000000000000000000000600082015250565b600061391d600b8361256d565b9150613928826138e7565b602082019050919050565b6000602082019050818103600083015261394c81613910565b9050919050565b600061396e61396961396484612c6a565b611f08565b612648565b9050919050565b61397e81613953565b82525050565b6000604082019050613999600083018561235f565b6139a66020830184613975565b9392505050565b7f6661696c656420746f20726573657420746865207377617020617070726f766160008201527f6c00000000000000000000000000000000000000000000000000000000000000602082015250565b6000613a0960218361256d565b9150613a14826139ad565b604082019050919050565b60006020820190508181036000830152613a38816139fc565b9050919050565b7f737761702070726f636565647320646f6e277420636f7665722074686520666c60008201527f617368206c6f616e000000000000000000000000000000000000000000000000602082015250565b6000613a9b60288361256d565b9150613aa682613a3f565b604082019050919050565b60006020820190508181036000830152613aca81613a8e565b9050919050565b60008060008060808587031215613aeb57613aea611f8c565b5b6000613af9878288016126b1565b9450506020613b0a878288016126b1565b9350506040613b1b878288016126b1565b925050606085015167ffffffffffffffff811115613b3c57613b3b611f91565b5b613b488782880161283d565b91505092959194509250565b7f64656c65676174696f6e20657870697265640000000000000000000000000000600082015250565b6000613b8a60128361256d565b9150613b9582613b54565b602082019050919050565b60006020820190508181036000830152613bb981613b7d565b9050919050565b600081905092915050565b7f1901000000000000000000000000000000000000000000000000000000000000600082015250565b6000613c01600283613bc0565b9150613c0c82613bcb565b600282019050919050565b6000819050919050565b6000819050919050565b613c3c613c3782613c17565b613c21565b82525050565b6000613c4d82613bf4565b9150613c598285613c2b565b602082019150613c698284613c2b565b6020820191508190509392505050565b7f7369676e657220646964206e6f74206d61746368000000000000000000000000600082015250565b6000613caf60148361256d565b9150613cba82613c79565b602082019050919050565b60006020820190508181036000830152613cde81613ca2565b9050919050565b7f19457468657265756d205369676e6564204d6573736167653a0a333200000000600082015250565b6000613d1b601c83613bc0565b9150613d2682613ce5565b601c82019050919050565b6000613d3c82613d0e565b9150613d488284613c2b565b60208201915081905092915050565b7f7061636b656420706172616d6574657273206e6f74207369676e65642062792060008201527f626f740000000000000000000000000000000000000000000000000000000000602082015250565b6000613db360238361256d565b9150613dbe82613d57565b604082019050919050565b60006020820190508181036000830152613de281613da6565b9050919050565b613df281613c17565b82525050565b600060a082019050613e0d6000830188613de9565b613e1a602083018761235f565b613e276040830186612edd565b613e346060830185612edd565b613e416080830184612edd565b9695505050505050565b7f77726f6e67207369676e6174757265206c656e67746800000000000000000000600082015250565b6000613e8160168361256d565b9150613e8c82613e4b565b602082019050919050565b60006020820190508181036000830152613eb081613e74565b9050919050565b6000613ec2826133ef565b9150613ecd836133ef565b9250828201905060ff811115613ee657613ee5612619565b5b92915050565b7f7620776173206e6f74203237206f722032380000000000000000000000000000600082015250565b6000613f2260128361256d565b9150613f2d82613eec565b602082019050919050565b60006020820190508181036000830152613f5181613f15565b9050919050565b613f61816133ef565b82525050565b6000608082019050613f7c6000830187613de9565b613f896020830186613f58565b613f966040830185613de9565b613fa36060830184613de9565b9594505050505056fea164736f6c6343000815000a"
 
 // DeployRepayment deploys a new Ethereum contract, binding an instance of Repayment to it.
-func DeployRepayment(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Repayment, error) {
+func DeployRepayment(auth *bind.TransactOpts, backend bind.ContractBackend, _provider common.Address, _version string, _salt string) (common.Address, *types.Transaction, *Repayment, error) {
 	parsed, err := abi.JSON(strings.NewReader(RepaymentABI))
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
 
-	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(RepaymentBin), backend)
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(RepaymentBin), backend, _provider, _version, _salt)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -250,25 +250,149 @@ func (_Repayment *RepaymentCallerSession) LENDINGPOOL() (common.Address, error)
 	return _Repayment.Contract.LENDINGPOOL(&_Repayment.CallOpts)
 }
 
+// MinNonce is a free data retrieval call binding the contract method 0xaa99fa98.
+//
+// Solidity: function minNonce(address ) view returns(uint256)
+func (_Repayment *RepaymentCaller) MinNonce(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Repayment.contract.Call(opts, &out, "minNonce", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinNonce is a free data retrieval call binding the contract method 0xaa99fa98.
+//
+// Solidity: function minNonce(address ) view returns(uint256)
+func (_Repayment *RepaymentSession) MinNonce(arg0 common.Address) (*big.Int, error) {
+	return _Repayment.Contract.MinNonce(&_Repayment.CallOpts, arg0)
+}
+
+// MinNonce is a free data retrieval call binding the contract method 0xaa99fa98.
+//
+// Solidity: function minNonce(address ) view returns(uint256)
+func (_Repayment *RepaymentCallerSession) MinNonce(arg0 common.Address) (*big.Int, error) {
+	return _Repayment.Contract.MinNonce(&_Repayment.CallOpts, arg0)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Repayment *RepaymentCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Repayment.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Repayment *RepaymentSession) Owner() (common.Address, error) {
+	return _Repayment.Contract.Owner(&_Repayment.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Repayment *RepaymentCallerSession) Owner() (common.Address, error) {
+	return _Repayment.Contract.Owner(&_Repayment.CallOpts)
+}
+
+// Routers is a free data retrieval call binding the contract method 0x80dd9a1f.
+//
+// Solidity: function routers(address ) view returns(bool)
+func (_Repayment *RepaymentCaller) Routers(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Repayment.contract.Call(opts, &out, "routers", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Routers is a free data retrieval call binding the contract method 0x80dd9a1f.
+//
+// Solidity: function routers(address ) view returns(bool)
+func (_Repayment *RepaymentSession) Routers(arg0 common.Address) (bool, error) {
+	return _Repayment.Contract.Routers(&_Repayment.CallOpts, arg0)
+}
+
+// Routers is a free data retrieval call binding the contract method 0x80dd9a1f.
+//
+// Solidity: function routers(address ) view returns(bool)
+func (_Repayment *RepaymentCallerSession) Routers(arg0 common.Address) (bool, error) {
+	return _Repayment.Contract.Routers(&_Repayment.CallOpts, arg0)
+}
+
+// Spenders is a free data retrieval call binding the contract method 0x63a31bd4.
+//
+// Solidity: function spenders(address ) view returns(bool)
+func (_Repayment *RepaymentCaller) Spenders(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _Repayment.contract.Call(opts, &out, "spenders", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Spenders is a free data retrieval call binding the contract method 0x63a31bd4.
+//
+// Solidity: function spenders(address ) view returns(bool)
+func (_Repayment *RepaymentSession) Spenders(arg0 common.Address) (bool, error) {
+	return _Repayment.Contract.Spenders(&_Repayment.CallOpts, arg0)
+}
+
+// Spenders is a free data retrieval call binding the contract method 0x63a31bd4.
+//
+// Solidity: function spenders(address ) view returns(bool)
+func (_Repayment *RepaymentCallerSession) Spenders(arg0 common.Address) (bool, error) {
+	return _Repayment.Contract.Spenders(&_Repayment.CallOpts, arg0)
+}
+
 // Execute is a paid mutator transaction binding the contract method 0x54f5c2c8.
 //
-// Solidity: function execute(address _user, bytes _botDelegationSignature, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
-func (_Repayment *RepaymentTransactor) Execute(opts *bind.TransactOpts, _user common.Address, _botDelegationSignature []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
-	return _Repayment.contract.Transact(opts, "execute", _user, _botDelegationSignature, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
+// Solidity: function execute(address _user, bytes _botDelegation, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
+func (_Repayment *RepaymentTransactor) Execute(opts *bind.TransactOpts, _user common.Address, _botDelegation []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
+	return _Repayment.contract.Transact(opts, "execute", _user, _botDelegation, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
 }
 
 // Execute is a paid mutator transaction binding the contract method 0x54f5c2c8.
 //
-// Solidity: function execute(address _user, bytes _botDelegationSignature, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
-func (_Repayment *RepaymentSession) Execute(_user common.Address, _botDelegationSignature []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
-	return _Repayment.Contract.Execute(&_Repayment.TransactOpts, _user, _botDelegationSignature, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
+// Solidity: function execute(address _user, bytes _botDelegation, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
+func (_Repayment *RepaymentSession) Execute(_user common.Address, _botDelegation []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
+	return _Repayment.Contract.Execute(&_Repayment.TransactOpts, _user, _botDelegation, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
 }
 
 // Execute is a paid mutator transaction binding the contract method 0x54f5c2c8.
 //
-// Solidity: function execute(address _user, bytes _botDelegationSignature, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
-func (_Repayment *RepaymentTransactorSession) Execute(_user common.Address, _botDelegationSignature []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
-	return _Repayment.Contract.Execute(&_Repayment.TransactOpts, _user, _botDelegationSignature, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
+// Solidity: function execute(address _user, bytes _botDelegation, address _sDebtToken, address _vDebtToken, address _dAsset, bytes _packedParams, bytes _packedParamsSignature) returns()
+func (_Repayment *RepaymentTransactorSession) Execute(_user common.Address, _botDelegation []byte, _sDebtToken common.Address, _vDebtToken common.Address, _dAsset common.Address, _packedParams []byte, _packedParamsSignature []byte) (*types.Transaction, error) {
+	return _Repayment.Contract.Execute(&_Repayment.TransactOpts, _user, _botDelegation, _sDebtToken, _vDebtToken, _dAsset, _packedParams, _packedParamsSignature)
 }
 
 // ExecuteOperation is a paid mutator transaction binding the contract method 0x920f5c84.
@@ -291,3 +415,522 @@ func (_Repayment *RepaymentSession) ExecuteOperation(_assets []common.Address, _
 func (_Repayment *RepaymentTransactorSession) ExecuteOperation(_assets []common.Address, _amounts []*big.Int, _premiums []*big.Int, arg3 common.Address, _params []byte) (*types.Transaction, error) {
 	return _Repayment.Contract.ExecuteOperation(&_Repayment.TransactOpts, _assets, _amounts, _premiums, arg3, _params)
 }
+
+// Revoke is a paid mutator transaction binding the contract method 0x20c5429b.
+//
+// Solidity: function revoke(uint256 _nonce) returns()
+func (_Repayment *RepaymentTransactor) Revoke(opts *bind.TransactOpts, _nonce *big.Int) (*types.Transaction, error) {
+	return _Repayment.contract.Transact(opts, "revoke", _nonce)
+}
+
+// Revoke is a paid mutator transaction binding the contract method 0x20c5429b.
+//
+// Solidity: function revoke(uint256 _nonce) returns()
+func (_Repayment *RepaymentSession) Revoke(_nonce *big.Int) (*types.Transaction, error) {
+	return _Repayment.Contract.Revoke(&_Repayment.TransactOpts, _nonce)
+}
+
+// Revoke is a paid mutator transaction binding the contract method 0x20c5429b.
+//
+// Solidity: function revoke(uint256 _nonce) returns()
+func (_Repayment *RepaymentTransactorSession) Revoke(_nonce *big.Int) (*types.Transaction, error) {
+	return _Repayment.Contract.Revoke(&_Repayment.TransactOpts, _nonce)
+}
+
+// SetRouters is a paid mutator transaction binding the contract method 0xb6c99111.
+//
+// Solidity: function setRouters(address[] _routers, bool _allowed) returns()
+func (_Repayment *RepaymentTransactor) SetRouters(opts *bind.TransactOpts, _routers []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.contract.Transact(opts, "setRouters", _routers, _allowed)
+}
+
+// SetRouters is a paid mutator transaction binding the contract method 0xb6c99111.
+//
+// Solidity: function setRouters(address[] _routers, bool _allowed) returns()
+func (_Repayment *RepaymentSession) SetRouters(_routers []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.Contract.SetRouters(&_Repayment.TransactOpts, _routers, _allowed)
+}
+
+// SetRouters is a paid mutator transaction binding the contract method 0xb6c99111.
+//
+// Solidity: function setRouters(address[] _routers, bool _allowed) returns()
+func (_Repayment *RepaymentTransactorSession) SetRouters(_routers []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.Contract.SetRouters(&_Repayment.TransactOpts, _routers, _allowed)
+}
+
+// SetSpenders is a paid mutator transaction binding the contract method 0x2370a62c.
+//
+// Solidity: function setSpenders(address[] _spenders, bool _allowed) returns()
+func (_Repayment *RepaymentTransactor) SetSpenders(opts *bind.TransactOpts, _spenders []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.contract.Transact(opts, "setSpenders", _spenders, _allowed)
+}
+
+// SetSpenders is a paid mutator transaction binding the contract method 0x2370a62c.
+//
+// Solidity: function setSpenders(address[] _spenders, bool _allowed) returns()
+func (_Repayment *RepaymentSession) SetSpenders(_spenders []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.Contract.SetSpenders(&_Repayment.TransactOpts, _spenders, _allowed)
+}
+
+// SetSpenders is a paid mutator transaction binding the contract method 0x2370a62c.
+//
+// Solidity: function setSpenders(address[] _spenders, bool _allowed) returns()
+func (_Repayment *RepaymentTransactorSession) SetSpenders(_spenders []common.Address, _allowed bool) (*types.Transaction, error) {
+	return _Repayment.Contract.SetSpenders(&_Repayment.TransactOpts, _spenders, _allowed)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address _owner) returns()
+func (_Repayment *RepaymentTransactor) TransferOwnership(opts *bind.TransactOpts, _owner common.Address) (*types.Transaction, error) {
+	return _Repayment.contract.Transact(opts, "transferOwnership", _owner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address _owner) returns()
+func (_Repayment *RepaymentSession) TransferOwnership(_owner common.Address) (*types.Transaction, error) {
+	return _Repayment.Contract.TransferOwnership(&_Repayment.TransactOpts, _owner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address _owner) returns()
+func (_Repayment *RepaymentTransactorSession) TransferOwnership(_owner common.Address) (*types.Transaction, error) {
+	return _Repayment.Contract.TransferOwnership(&_Repayment.TransactOpts, _owner)
+}
+
+// RepaymentRevokedIterator is returned from FilterRevoked and is used to iterate over the raw logs and unpacked data for Revoked events raised by the Repayment contract.
+type RepaymentRevokedIterator struct {
+	Event *RepaymentRevoked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *RepaymentRevokedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(RepaymentRevoked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(RepaymentRevoked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *RepaymentRevokedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *RepaymentRevokedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// RepaymentRevoked represents a Revoked event raised by the Repayment contract.
+type RepaymentRevoked struct {
+	User  common.Address
+	Nonce *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterRevoked is a free log retrieval operation binding the contract event 0x713b90881ad62c4fa8ab6bd9197fa86481fc0c11b2edba60026514281b2dbac4.
+//
+// Solidity: event Revoked(address indexed user, uint256 nonce)
+func (_Repayment *RepaymentFilterer) FilterRevoked(opts *bind.FilterOpts, user []common.Address) (*RepaymentRevokedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Repayment.contract.FilterLogs(opts, "Revoked", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &RepaymentRevokedIterator{contract: _Repayment.contract, event: "Revoked", logs: logs, sub: sub}, nil
+}
+
+// WatchRevoked is a free log subscription operation binding the contract event 0x713b90881ad62c4fa8ab6bd9197fa86481fc0c11b2edba60026514281b2dbac4.
+//
+// Solidity: event Revoked(address indexed user, uint256 nonce)
+func (_Repayment *RepaymentFilterer) WatchRevoked(opts *bind.WatchOpts, sink chan<- *RepaymentRevoked, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _Repayment.contract.WatchLogs(opts, "Revoked", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(RepaymentRevoked)
+				if err := _Repayment.contract.UnpackLog(event, "Revoked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRevoked is a log parse operation binding the contract event 0x713b90881ad62c4fa8ab6bd9197fa86481fc0c11b2edba60026514281b2dbac4.
+//
+// Solidity: event Revoked(address indexed user, uint256 nonce)
+func (_Repayment *RepaymentFilterer) ParseRevoked(log types.Log) (*RepaymentRevoked, error) {
+	event := new(RepaymentRevoked)
+	if err := _Repayment.contract.UnpackLog(event, "Revoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// RepaymentRouterAllowedIterator is returned from FilterRouterAllowed and is used to iterate over the raw logs and unpacked data for RouterAllowed events raised by the Repayment contract.
+type RepaymentRouterAllowedIterator struct {
+	Event *RepaymentRouterAllowed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *RepaymentRouterAllowedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(RepaymentRouterAllowed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(RepaymentRouterAllowed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *RepaymentRouterAllowedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *RepaymentRouterAllowedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// RepaymentRouterAllowed represents a RouterAllowed event raised by the Repayment contract.
+type RepaymentRouterAllowed struct {
+	Router  common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRouterAllowed is a free log retrieval operation binding the contract event 0x5ae067a93e2af6193017728cb7e9ef5af1d750a5beb7b16a05b54d56f6f355e3.
+//
+// Solidity: event RouterAllowed(address indexed router, bool allowed)
+func (_Repayment *RepaymentFilterer) FilterRouterAllowed(opts *bind.FilterOpts, router []common.Address) (*RepaymentRouterAllowedIterator, error) {
+
+	var routerRule []interface{}
+	for _, routerItem := range router {
+		routerRule = append(routerRule, routerItem)
+	}
+
+	logs, sub, err := _Repayment.contract.FilterLogs(opts, "RouterAllowed", routerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &RepaymentRouterAllowedIterator{contract: _Repayment.contract, event: "RouterAllowed", logs: logs, sub: sub}, nil
+}
+
+// WatchRouterAllowed is a free log subscription operation binding the contract event 0x5ae067a93e2af6193017728cb7e9ef5af1d750a5beb7b16a05b54d56f6f355e3.
+//
+// Solidity: event RouterAllowed(address indexed router, bool allowed)
+func (_Repayment *RepaymentFilterer) WatchRouterAllowed(opts *bind.WatchOpts, sink chan<- *RepaymentRouterAllowed, router []common.Address) (event.Subscription, error) {
+
+	var routerRule []interface{}
+	for _, routerItem := range router {
+		routerRule = append(routerRule, routerItem)
+	}
+
+	logs, sub, err := _Repayment.contract.WatchLogs(opts, "RouterAllowed", routerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(RepaymentRouterAllowed)
+				if err := _Repayment.contract.UnpackLog(event, "RouterAllowed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRouterAllowed is a log parse operation binding the contract event 0x5ae067a93e2af6193017728cb7e9ef5af1d750a5beb7b16a05b54d56f6f355e3.
+//
+// Solidity: event RouterAllowed(address indexed router, bool allowed)
+func (_Repayment *RepaymentFilterer) ParseRouterAllowed(log types.Log) (*RepaymentRouterAllowed, error) {
+	event := new(RepaymentRouterAllowed)
+	if err := _Repayment.contract.UnpackLog(event, "RouterAllowed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// RepaymentSpenderAllowedIterator is returned from FilterSpenderAllowed and is used to iterate over the raw logs and unpacked data for SpenderAllowed events raised by the Repayment contract.
+type RepaymentSpenderAllowedIterator struct {
+	Event *RepaymentSpenderAllowed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *RepaymentSpenderAllowedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(RepaymentSpenderAllowed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(RepaymentSpenderAllowed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *RepaymentSpenderAllowedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *RepaymentSpenderAllowedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// RepaymentSpenderAllowed represents a SpenderAllowed event raised by the Repayment contract.
+type RepaymentSpenderAllowed struct {
+	Spender common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterSpenderAllowed is a free log retrieval operation binding the contract event 0x58863f0ebbc6d5514137a654a9c6ba3d90fa59b8a605dfc4c7b21aa505cbcb9c.
+//
+// Solidity: event SpenderAllowed(address indexed spender, bool allowed)
+func (_Repayment *RepaymentFilterer) FilterSpenderAllowed(opts *bind.FilterOpts, spender []common.Address) (*RepaymentSpenderAllowedIterator, error) {
+
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _Repayment.contract.FilterLogs(opts, "SpenderAllowed", spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &RepaymentSpenderAllowedIterator{contract: _Repayment.contract, event: "SpenderAllowed", logs: logs, sub: sub}, nil
+}
+
+// WatchSpenderAllowed is a free log subscription operation binding the contract event 0x58863f0ebbc6d5514137a654a9c6ba3d90fa59b8a605dfc4c7b21aa505cbcb9c.
+//
+// Solidity: event SpenderAllowed(address indexed spender, bool allowed)
+func (_Repayment *RepaymentFilterer) WatchSpenderAllowed(opts *bind.WatchOpts, sink chan<- *RepaymentSpenderAllowed, spender []common.Address) (event.Subscription, error) {
+
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _Repayment.contract.WatchLogs(opts, "SpenderAllowed", spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(RepaymentSpenderAllowed)
+				if err := _Repayment.contract.UnpackLog(event, "SpenderAllowed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSpenderAllowed is a log parse operation binding the contract event 0x58863f0ebbc6d5514137a654a9c6ba3d90fa59b8a605dfc4c7b21aa505cbcb9c.
+//
+// Solidity: event SpenderAllowed(address indexed spender, bool allowed)
+func (_Repayment *RepaymentFilterer) ParseSpenderAllowed(log types.Log) (*RepaymentSpenderAllowed, error) {
+	event := new(RepaymentSpenderAllowed)
+	if err := _Repayment.contract.UnpackLog(event, "SpenderAllowed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
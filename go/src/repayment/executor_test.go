@@ -0,0 +1,215 @@
+package repayment
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"gas"
+	"simulated"
+	"swap"
+	"txmanager"
+)
+
+// flashParamsT is the layout of the parameters the executor passes itself through the flash loan.
+var flashParamsT, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	{Name: "user", Type: "address"},
+	{Name: "bot", Type: "address"},
+	{Name: "botDelegation", Type: "bytes"},
+	{Name: "packedParams", Type: "bytes"},
+	{Name: "packedParamsSignature", Type: "bytes"},
+})
+
+// flashParams are the `FlashParams` of the executor.
+type flashParams struct {
+	User                  common.Address
+	Bot                   common.Address
+	BotDelegation         []byte
+	PackedParams          []byte
+	PackedParamsSignature []byte
+}
+
+// callback calls the flash loan callback of the executor the way the Lending Pool does once it
+// has lent `amount` of the debt asset, and returns its revert reason if it reverts.
+func (et *executionTest) callback(ctx context.Context, e *Execution, amount, premium *big.Int,
+	fp flashParams) (string, error) {
+	params, err := abi.Arguments{{Type: flashParamsT}}.Pack(fp)
+	if err != nil {
+		return "", err
+	}
+	input, err := repaymentABI.Pack("executeOperation", []common.Address{e.debt.Asset}, []*big.Int{amount},
+		[]*big.Int{premium}, et.rAddr, params)
+	if err != nil {
+		return "", err
+	}
+	out, err := et.c.ETH().CallContract(ctx, ethereum.CallMsg{From: simulated.LendingPool, To: &et.rAddr,
+		Data: input}, nil)
+	if err != nil {
+		var revert *txmanager.RevertError
+		if errors.As(txmanager.DecodeRevert(err), &revert) {
+			return revert.Reason, nil
+		}
+		return "", err
+	}
+	if ok, err := repaymentABI.Unpack("executeOperation", out); err != nil || !ok[0].(bool) {
+		return "", errors.New("executeOperation didn't return true")
+	}
+	return "", nil
+}
+
+// TestExecuteOperation checks that the executor deployed from `RepaymentBin` accepts the
+// delegation and the parameters the bot packs and signs, and only swaps through allowed
+// aggregators for enough to pay back the flash loan.
+func TestExecuteOperation(t *testing.T) {
+	ctx := context.Background()
+	et := newExecutionTest(t)
+	e, err := et.execution(ctx, 0)
+	if err != nil {
+		t.Fatalf("NewExecution(...) = _, %v, want _, nil", err)
+	}
+
+	// The mocks let the repayment go through: the tokens accept the transfers and the executor holds
+	// no Dai until the swap.
+	market, dai := et.chain.Market, et.chain.DAI
+	amount, premium := ether(10000), ether(9)
+	for _, p := range []struct {
+		mock    *simulated.Mock
+		method  string
+		args    []interface{}
+		results []interface{}
+	}{
+		{market.LendingPool, "repay", nil, []interface{}{amount}},
+		{market.LendingPool, "withdraw", nil, []interface{}{e.cAmount}},
+		{market.WETH.AToken, "transferFrom", nil, []interface{}{true}},
+		{market.WETH.Asset, "approve", nil, []interface{}{true}},
+		{dai.Asset, "approve", nil, []interface{}{true}},
+		{dai.Asset, "transfer", nil, []interface{}{true}},
+		{dai.Asset, "balanceOf", []interface{}{et.rAddr}, []interface{}{new(big.Int)}},
+	} {
+		if err := p.mock.Returns(ctx, p.method, p.args, p.results...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The Dai mock serves as the swap router: the swap programs it to credit the executor with the
+	// proceeds.
+	if _, err := et.c.ExecuteAsBot(ctx, gas.Normal, "allowing the swap",
+		func(txr *bind.TransactOpts) (*types.Transaction, error) {
+			return et.r.SetRouters(txr, []common.Address{dai.Asset.Address}, true)
+		}); err != nil {
+		t.Fatal(err)
+	}
+	swapFor := func(proceeds *big.Int) *swap.Tx {
+		credit, err := dai.Asset.ReturnsCall("balanceOf", []interface{}{et.rAddr}, proceeds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &swap.Tx{Router: dai.Asset.Address, Spender: et.chain.Router.Spender(), Calldata: credit}
+	}
+	covering := swapFor(ether(10100))
+	// The user revokes the certificates before the one of `et.delegation`.
+	if err := et.c.Execute(ctx, et.chain.User, "revoking certificate 0",
+		func(txr *bind.TransactOpts) (*types.Transaction, error) {
+			return et.r.Revoke(txr, new(big.Int))
+		}); err != nil {
+		t.Fatal(err)
+	}
+	// signed returns a delegation with the given terms signed by the user.
+	signed := func(nonce uint64, maxCollateral *big.Int) []byte {
+		return signDelegation(t, et.chain, et.rAddr, et.chain.User, validTerms(nonce, maxCollateral))
+	}
+
+	for _, tc := range []struct {
+		name string
+		swap *swap.Tx
+		// change alters the flash loan parameters after the bot packed and signed them.
+		change func(fp *flashParams)
+		reason string
+	}{
+		{
+			name: "signed by the user and the bot",
+			swap: covering,
+		},
+		{
+			name: "delegation not signed by the user",
+			swap: covering,
+			change: func(fp *flashParams) {
+				fp.BotDelegation = signDelegation(t, et.chain, et.rAddr, et.chain.Bot, validTerms(1, nil))
+			},
+			reason: "signer did not match",
+		},
+		{
+			name:   "delegation revoked",
+			swap:   covering,
+			change: func(fp *flashParams) { fp.BotDelegation = signed(0, nil) },
+			reason: "delegation revoked",
+		},
+		{
+			name:   "collateral within the delegation limit",
+			swap:   covering,
+			change: func(fp *flashParams) { fp.BotDelegation = signed(1, e.cAmount) },
+		},
+		{
+			name:   "collateral above the delegation limit",
+			swap:   covering,
+			change: func(fp *flashParams) { fp.BotDelegation = signed(1, new(big.Int).Sub(e.cAmount, big.NewInt(1))) },
+			reason: "collateral exceeds the delegation limit",
+		},
+		{
+			name: "parameters not signed by the bot",
+			swap: covering,
+			change: func(fp *flashParams) {
+				sig, err := et.chain.User.SignHash(crypto.Keccak256Hash(fp.PackedParams))
+				if err != nil {
+					t.Fatal(err)
+				}
+				fp.PackedParamsSignature = sig
+			},
+			reason: "packed parameters not signed by bot",
+		},
+		{
+			name:   "parameters changed after signing",
+			swap:   covering,
+			change: func(fp *flashParams) { fp.PackedParams = append(fp.PackedParams, 0) },
+			reason: "packed parameters not signed by bot",
+		},
+		{
+			name:   "router not allowed",
+			swap:   &swap.Tx{Router: market.WETH.Asset.Address, Spender: covering.Spender, Calldata: covering.Calldata},
+			reason: "swap router not allowed",
+		},
+		{
+			name:   "spender not allowed",
+			swap:   &swap.Tx{Router: covering.Router, Spender: dai.Asset.Address, Calldata: covering.Calldata},
+			reason: "swap spender not allowed",
+		},
+		{
+			name:   "proceeds short of the flash loan and its premium",
+			swap:   swapFor(ether(10008)),
+			reason: "swap proceeds don't cover the flash loan",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e.swap = tc.swap
+			packed, packedSig, err := e.packedArgs(et.c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fp := flashParams{et.loan.User, et.chain.Bot.Address(), et.delegation, packed, packedSig}
+			if tc.change != nil {
+				tc.change(&fp)
+			}
+			reason, err := et.callback(ctx, e, amount, premium, fp)
+			if err != nil || reason != tc.reason {
+				t.Errorf("executeOperation(...) reverted with %q, %v, want %q, nil", reason, err, tc.reason)
+			}
+		})
+	}
+}
@@ -2,11 +2,9 @@ package repayment
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"log"
 	"math/big"
-	"text/template"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -15,15 +13,11 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"clients"
-	"oneinch"
+	"gas"
+	"swap"
 )
 
 var (
-	oneInchSwapTemplate = template.Must(template.New("1inch API").Parse(
-		"https://api.1inch.exchange/v2.0/swap?" +
-			"fromTokenAddress={{.From}}&toTokenAddress={{.To}}" +
-			"&amount={{.Amount}}&fromAddress={{.FromAddress}}&slippage={{.Slippage}}" +
-			"&disableEstimate=true"))
 	addressT abi.Type
 	uintT    abi.Type
 	bytesT   abi.Type
@@ -47,57 +41,207 @@ func init() {
 
 // Execution encapsulates information needed to perform repayment.
 type Execution struct {
-	loan      *clients.Loan
-	cAmount   *big.Int
-	signature []byte
-	calldata  []byte
+	loan *clients.Loan
+	// collateral is sold to repay debt.
+	collateral *clients.Reserve
+	debt       *clients.Reserve
+	cAmount    *big.Int
+	// dAmount is the amount of debt to repay. Zero repays all of it.
+	dAmount *big.Int
+	// repaid is the amount of debt expected to be repaid: `dAmount` or the whole debt.
+	repaid *big.Int
+	// cValue is the value of `cAmount` in ETH.
+	cValue     *big.Rat
+	delegation []byte
+	swap       *swap.Tx
+	// rAddr is the address of the RepaymentExecutor contract.
+	rAddr common.Address
 }
 
 // NewExecution prepares for repayment. `rAddr` is the address of the RepaymentExecutor contract
-// and `signature` is the delegation certificate that approves the Bot to perform repayment.
-func NewExecution(ctx context.Context, c *clients.Client, loan *clients.Loan, rAddr common.Address, signature []byte) (*Execution, error) {
-	tx, cAmount, err := oneinch.Swap(ctx, c, loan, rAddr)
+// and `delegation` is the packed delegation certificate that approves the Bot to perform
+// repayment (see `delegation.Certificate.Pack`).
+//
+// The largest debt of the loan is repaid by selling its largest collateral through the aggregator
+// among `quoters` offering the best output net of gas. Debts to other reserves take an execution
+// each once the loan is read again. If `quoters` is nil, `swap.Defaults` are
+// used. The swap must cover the flash loan and its premium, and must not lose more than the
+// client's `MaxPriceImpact` compared to oracle prices.
+//
+// If `target` is 0, the debt is repaid in full. Otherwise, only enough is repaid to bring the ratio
+// down to `target`, in units of 1/10000.
+func NewExecution(ctx context.Context, c *clients.Client, loan *clients.Loan, rAddr common.Address,
+	delegation []byte, target uint16, quoters []swap.Quoter) (*Execution, error) {
+	data, err := loan.Data(ctx, c)
 	if err != nil {
-		return nil, fmt.Errorf("preparing swap execution: %w", err)
+		return nil, fmt.Errorf("retrieving loan amounts: %w", err)
+	}
+	collateral, debt := data.LargestCollateral(), data.LargestDebt()
+	var cAmount, dAmount *big.Int
+	if target > 0 {
+		cAmount, dAmount, err = partialAmounts(data, collateral, debt, target)
+		if err != nil {
+			return nil, fmt.Errorf("computing partial repayment of %v: %w", loan.User, err)
+		}
+	} else {
+		// This balance might be slightly less than the balance when the repayment executes, but only
+		// by the amount of interest accumulated over 1 block. Since aggregator APIs are off-chain,
+		// it's not really possible to get the exact amount at the time of execution.
+		cAmount, err = c.BalanceOf(ctx, collateral.Reserve.AToken, loan.User)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving user collateral balance: %w", err)
+		}
 	}
-	calldataHex, ok := tx["data"].(string)
-	if !ok {
-		return nil, fmt.Errorf("1inch response.tx.data wasn't a string: %v", tx)
+	if dAmount == nil {
+		dAmount = big.NewInt(0)
 	}
-	calldata, err := hex.DecodeString(calldataHex[2:])
+
+	if quoters == nil {
+		quoters = swap.Defaults(c.ChainID())
+	}
+	gasCost, err := gasCostIn(ctx, c, debt.Reserve)
 	if err != nil {
-		return nil, fmt.Errorf("decoding 1inch calldata %v: %w", tx, err)
+		// Quotes can still be compared on output alone.
+		log.Printf("Error pricing gas in %s, ignoring swap gas costs: %v", debt.Reserve.Name, err)
+	}
+	tx, err := swap.Best(ctx, quoters, &swap.Request{
+		From:         collateral.Reserve.Asset,
+		To:           debt.Reserve.Asset,
+		FromDecimals: collateral.Reserve.Decimals,
+		ToDecimals:   debt.Reserve.Decimals,
+		Amount:       cAmount,
+		Taker:        rAddr,
+	}, gasCost)
+	if err != nil {
+		return nil, fmt.Errorf("preparing swap execution: %w", err)
+	}
+	// The flash loan covers the whole debt unless a partial amount is given.
+	loanAmount := debt.Amount
+	if dAmount.Sign() > 0 {
+		loanAmount = dAmount
+	}
+	if err := checkQuote(collateral, debt, cAmount, loanAmount, tx.ToAmount, tx.MinAmount,
+		c.MaxPriceImpact()); err != nil {
+		return nil, fmt.Errorf("checking %s swap for %v: %w", tx.Backend, loan.User, err)
+	}
+
+	cValue := new(big.Rat)
+	if collateral.Amount.Sign() > 0 {
+		cValue.Mul(collateral.ETHValue, new(big.Rat).SetFrac(cAmount, collateral.Amount))
 	}
 	return &Execution{
-		loan:      loan,
-		cAmount:   cAmount,
-		signature: signature,
-		calldata:  calldata,
+		loan:       loan,
+		collateral: collateral.Reserve,
+		debt:       debt.Reserve,
+		cAmount:    cAmount,
+		dAmount:    dAmount,
+		repaid:     loanAmount,
+		cValue:     cValue,
+		delegation: delegation,
+		swap:       tx,
+		rAddr:      rAddr,
 	}, nil
 }
 
-// Execute executes repayment. This should be called soon after `NewExecution` to avoid slippage.
-func (e *Execution) Execute(ctx context.Context, c *clients.Client, r *Repayment) error {
-	if err := c.ExecuteAsBot(ctx, "executing repayment",
+// gasCostIn returns a function converting gas into units of the `r` asset at the current gas price.
+func gasCostIn(ctx context.Context, c *clients.Client, r *clients.Reserve) (swap.GasCost, error) {
+	gasPrice, err := c.ETH().SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving gas price: %w", err)
+	}
+	price, factor, err := c.PriceOf(ctx, r.Asset.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if price.Sign() == 0 {
+		return nil, fmt.Errorf("zero price")
+	}
+	// units = gas * gasPrice / 1e18 / (price / factor) * 10^decimals
+	num := new(big.Int).Mul(gasPrice, factor)
+	num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(r.Decimals)), nil))
+	den := new(big.Int).Mul(price, big.NewInt(1e18))
+	return func(gas uint64) *big.Int {
+		units := new(big.Int).Mul(num, new(big.Int).SetUint64(gas))
+		return units.Quo(units, den)
+	}, nil
+}
+
+// CollateralValue returns the value in wei of the collateral the execution sells.
+func (e *Execution) CollateralValue() *big.Int {
+	wei := new(big.Rat).Mul(e.cValue, new(big.Rat).SetInt(big.NewInt(1e18)))
+	return new(big.Int).Quo(wei.Num(), wei.Denom())
+}
+
+// packedArgs returns the parameters of the `execute` call that the contract uses after the flash
+// loan, along with the bot's signature of them.
+func (e *Execution) packedArgs(c *clients.Client) ([]byte, []byte, error) {
+	args := abi.Arguments{
+		abi.Argument{Name: "_aToken", Type: addressT},
+		abi.Argument{Name: "_cAsset", Type: addressT},
+		abi.Argument{Name: "_cAmount", Type: uintT},
+		abi.Argument{Name: "_dAsset", Type: addressT},
+		abi.Argument{Name: "_dAmount", Type: uintT},
+		abi.Argument{Name: "_swapRouter", Type: addressT},
+		abi.Argument{Name: "_swapSpender", Type: addressT},
+		abi.Argument{Name: "_swapCalldata", Type: bytesT},
+	}
+	packed, err := args.Pack(e.collateral.AToken, e.collateral.Asset, e.cAmount, e.debt.Asset, e.dAmount,
+		e.swap.Router, e.swap.Spender, e.swap.Calldata)
+	if err != nil {
+		return nil, nil, fmt.Errorf("packing args: %w", err)
+	}
+	packedSig, err := c.SignAsBot(crypto.Keccak256Hash(packed))
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing packed args: %w", err)
+	}
+	return packed, packedSig, nil
+}
+
+// Result describes a repayment transaction.
+type Result struct {
+	TxHash  common.Hash
+	Block   uint64
+	GasUsed uint64
+	// Succeeded is false if the transaction reverted.
+	Succeeded bool
+	// CollateralSold of CollateralAsset was swapped through Backend to repay DebtRepaid of
+	// DebtAsset. DebtRepaid is the amount owed when the repayment was prepared.
+	CollateralAsset, DebtAsset common.Address
+	CollateralSold, DebtRepaid *big.Int
+	Backend                    string
+}
+
+// Execute executes repayment after simulating it, which avoids paying for a transaction that
+// would revert. This should be called soon after `NewExecution` to avoid slippage. If the
+// transaction was mined but reverted, its result is returned along with the error.
+func (e *Execution) Execute(ctx context.Context, c *clients.Client, r *Repayment) (*Result, error) {
+	// The parameters are signed once, which external signers ask to approve, and the transaction
+	// sends exactly what was simulated.
+	packed, packedSig, err := e.packedArgs(c)
+	if err != nil {
+		return nil, err
+	}
+	sim, err := e.simulate(ctx, c, packed, packedSig)
+	if err != nil {
+		return nil, err
+	}
+	report, err := c.ExecuteAsBot(ctx, gas.Urgent, "executing repayment",
 		func(txr *bind.TransactOpts) (*types.Transaction, error) {
-			args := abi.Arguments{
-				abi.Argument{Name: "_aToken", Type: addressT},
-				abi.Argument{Name: "_cAsset", Type: addressT},
-				abi.Argument{Name: "_cAmount", Type: uintT},
-				abi.Argument{Name: "_dAsset", Type: addressT},
-				abi.Argument{Name: "_oneInchCalldata", Type: bytesT},
-			}
-			packed, err := args.Pack(e.loan.AToken, e.loan.Collateral, e.cAmount, e.loan.Debt, e.calldata)
-			if err != nil {
-				return nil, fmt.Errorf("packing args: %w", err)
-			}
-			packedSig, err := c.SignAsBot(crypto.Keccak256Hash(packed))
-			if err != nil {
-				return nil, fmt.Errorf("signing packed args: %w", err)
-			}
-			return r.Execute(txr, e.loan.User, e.signature, e.loan.StableDebt, e.loan.VariableDebt, e.loan.Debt, packed, packedSig)
-		}); err != nil {
-		return err
-	}
-	return nil
+			txr.GasLimit = sim.GasLimit()
+			return r.Execute(txr, e.loan.User, e.delegation, e.debt.StableDebt, e.debt.VariableDebt, e.debt.Asset, packed, packedSig)
+		})
+	if report == nil {
+		return nil, err
+	}
+	return &Result{
+		TxHash:          report.Tx.Hash(),
+		Block:           report.Receipt.BlockNumber.Uint64(),
+		GasUsed:         report.Receipt.GasUsed,
+		Succeeded:       report.Receipt.Status == types.ReceiptStatusSuccessful,
+		CollateralAsset: e.collateral.Asset,
+		DebtAsset:       e.debt.Asset,
+		CollateralSold:  e.cAmount,
+		DebtRepaid:      e.repaid,
+		Backend:         e.swap.Backend,
+	}, err
 }
@@ -0,0 +1,167 @@
+package repayment
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"clients"
+	"delegation"
+	"simulated"
+	"swap"
+	"txmanager"
+	"wallets"
+)
+
+// executionTest is a user loan of 10 ETH against 10000 Dai worth 5 ETH on a simulated chain, with a
+// RepaymentExecutor deployed from `RepaymentBin` and a delegation the user signed for the bot. The
+// mock Lending Pool accepts flash loans without calling the executor back.
+type executionTest struct {
+	chain      *simulated.Chain
+	c          *clients.Client
+	loan       *clients.Loan
+	rAddr      common.Address
+	r          *Repayment
+	delegation []byte
+}
+
+func newExecutionTest(t *testing.T) *executionTest {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	if err := chain.Borrow(ctx, ether(10), ether(10000)); err != nil {
+		t.Fatal(err)
+	}
+	c, err := clients.NewClient(chain.Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loan, err := c.Loan(ctx, chain.User.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, rAddr, err := Deploy(ctx, c, []swap.Quoter{chain.Router})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.Market.LendingPool.Returns(ctx, "flashLoan", nil); err != nil {
+		t.Fatal(err)
+	}
+	return &executionTest{
+		chain:      chain,
+		c:          c,
+		loan:       loan,
+		rAddr:      rAddr,
+		r:          r,
+		delegation: signDelegation(t, chain, rAddr, chain.User, validTerms(1, nil)),
+	}
+}
+
+// validTerms returns the terms of a certificate valid for an hour.
+func validTerms(nonce uint64, maxCollateral *big.Int) delegation.Terms {
+	return delegation.Terms{
+		Deadline:      uint64(time.Now().Add(time.Hour).Unix()),
+		Nonce:         nonce,
+		MaxCollateral: maxCollateral,
+	}
+}
+
+// signDelegation returns the packed delegation of the bot for the contract at `rAddr` with `terms`
+// signed by `signer`.
+func signDelegation(t *testing.T, chain *simulated.Chain, rAddr common.Address, signer *wallets.Wallet,
+	terms delegation.Terms) []byte {
+	t.Helper()
+	domain := delegation.DomainFor(chain.Params)
+	domain.VerifyingContract = rAddr
+	cert, err := delegation.New(chain.Bot.Address(), domain, terms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.SignDigest(cert.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := cert.Pack(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return packed
+}
+
+func (et *executionTest) execution(ctx context.Context, target uint16) (*Execution, error) {
+	return NewExecution(ctx, et.c, et.loan, et.rAddr, et.delegation, target, []swap.Quoter{et.chain.Router})
+}
+
+func TestExecute(t *testing.T) {
+	ctx := context.Background()
+	et := newExecutionTest(t)
+	e, err := et.execution(ctx, 0)
+	if err != nil {
+		t.Fatalf("NewExecution(...) = _, %v, want _, nil", err)
+	}
+	if got := e.CollateralValue(); got.Cmp(ether(10)) != 0 {
+		t.Errorf("CollateralValue() = %v, want %v", got, ether(10))
+	}
+	res, err := e.Execute(ctx, et.c, et.r)
+	if err != nil {
+		t.Fatalf("Execute(...) = _, %v, want _, nil", err)
+	}
+	if !res.Succeeded {
+		t.Errorf("Execute(...) result didn't succeed")
+	}
+	if res.CollateralAsset != et.chain.Market.WETH.Asset.Address || res.CollateralSold.Cmp(ether(10)) != 0 {
+		t.Errorf("Execute(...) sold %v of %v, want %v of WETH", res.CollateralSold, res.CollateralAsset, ether(10))
+	}
+	if res.DebtAsset != et.chain.DAI.Asset.Address || res.DebtRepaid.Cmp(ether(10000)) != 0 {
+		t.Errorf("Execute(...) repaid %v of %v, want %v of Dai", res.DebtRepaid, res.DebtAsset, ether(10000))
+	}
+	if res.Backend != "simulated" {
+		t.Errorf("Execute(...) swapped through %q, want %q", res.Backend, "simulated")
+	}
+	receipt, err := et.c.ETH().TransactionReceipt(ctx, res.TxHash)
+	if err != nil {
+		t.Fatalf("TransactionReceipt(%v) = _, %v, want _, nil", res.TxHash.Hex(), err)
+	}
+	if receipt.BlockNumber.Uint64() != res.Block {
+		t.Errorf("Execute(...) block = %d, want %d", res.Block, receipt.BlockNumber.Uint64())
+	}
+}
+
+func TestExecuteReverts(t *testing.T) {
+	ctx := context.Background()
+	et := newExecutionTest(t)
+	e, err := et.execution(ctx, 4000)
+	if err != nil {
+		t.Fatalf("NewExecution(...) = _, %v, want _, nil", err)
+	}
+	// The Lending Pool reverts with the reason of the executor's callback.
+	if err := et.chain.Market.LendingPool.Reverts(ctx, "flashLoan", nil, "signer did not match"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := e.Execute(ctx, et.c, et.r)
+	var revert *txmanager.RevertError
+	if !errors.As(err, &revert) || revert.Reason != "signer did not match" {
+		t.Fatalf("Execute(...) = _, %v, want a revert with reason %q", err, "signer did not match")
+	}
+	// The revert is caught by the simulation, so no transaction is sent.
+	if res != nil {
+		t.Errorf("Execute(...) = %+v, _, want nil", res)
+	}
+}
+
+func TestExecutionPriceImpact(t *testing.T) {
+	ctx := context.Background()
+	et := newExecutionTest(t)
+	// The swap still covers the flash loan, but loses more than the maximum impact.
+	et.chain.Router.Impact = 0.1
+	if _, err := et.execution(ctx, 0); err == nil {
+		t.Errorf("NewExecution(...) with a %.0f%% price impact = _, nil, want an error", et.chain.Router.Impact*100)
+	}
+	et.chain.Router.Impact = 0.01
+	if _, err := et.execution(ctx, 0); err != nil {
+		t.Errorf("NewExecution(...) with a %.0f%% price impact = _, %v, want _, nil", et.chain.Router.Impact*100, err)
+	}
+}
@@ -0,0 +1,60 @@
+package repayment
+
+import (
+	"fmt"
+	"math/big"
+
+	"clients"
+	"swap"
+)
+
+// flashLoanPremium is the AAVE flash loan fee in units of 1/10000.
+const flashLoanPremium = 9
+
+// partialAmounts returns the amount of `collateral` to sell and the amount of `debt` to repay to
+// bring the loan back down to the `target` ratio (in units of 1/10000). A nil debt amount means the
+// whole debt of the reserve is repaid.
+//
+// Repaying x ETH of debt costs x*k ETH of collateral where k covers the flash loan premium and the
+// swap slippage. Solving (D - x) / (C - x*k) = t for x gives x = (D - t*C) / (1 - t*k).
+func partialAmounts(data *clients.LoanAmount, collateral, debt *clients.AssetAmount, target uint16) (*big.Int, *big.Int, error) {
+	t := big.NewRat(int64(target), 10000)
+	k := new(big.Rat).Quo(
+		big.NewRat(10000+flashLoanPremium, 10000),
+		big.NewRat(100-swap.SlippagePercent, 100))
+
+	excess := new(big.Rat).Sub(data.DebtETH, new(big.Rat).Mul(t, data.CollateralETH))
+	if excess.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("ratio %s is already below target %d", data.CurrentRatio.FloatString(4), target)
+	}
+	denom := new(big.Rat).Sub(big.NewRat(1, 1), new(big.Rat).Mul(t, k))
+	if denom.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("target %d is unreachable with the swap costs", target)
+	}
+	repayETH := new(big.Rat).Quo(excess, denom)
+
+	var dAmount *big.Int
+	if repayETH.Cmp(debt.ETHValue) >= 0 {
+		// The chosen reserve doesn't hold enough debt to reach the target, so all of it is repaid.
+		repayETH = debt.ETHValue
+	} else {
+		dAmount = toUnits(repayETH, debt, false)
+	}
+	sellETH := new(big.Rat).Mul(repayETH, k)
+	if sellETH.Cmp(collateral.ETHValue) >= 0 {
+		return nil, nil, fmt.Errorf("collateral %v worth %s ETH can't cover repaying %s ETH",
+			collateral.Reserve, collateral.ETHValue.FloatString(6), repayETH.FloatString(6))
+	}
+	return toUnits(sellETH, collateral, true), dAmount, nil
+}
+
+// toUnits converts an ETH value to units of the asset of `a` using the price implied by `a`.
+func toUnits(eth *big.Rat, a *clients.AssetAmount, roundUp bool) *big.Int {
+	units := new(big.Rat).Mul(eth, new(big.Rat).SetFrac(a.Amount, big.NewInt(1)))
+	units.Quo(units, a.ETHValue)
+	ret := new(big.Int).Quo(units.Num(), units.Denom())
+	if roundUp && !units.IsInt() {
+		ret.Add(ret, big.NewInt(1))
+	}
+	return ret
+}
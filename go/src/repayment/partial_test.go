@@ -0,0 +1,58 @@
+package repayment
+
+import (
+	"math/big"
+	"testing"
+
+	"clients"
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestPartialAmounts(t *testing.T) {
+	// 100 ETH of collateral and 140000 Dai of debt worth 70 ETH.
+	collateral := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "WETH"}, Amount: ether(100), ETHValue: big.NewRat(100, 1)}
+	debt := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "Dai"}, Amount: ether(140000), ETHValue: big.NewRat(70, 1)}
+	data := &clients.LoanAmount{
+		Collateral:    []*clients.AssetAmount{collateral},
+		Debt:          []*clients.AssetAmount{debt},
+		CollateralETH: collateral.ETHValue,
+		DebtETH:       debt.ETHValue,
+		CurrentRatio:  big.NewRat(70, 100),
+	}
+
+	cAmount, dAmount, err := partialAmounts(data, collateral, debt, 6000)
+	if err != nil {
+		t.Fatalf("partialAmounts(...) = _, _, %v, want _, _, nil", err)
+	}
+	if dAmount == nil || dAmount.Cmp(debt.Amount) >= 0 {
+		t.Fatalf("dAmount = %v, want a partial amount of %v", dAmount, debt.Amount)
+	}
+
+	// Sold collateral is in ETH and Dai trades at 2000 per ETH.
+	remainingDebt := new(big.Rat).SetFrac(new(big.Int).Sub(debt.Amount, dAmount), big.NewInt(2000))
+	remainingCollateral := new(big.Rat).SetInt(new(big.Int).Sub(collateral.Amount, cAmount))
+	ratio, _ := new(big.Rat).Quo(remainingDebt, remainingCollateral).Float64()
+	if ratio < 0.5999 || ratio > 0.6001 {
+		t.Errorf("ratio after repayment = %v, want 0.6", ratio)
+	}
+}
+
+func TestPartialAmountsBelowTarget(t *testing.T) {
+	collateral := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "WETH"}, Amount: ether(100), ETHValue: big.NewRat(100, 1)}
+	debt := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "Dai"}, Amount: ether(100000), ETHValue: big.NewRat(50, 1)}
+	data := &clients.LoanAmount{
+		CollateralETH: collateral.ETHValue,
+		DebtETH:       debt.ETHValue,
+		CurrentRatio:  big.NewRat(50, 100),
+	}
+	if _, _, err := partialAmounts(data, collateral, debt, 6000); err == nil {
+		t.Errorf("partialAmounts(...) = _, _, nil, want error for a ratio below target")
+	}
+}
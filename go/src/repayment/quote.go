@@ -0,0 +1,54 @@
+package repayment
+
+import (
+	"fmt"
+	"math/big"
+
+	"clients"
+)
+
+// minReturn returns the debt asset needed to pay back a flash loan of `dAmount`, including the
+// premium.
+func minReturn(dAmount *big.Int) *big.Int {
+	ret := new(big.Int).Mul(dAmount, big.NewInt(10000+flashLoanPremium))
+	// Rounds up like the Lending Pool does for the premium.
+	ret.Add(ret, big.NewInt(9999))
+	return ret.Quo(ret, big.NewInt(10000))
+}
+
+// priceImpact returns the fraction of value lost by swapping `cAmount` of `collateral` for
+// `toAmount` of `debt`, compared to the oracle prices implied by the asset amounts. It's negative
+// if the swap returns more than the oracle prices.
+func priceImpact(collateral, debt *clients.AssetAmount, cAmount, toAmount *big.Int) (float64, error) {
+	if collateral.Amount.Sign() == 0 || debt.Amount.Sign() == 0 || debt.ETHValue.Sign() == 0 {
+		return 0, fmt.Errorf("no oracle price for %v to %v", collateral.Reserve, debt.Reserve)
+	}
+	sellETH := new(big.Rat).Mul(collateral.ETHValue, new(big.Rat).SetFrac(cAmount, collateral.Amount))
+	expected := new(big.Rat).Mul(sellETH, new(big.Rat).SetFrac(debt.Amount, big.NewInt(1)))
+	expected.Quo(expected, debt.ETHValue)
+	if expected.Sign() == 0 {
+		return 0, fmt.Errorf("selling %v of %v is worth nothing", cAmount, collateral.Reserve)
+	}
+	ratio, _ := new(big.Rat).Quo(new(big.Rat).SetInt(toAmount), expected).Float64()
+	return 1 - ratio, nil
+}
+
+// checkQuote verifies that swapping `cAmount` of `collateral` for the quoted `toAmount` of `debt`
+// repays a flash loan of `dAmount` even if it only returns its slippage-adjusted `minAmount`, and
+// loses at most `maxImpact` of value to price impact.
+func checkQuote(collateral, debt *clients.AssetAmount, cAmount, dAmount, toAmount, minAmount *big.Int,
+	maxImpact float64) error {
+	if min := minReturn(dAmount); minAmount.Cmp(min) < 0 {
+		return fmt.Errorf("swap of %v %v returns as little as %v %v, less than the %v owed for the flash loan",
+			cAmount, collateral.Reserve, minAmount, debt.Reserve, min)
+	}
+	impact, err := priceImpact(collateral, debt, cAmount, toAmount)
+	if err != nil {
+		return err
+	}
+	if impact > maxImpact {
+		return fmt.Errorf("swap of %v %v for %v %v has a price impact of %.2f%%, above the maximum of %.2f%%",
+			cAmount, collateral.Reserve, toAmount, debt.Reserve, impact*100, maxImpact*100)
+	}
+	return nil
+}
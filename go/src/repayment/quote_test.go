@@ -0,0 +1,56 @@
+package repayment
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"clients"
+	"swap"
+)
+
+func TestMinReturn(t *testing.T) {
+	for _, tc := range []struct {
+		dAmount, want *big.Int
+	}{
+		{ether(10000), ether(10009)},
+		// The premium rounds up.
+		{big.NewInt(1), big.NewInt(2)},
+		{big.NewInt(0), big.NewInt(0)},
+	} {
+		if got := minReturn(tc.dAmount); got.Cmp(tc.want) != 0 {
+			t.Errorf("minReturn(%v) = %v, want %v", tc.dAmount, got, tc.want)
+		}
+	}
+}
+
+func TestCheckQuote(t *testing.T) {
+	// 10 ETH of collateral and 10000 Dai of debt worth 5 ETH.
+	collateral := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "WETH"}, Amount: ether(10), ETHValue: big.NewRat(10, 1)}
+	debt := &clients.AssetAmount{
+		Reserve: &clients.Reserve{Name: "Dai"}, Amount: ether(10000), ETHValue: big.NewRat(5, 1)}
+
+	for _, tc := range []struct {
+		desc              string
+		cAmount, toAmount *big.Int
+		wantErr           string
+	}{
+		{"at the oracle price", ether(6), ether(12000), ""},
+		{"better than the oracle price", ether(6), ether(12500), ""},
+		{"within the price impact", ether(6), ether(11700), ""},
+		{"above the price impact", ether(6), ether(11000), "price impact"},
+		{"short of the flash loan", ether(5), ether(10005), "flash loan"},
+		// 5.05 ETH is quoted at the oracle price for 10100 Dai, but the swap may return 1% less.
+		{"short of the flash loan after slippage", new(big.Int).Quo(ether(505), big.NewInt(100)), ether(10100),
+			"flash loan"},
+	} {
+		err := checkQuote(collateral, debt, tc.cAmount, debt.Amount, tc.toAmount, swap.MinAmount(tc.toAmount), 0.03)
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s: checkQuote(...) = %v, want nil", tc.desc, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s: checkQuote(...) = %v, want an error about %s", tc.desc, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package repayment
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"clients"
+	"txmanager"
+)
+
+// gasHeadroom is the fraction added to the simulated gas for the transaction gas limit, since the
+// state may change before the transaction is mined.
+const gasHeadroom = 0.2
+
+var repaymentABI abi.ABI
+
+func init() {
+	var err error
+	repaymentABI, err = abi.JSON(strings.NewReader(RepaymentABI))
+	if err != nil {
+		log.Fatalf("Error parsing RepaymentExecutor ABI: %v", err)
+	}
+}
+
+// Simulation is the outcome of a simulated repayment.
+type Simulation struct {
+	// User is the owner of the repaid loan.
+	User string
+	// Collateral and Debt describe the swap as "<amount> <reserve>".
+	Collateral, Debt string
+	// Backend is the aggregator performing the swap.
+	Backend string
+	// Gas is the estimated gas of the repayment.
+	Gas uint64
+}
+
+// GasLimit returns the gas limit to execute the repayment with.
+func (s *Simulation) GasLimit() uint64 {
+	return s.Gas + uint64(float64(s.Gas)*gasHeadroom)
+}
+
+func (s *Simulation) String() string {
+	return fmt.Sprintf("repay %s of %s by selling %s through %s for %d gas", s.Debt, s.User, s.Collateral,
+		s.Backend, s.Gas)
+}
+
+// Simulate runs the repayment transaction through `eth_call` and estimates its gas at the latest
+// block without broadcasting it. If the contract reverts, the error wraps a
+// `*txmanager.RevertError`.
+func (e *Execution) Simulate(ctx context.Context, c *clients.Client) (*Simulation, error) {
+	packed, packedSig, err := e.packedArgs(c)
+	if err != nil {
+		return nil, err
+	}
+	return e.simulate(ctx, c, packed, packedSig)
+}
+
+// simulate simulates the repayment with the packed parameters and the bot's signature of them, so
+// the transaction can then be sent with the same signature.
+func (e *Execution) simulate(ctx context.Context, c *clients.Client, packed, packedSig []byte) (*Simulation,
+	error) {
+	input, err := repaymentABI.Pack("execute", e.loan.User, e.delegation, e.debt.StableDebt,
+		e.debt.VariableDebt, e.debt.Asset, packed, packedSig)
+	if err != nil {
+		return nil, fmt.Errorf("packing execute call: %w", err)
+	}
+	msg := ethereum.CallMsg{From: c.BotAddress(), To: &e.rAddr, Data: input}
+	if _, err := c.ETH().CallContract(ctx, msg, nil); err != nil {
+		return nil, fmt.Errorf("simulating repayment: %w", txmanager.DecodeRevert(err))
+	}
+	gas, err := c.ETH().EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("estimating repayment gas: %w", txmanager.DecodeRevert(err))
+	}
+	debt := "all"
+	if e.dAmount.Sign() > 0 {
+		debt = e.dAmount.String()
+	}
+	return &Simulation{
+		User:       e.loan.User.Hex(),
+		Collateral: fmt.Sprintf("%v %v", e.cAmount, e.collateral),
+		Debt:       fmt.Sprintf("%s %v", debt, e.debt),
+		Backend:    e.swap.Backend,
+		Gas:        gas,
+	}, nil
+}
@@ -16,7 +16,7 @@ import (
 )
 
 // SetupLoan deposits 1 ETH from the given account and borrows 500 Dai.
-func SetupLoan(ctx context.Context, c *clients.Client, user *wallets.Wallet) error {
+func SetupLoan(ctx context.Context, c *clients.Client, user wallets.Signer) error {
 	cAmount := new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
 	if err := c.DepositETH(ctx, user, cAmount); err != nil {
 		return fmt.Errorf("setting up loan: %w", err)
@@ -32,7 +32,7 @@ func SetupLoan(ctx context.Context, c *clients.Client, user *wallets.Wallet) err
 }
 
 // Approve allows the contract to redeem the collateral to pay back the debt.
-func ApproveAToken(ctx context.Context, c *clients.Client, user *wallets.Wallet, contract common.Address) error {
+func ApproveAToken(ctx context.Context, c *clients.Client, user wallets.Signer, contract common.Address) error {
 	aToken, err := c.AToken(ctx, c.WETH9Address())
 	if err != nil {
 		return fmt.Errorf("looking up aToken for approval: %w", err)
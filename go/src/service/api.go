@@ -0,0 +1,86 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBodyBytes bounds the size of API request bodies. Registrations are a few hundred bytes.
+const maxBodyBytes = 16 << 10
+
+// Codes of API errors.
+const (
+	codeBodyTooLarge  = "body-too-large"
+	codeBadBody       = "bad-body"
+	codeBadAddress    = "bad-address"
+	codeBadTerms      = "bad-terms"
+	codeExpired       = "expired"
+	codeRevoked       = "revoked"
+	codeSuperseded    = "superseded"
+	codeBadSignature  = "bad-signature"
+	codeWrongSigner   = "wrong-signer"
+	codeBadThreshold  = "bad-threshold"
+	codeBadTarget     = "bad-target"
+	codeNoLoan        = "no-loan"
+	codeLowFunds      = "low-funds"
+	codeNotRegistered = "not-registered"
+	codeInternal      = "internal"
+)
+
+// apiError is an error reported to API clients with a status and a code they can act upon.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+// invalid returns a 400 error with the given code.
+func invalid(code, format string, args ...interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// abort ends the request with `err` as a JSON body of the form
+// `{"error": {"code": ..., "message": ...}}`. Errors not wrapping an `*apiError` are internal and
+// their message isn't shown to clients.
+func abort(ctx *gin.Context, err error) {
+	var e *apiError
+	if !errors.As(err, &e) {
+		e = &apiError{status: http.StatusInternalServerError, code: codeInternal, message: "internal error"}
+	}
+	ctx.Error(err)
+	ctx.AbortWithStatusJSON(e.status, gin.H{
+		"error": gin.H{
+			"code":    e.code,
+			"message": e.message,
+		},
+	})
+}
+
+// decodeBody decodes the JSON body of the request into `v`, refusing bodies over `maxBodyBytes`.
+func decodeBody(ctx *gin.Context, v interface{}) error {
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &apiError{
+				status:  http.StatusRequestEntityTooLarge,
+				code:    codeBodyTooLarge,
+				message: fmt.Sprintf("body is over %d bytes", maxBodyBytes),
+			}
+		}
+		return invalid(codeBadBody, "reading body: %v", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return invalid(codeBadBody, "body isn't a JSON object of the expected fields: %v", err)
+	}
+	return nil
+}
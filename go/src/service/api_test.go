@@ -0,0 +1,196 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+
+	"delegation"
+)
+
+// apiTest serves the registration handlers of a service without a client, so only requests
+// rejected before the chain is queried can be tested.
+type apiTest struct {
+	s      *Service
+	router *gin.Engine
+	user   common.Address
+	// sig is the user's signature of a certificate with `terms`.
+	sig   string
+	terms delegation.Terms
+}
+
+func newAPITest(t *testing.T) *apiTest {
+	gin.SetMode(gin.TestMode)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &apiTest{
+		s: &Service{
+			store: NewMemoryStore(),
+			bot:   common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+			domain: delegation.Domain{
+				ChainID: big.NewInt(1),
+				Version: delegation.DefaultVersion,
+				Salt:    delegation.DefaultSalt,
+			},
+		},
+		router: gin.New(),
+		user:   crypto.PubkeyToAddress(key.PublicKey),
+		terms: delegation.Terms{
+			Deadline:      uint64(time.Now().Add(time.Hour).Unix()),
+			Nonce:         7,
+			MaxCollateral: new(big.Int),
+		},
+	}
+	a.router.POST("/api/register", a.s.handleRegister)
+	a.router.DELETE("/api/register", a.s.handleRevoke)
+	cert, err := delegation.New(a.s.bot, a.s.domain, a.terms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(cert.Hash().Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[64] += 27
+	a.sig = hexutil.Encode(sig)
+	return a
+}
+
+// body returns a valid registration body with `changes` applied.
+func (a *apiTest) body(changes func(r *rawRegistration)) string {
+	r := &rawRegistration{
+		User:      a.user.Hex(),
+		Signature: a.sig,
+		Threshold: "0.7",
+		Target:    "0.6",
+		Deadline:  fmt.Sprint(a.terms.Deadline),
+		Nonce:     fmt.Sprint(a.terms.Nonce),
+	}
+	if changes != nil {
+		changes(r)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// do sends a request and returns the status and error code of the response.
+func (a *apiTest) do(t *testing.T, method, body string) (int, string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	a.router.ServeHTTP(w, httptest.NewRequest(method, "/api/register", strings.NewReader(body)))
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response %q isn't JSON: %v", w.Body.String(), err)
+	}
+	if resp.Error.Message == "" {
+		t.Errorf("response %q has no error message", w.Body.String())
+	}
+	return w.Code, resp.Error.Code
+}
+
+func TestRegisterRejections(t *testing.T) {
+	a := newAPITest(t)
+	revoked := uint64(9)
+	other := common.HexToAddress("0x02")
+	if err := a.s.store.Put(&StoredRegistration{User: other, RevokedNonce: &revoked}); err != nil {
+		t.Fatal(err)
+	}
+	// The user registered a certificate newer than the one of the request.
+	newer := common.HexToAddress("0x03")
+	stored := &StoredRegistration{User: newer, Status: StatusActive, Nonce: a.terms.Nonce + 1}
+	if err := a.s.store.Put(stored); err != nil {
+		t.Fatal(err)
+	}
+	short := hexutil.Encode(make([]byte, crypto.SignatureLength-1))
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"too large", `{"user": "` + strings.Repeat("0", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge,
+			codeBodyTooLarge},
+		{"not JSON", "user=0x01", http.StatusBadRequest, codeBadBody},
+		{"wrong types", `{"user": 1}`, http.StatusBadRequest, codeBadBody},
+		{"empty", "", http.StatusBadRequest, codeBadBody},
+		{"bad address", a.body(func(r *rawRegistration) { r.User = "0x01" }), http.StatusBadRequest, codeBadAddress},
+		{"bad deadline", a.body(func(r *rawRegistration) { r.Deadline = "soon" }), http.StatusBadRequest, codeBadTerms},
+		{"bad nonce", a.body(func(r *rawRegistration) { r.Nonce = "-1" }), http.StatusBadRequest, codeBadTerms},
+		{"bad max collateral", a.body(func(r *rawRegistration) { r.MaxCollateral = "-5" }), http.StatusBadRequest,
+			codeBadTerms},
+		{"expired", a.body(func(r *rawRegistration) { r.Deadline = "1000" }), http.StatusBadRequest, codeExpired},
+		{"revoked", a.body(func(r *rawRegistration) { r.User = other.Hex() }), http.StatusBadRequest, codeRevoked},
+		{"superseded", a.body(func(r *rawRegistration) { r.User = newer.Hex() }), http.StatusBadRequest,
+			codeSuperseded},
+		{"signature not hex", a.body(func(r *rawRegistration) { r.Signature = "sig" }), http.StatusBadRequest,
+			codeBadSignature},
+		{"short signature", a.body(func(r *rawRegistration) { r.Signature = short }), http.StatusBadRequest,
+			codeBadSignature},
+		{"empty signature", a.body(func(r *rawRegistration) { r.Signature = "0x" }), http.StatusBadRequest,
+			codeBadSignature},
+		// The signature is for other terms, so it recovers to another address.
+		{"other terms", a.body(func(r *rawRegistration) { r.Nonce = "8" }), http.StatusBadRequest, codeWrongSigner},
+		{"bad threshold", a.body(func(r *rawRegistration) { r.Threshold = "high" }), http.StatusBadRequest,
+			codeBadThreshold},
+		{"threshold above 1", a.body(func(r *rawRegistration) { r.Threshold = "70" }), http.StatusBadRequest,
+			codeBadThreshold},
+		{"threshold too small", a.body(func(r *rawRegistration) { r.Threshold = "0.00001" }), http.StatusBadRequest,
+			codeBadThreshold},
+		{"target above threshold", a.body(func(r *rawRegistration) { r.Target = "0.8" }), http.StatusBadRequest,
+			codeBadTarget},
+		{"bad target", a.body(func(r *rawRegistration) { r.Target = "low" }), http.StatusBadRequest, codeBadTarget},
+	} {
+		if status, code := a.do(t, http.MethodPost, tc.body); status != tc.status || code != tc.code {
+			t.Errorf("%s: POST /api/register = %d %q, want %d %q", tc.name, status, code, tc.status, tc.code)
+		}
+	}
+	regs, err := a.s.store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regs) != 2 {
+		t.Errorf("store has %d registrations after rejections, want 2", len(regs))
+	}
+}
+
+func TestRevokeRejections(t *testing.T) {
+	a := newAPITest(t)
+	for _, tc := range []struct {
+		name string
+		body string
+		code string
+	}{
+		{"not JSON", "{", codeBadBody},
+		{"bad address", `{"user": "0x01", "nonce": "1", "signature": "` + a.sig + `"}`, codeBadAddress},
+		{"bad nonce", `{"user": "` + a.user.Hex() + `", "nonce": "one", "signature": "` + a.sig + `"}`, codeBadTerms},
+		{"short signature", `{"user": "` + a.user.Hex() + `", "nonce": "1", "signature": "0x01"}`, codeBadSignature},
+		// The signature is of a certificate, not a revocation.
+		{"wrong signer", `{"user": "` + a.user.Hex() + `", "nonce": "1", "signature": "` + a.sig + `"}`,
+			codeWrongSigner},
+	} {
+		if status, code := a.do(t, http.MethodDelete, tc.body); status != http.StatusBadRequest || code != tc.code {
+			t.Errorf("%s: DELETE /api/register = %d %q, want 400 %q", tc.name, status, code, tc.code)
+		}
+	}
+}
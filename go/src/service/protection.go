@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"backoff"
+)
+
+// State is where the protection of a registration is.
+type State string
+
+const (
+	// StateWatching registrations wait for their loan to reach the threshold.
+	StateWatching State = "watching"
+	// StateTriggered registrations reached the threshold and are preparing a repayment.
+	StateTriggered State = "triggered"
+	// StateExecuting registrations are sending a repayment.
+	StateExecuting State = "executing"
+	// StateSucceeded registrations had their loan repaid. Partially repaid loans go back to
+	// watching.
+	StateSucceeded State = "succeeded"
+	// StateRetrying registrations failed to repay and wait to try again.
+	StateRetrying State = "retrying"
+	// StateHeld registrations reached the threshold but their repayment was withheld, because it
+	// would sell more collateral than the terms allow or the bot is in dry-run mode. The repayment
+	// isn't prepared again until the loan changes or the hold expires.
+	StateHeld State = "held"
+	// StateFailed registrations ran out of attempts or can't be repaid anymore, such as when the
+	// certificate expired.
+	StateFailed State = "failed"
+)
+
+// Defaults of `retryPolicy`.
+const (
+	defaultMaxAttempts    = 8
+	defaultMinRetryDelay  = 5 * time.Second
+	defaultMaxRetryDelay  = 2 * time.Minute
+	defaultRestartDelay   = time.Second
+	defaultMaxRestartWait = time.Minute
+	// The first hold of a loan lasts up to minHold, and each following one twice as long up to
+	// maxHold.
+	minHold = time.Minute
+	maxHold = time.Hour
+)
+
+// retryPolicy bounds the repayment attempts of a triggered registration.
+type retryPolicy struct {
+	// maxAttempts is the retry budget, including the first attempt.
+	maxAttempts int
+	// minDelay is the wait after the first failure. It doubles with each failure up to maxDelay.
+	minDelay, maxDelay time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts: defaultMaxAttempts,
+	minDelay:    defaultMinRetryDelay,
+	maxDelay:    defaultMaxRetryDelay,
+}
+
+// backoff returns the jittered wait after `attempt` failed attempts.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	return backoff.Wait(p.minDelay, p.maxDelay, attempt)
+}
+
+// protection tracks the state of a registration for the API.
+type protection struct {
+	mu    sync.Mutex
+	state State
+	// attempts counts the failed repayment attempts since the loan was last triggered.
+	attempts int
+	// lastErr is the error of the last failed attempt.
+	lastErr   error
+	nextRetry time.Time
+	updated   time.Time
+	// heldLoan identifies the loan the last `holds` consecutive holds were for. See `hold`.
+	heldLoan string
+	holds    int
+}
+
+// protectionStatus is a snapshot of a `protection`.
+type protectionStatus struct {
+	State     State
+	Attempts  int
+	LastErr   error
+	NextRetry time.Time
+	Updated   time.Time
+}
+
+// set moves to `state`. Errors are kept until the next successful repayment so the API can show
+// them while watching again. Held registrations stay held while watching.
+func (p *protection) set(state State, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.state == StateHeld && state == StateWatching {
+		return
+	}
+	p.state = state
+	p.updated = time.Now()
+	if err != nil {
+		p.lastErr = err
+	}
+	if state != StateRetrying && state != StateHeld {
+		p.nextRetry = time.Time{}
+	}
+	if state == StateSucceeded {
+		p.attempts = 0
+		p.lastErr = nil
+		p.heldLoan, p.holds = "", 0
+	}
+}
+
+// hold withholds the repayment of the loan identified by `loan` for `err`, and returns when it
+// may be prepared again. Holds of the same loan grow from `minHold` to `maxHold`.
+func (p *protection) hold(loan string, err error) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if loan != p.heldLoan {
+		p.heldLoan, p.holds = loan, 0
+	}
+	p.holds++
+	p.state = StateHeld
+	p.updated = time.Now()
+	p.lastErr = err
+	p.nextRetry = p.updated.Add(backoff.Wait(minHold, maxHold, p.holds))
+	return p.nextRetry
+}
+
+// held reports whether the repayment of the loan identified by `loan` is withheld at `now`.
+func (p *protection) held(loan string, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state == StateHeld && loan == p.heldLoan && now.Before(p.nextRetry)
+}
+
+// release ends the hold of a loan that recovered, so the next time it reaches the threshold is a
+// new trigger.
+func (p *protection) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.heldLoan, p.holds = "", 0
+	if p.state == StateHeld {
+		p.state = StateWatching
+		p.updated = time.Now()
+		p.nextRetry = time.Time{}
+	}
+}
+
+// retry records the failed attempt `attempt` and moves to retrying until `next`.
+func (p *protection) retry(err error, attempt int, next time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state = StateRetrying
+	p.updated = time.Now()
+	p.attempts = attempt
+	p.lastErr = err
+	p.nextRetry = next
+}
+
+func (p *protection) status() protectionStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return protectionStatus{
+		State:     p.state,
+		Attempts:  p.attempts,
+		LastErr:   p.lastErr,
+		NextRetry: p.nextRetry,
+		Updated:   p.updated,
+	}
+}
+
+// supervise runs `f` until it returns or `ctx` is done. If `f` panics, the panic is logged and `f`
+// restarted after a growing delay, so one failure doesn't take the other registrations down.
+func supervise(ctx context.Context, name string, f func(context.Context)) {
+	for restarts := 1; ; restarts++ {
+		if !panicked(ctx, name, f) || ctx.Err() != nil {
+			return
+		}
+		wait := backoff.Wait(defaultRestartDelay, defaultMaxRestartWait, restarts)
+		log.Printf("ALERT: %s crashed, restarting in %v", name, wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// panicked runs `f` and reports whether it panicked.
+func panicked(ctx context.Context, name string, f func(context.Context)) (ret bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s panicked: %v\n%s", name, r, debug.Stack())
+			ret = true
+		}
+	}()
+	f(ctx)
+	return false
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	p := retryPolicy{maxAttempts: 5, minDelay: time.Second, maxDelay: 4 * time.Second}
+	for _, tc := range []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{10, 4 * time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			if got := p.backoff(tc.attempt); got < tc.max/2 || got > tc.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tc.attempt, got, tc.max/2, tc.max)
+			}
+		}
+	}
+}
+
+func TestProtectionStates(t *testing.T) {
+	var p protection
+	failure := errors.New("aggregator down")
+	next := time.Now().Add(time.Minute)
+
+	p.set(StateTriggered, nil)
+	p.retry(failure, 2, next)
+	if st := p.status(); st.State != StateRetrying || st.Attempts != 2 || st.LastErr != failure ||
+		!st.NextRetry.Equal(next) {
+		t.Errorf("status() after retry = %+v, want retrying after 2 attempts", st)
+	}
+	// The error stays visible while watching again.
+	p.set(StateWatching, nil)
+	if st := p.status(); st.LastErr != failure || !st.NextRetry.IsZero() {
+		t.Errorf("status() while watching = %+v, want the last error and no retry", st)
+	}
+	p.set(StateSucceeded, nil)
+	if st := p.status(); st.LastErr != nil || st.Attempts != 0 {
+		t.Errorf("status() after success = %+v, want no error or attempts", st)
+	}
+}
+
+func TestProtectionHolds(t *testing.T) {
+	var p protection
+	limit := errors.New("above the collateral limit")
+	until := p.hold("loan", limit)
+	if min := time.Now().Add(minHold / 2); until.Before(min) || until.After(time.Now().Add(minHold)) {
+		t.Errorf("hold(...) = %v, want between %v and %v", until, min, time.Now().Add(minHold))
+	}
+	if !p.held("loan", time.Now()) || p.held("other loan", time.Now()) || p.held("loan", until) {
+		t.Errorf("held(...) = false for the held loan before %v, or true for another loan or time", until)
+	}
+	// Watching again doesn't end the hold, and holding the same loan again lasts longer.
+	p.set(StateWatching, nil)
+	if st := p.status(); st.State != StateHeld || st.LastErr != limit {
+		t.Errorf("status() while watching = %+v, want held", st)
+	}
+	if again := p.hold("loan", limit); again.Before(time.Now().Add(minHold)) {
+		t.Errorf("second hold(...) = %v, want at least %v", again, minHold)
+	}
+	p.release()
+	if st := p.status(); st.State != StateWatching || p.held("loan", time.Now()) {
+		t.Errorf("status() after release = %+v, want watching and not held", st)
+	}
+}
+
+func TestSuperviseRestartsAfterPanic(t *testing.T) {
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		supervise(context.Background(), "test", func(context.Context) {
+			runs++
+			if runs == 1 {
+				panic("boom")
+			}
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("supervise(...) didn't return after a clean run")
+	}
+	if runs != 2 {
+		t.Errorf("function ran %d times, want 2", runs)
+	}
+}
+
+func TestSuperviseStopsWhenDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	supervise(ctx, "test", func(context.Context) {
+		runs++
+		cancel()
+		panic("boom")
+	})
+	if runs != 1 {
+		t.Errorf("function ran %d times after its context was done, want 1", runs)
+	}
+}
@@ -2,12 +2,12 @@
 package service
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
+	"math"
+	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -24,13 +25,42 @@ import (
 	"clients"
 	"delegation"
 	"erc20"
+	"funds"
+	"monitor"
 	"repayment"
+	"swap"
+)
+
+const (
+	// divergenceTolerance is the relative difference between the Lending Pool's ratio and the
+	// ratio computed from price feeds above which an alert is logged.
+	divergenceTolerance = 0.01
+	// maxHistory bounds the executions kept per user.
+	maxHistory = 50
+	// defaultValidity is how long certificates are valid for if no deadline is requested.
+	defaultValidity = 90 * 24 * time.Hour
+	// atRiskFraction is the fraction of its threshold a loan's ratio must reach for the loan to count
+	// as at risk when budgeting the bot's funds.
+	atRiskFraction = 0.9
 )
 
 type rawRegistration struct {
 	User      string `json:"user"`
 	Signature string `json:"signature"`
 	Threshold string `json:"threshold"`
+	// Target is optional. If set, only enough debt is repaid to bring the ratio back to it.
+	Target string `json:"target"`
+	// Deadline, Nonce and MaxCollateral are the terms of the signed certificate as decimal
+	// strings. MaxCollateral is optional.
+	Deadline      string `json:"deadline"`
+	Nonce         string `json:"nonce"`
+	MaxCollateral string `json:"max-collateral"`
+}
+
+type rawRevocation struct {
+	User      string `json:"user"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
 }
 
 // Deps contains dependencies needed to instantiate the service.
@@ -40,20 +70,36 @@ type Deps struct {
 	RepAddr common.Address
 	Rep     *repayment.Repayment
 
-	// Root is the root path to statically served files.
+	// Root is the root path to statically served files. If empty, the client's `UIRoot` is used.
 	Root string
 
-	// Cert is the unsiged bot delegation certificate that grants permission to the bot to execute
-	// repayment.
-	Cert *delegation.Certificate
+	// Domain is the EIP-712 domain of the delegation certificates that grant permission to the bot
+	// to execute repayment. Its verifying contract must be `RepAddr`.
+	Domain delegation.Domain
+
+	// Store persists registrations across restarts. If nil, registrations are stored in the file at
+	// the client's `StorePath`.
+	Store RegistrationStore
+
+	// Quoters are the DEX aggregators collateral is sold through. If nil, `swap.Defaults` are used.
+	Quoters []swap.Quoter
 }
 
 // Service holds the service state.
 type Service struct {
-	client  *clients.Client
+	client *clients.Client
+	// bot is the address of the bot, the delegate of the certificates.
+	bot     common.Address
 	repAddr common.Address
 	rep     *repayment.Repayment
-	cert    *delegation.Certificate
+	domain  delegation.Domain
+	store   RegistrationStore
+	// storeMu serializes read-modify-write updates of stored registrations.
+	storeMu sync.Mutex
+	quoters []swap.Quoter
+	monitor *monitor.Monitor
+	funds   *funds.Watcher
+	retry   retryPolicy
 	router  *gin.Engine
 	// users contains the actively monitored loans. It maps from user `common.Address` to
 	// `*registration` values.
@@ -62,15 +108,39 @@ type Service struct {
 
 // New instantiates a new Service instance.
 func New(deps Deps) (*Service, error) {
+	if deps.Domain.VerifyingContract != deps.RepAddr {
+		return nil, fmt.Errorf("certificates are for contract %v, not the repayment contract %v",
+			deps.Domain.VerifyingContract, deps.RepAddr)
+	}
 	s := &Service{
 		client:  deps.Client,
+		bot:     deps.Client.BotAddress(),
 		repAddr: deps.RepAddr,
 		rep:     deps.Rep,
-		cert:    deps.Cert,
+		domain:  deps.Domain,
+		store:   deps.Store,
+		quoters: deps.Quoters,
+		monitor: monitor.New(deps.Client),
+		retry:   defaultRetryPolicy,
 		router:  gin.Default(),
 	}
+	if s.store == nil {
+		store, err := NewFileStore(deps.Client.StorePath())
+		if err != nil {
+			return nil, err
+		}
+		s.store = store
+	}
+	s.funds = funds.New(deps.Client.ETH(), deps.Client, s.bot, s.atRisk, funds.Config{
+		Floor:  deps.Client.MinBotBalance(),
+		Alerts: deps.Client.BotBalanceAlerts(),
+	})
 
-	s.router.Use(static.Serve("/", static.LocalFile(deps.Root, true)))
+	root := deps.Root
+	if root == "" {
+		root = deps.Client.UIRoot()
+	}
+	s.router.Use(static.Serve("/", static.LocalFile(root, true)))
 	api := s.router.Group("/api")
 
 	api.GET("/state", func(ctx *gin.Context) {
@@ -92,19 +162,62 @@ func New(deps Deps) (*Service, error) {
 			ctx.AbortWithError(400, err)
 			return
 		}
+		account, err := deps.Client.AccountData(ctx, addr)
+		if err != nil {
+			ctx.AbortWithError(400, err)
+			return
+		}
 
-		threshold := float64(loan.LiquidationThreshold) / float64(10000)
+		collateral, debt := amount.LargestCollateral(), amount.LargestDebt()
+		threshold := float64(amount.LiquidationThreshold) / float64(10000)
+		healthFactor, aaveHealthFactor := "", ""
+		if amount.HealthFactor != nil {
+			healthFactor = amount.HealthFactor.FloatString(4)
+		}
+		if account.HealthFactor != nil {
+			aaveHealthFactor = account.HealthFactor.FloatString(4)
+		}
 		ctx.JSON(http.StatusOK, gin.H{
-			"collateral-name":       loan.CollateralName,
-			"collateral-address":    loan.Collateral.String(),
-			"collateral-amount":     amount.CollateralAmount.String(),
-			"a-token-address":       loan.AToken.String(),
-			"debt-name":             loan.DebtName,
-			"debt-address":          loan.Debt.String(),
-			"debt-amount":           amount.DebtAmount.String(),
+			// The largest collateral and debt are the ones sold and repaid on execution.
+			"collateral-name":       collateral.Name,
+			"collateral-address":    collateral.Asset.String(),
+			"collateral-amount":     collateral.Amount.String(),
+			"a-token-address":       collateral.AToken.String(),
+			"debt-name":             debt.Name,
+			"debt-address":          debt.Asset.String(),
+			"debt-amount":           debt.Amount.String(),
+			"collateral":            assetsJSON(amount.Collateral),
+			"debt":                  assetsJSON(amount.Debt),
 			"current-ratio":         amount.CurrentRatio.FloatString(10),
+			"health-factor":         healthFactor,
 			"liquidation-threshold": fmt.Sprintf("%.4f", threshold),
-			"contract-address":      deps.RepAddr.String(),
+			// The same figures as reported by the Lending Pool.
+			"aave-ratio":                 fmt.Sprintf("%.4f", float64(account.Ratio())/float64(10000)),
+			"aave-health-factor":         aaveHealthFactor,
+			"aave-liquidation-threshold": fmt.Sprintf("%.4f", float64(account.LiquidationThreshold)/float64(10000)),
+			"contract-address":           deps.RepAddr.String(),
+			// The state of the bot's protection, or null if the user isn't registered.
+			"protection": s.protectionJSON(addr),
+		})
+	})
+
+	// Reports the bot's funds against the cost of repaying the loans at risk.
+	api.GET("/status", func(ctx *gin.Context) {
+		status, err := s.funds.Recent(ctx)
+		if err != nil {
+			ctx.AbortWithError(500, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"bot-address":        status.Address.String(),
+			"bot-balance":        status.Balance.String(),
+			"bot-balance-floor":  status.Floor.String(),
+			"at-risk":            status.AtRisk,
+			"fee-per-gas":        status.FeePerGas.String(),
+			"required-balance":   status.Required.String(),
+			"funds-cover-risk":   status.Covered(),
+			"accepting-requests": status.AboveFloor(),
+			"dry-run":            s.client.DryRun(),
 		})
 	})
 
@@ -119,142 +232,940 @@ func New(deps Deps) (*Service, error) {
 		}
 	})
 
+	// Returns the certificate for the user to sign. The terms can be set with the `deadline` (Unix
+	// time), `nonce` and `max-collateral` (wei) query parameters. By default the certificate is
+	// valid for `defaultValidity`, has no collateral limit and uses the next nonce of the user given
+	// by `address`.
 	api.GET("/cert", func(ctx *gin.Context) {
-		ctx.AsciiJSON(http.StatusOK, deps.Cert.TypedData())
+		terms, err := s.requestedTerms(ctx)
+		if err != nil {
+			ctx.AbortWithError(400, err)
+			return
+		}
+		cert, err := delegation.New(s.bot, s.domain, *terms)
+		if err != nil {
+			ctx.AbortWithError(500, err)
+			return
+		}
+		ctx.AsciiJSON(http.StatusOK, cert.TypedData())
 	})
 
-	api.POST("/register", func(ctx *gin.Context) {
-		body, err := ioutil.ReadAll(ctx.Request.Body)
+	// Returns the revocation for the user to sign to revoke certificates up to `nonce`.
+	api.GET("/revocation", func(ctx *gin.Context) {
+		nonce, err := strconv.ParseUint(ctx.Query("nonce"), 10, 64)
 		if err != nil {
-			ctx.AbortWithError(400, fmt.Errorf("getting register body contents: %w", err))
+			ctx.AbortWithError(400, fmt.Errorf("nonce parse error: %w", err))
+			return
 		}
-		rr := &rawRegistration{}
-		if err := json.Unmarshal(body, rr); err != nil {
-			ctx.AbortWithError(400, fmt.Errorf("couldn't parse body %s: %w", body, err))
+		rev, err := delegation.NewRevocation(s.bot, s.domain, nonce)
+		if err != nil {
+			ctx.AbortWithError(500, err)
+			return
 		}
+		ctx.AsciiJSON(http.StatusOK, rev.TypedData())
+	})
+
+	api.POST("/register", s.handleRegister)
 
-		reg, err := s.verify(ctx, rr)
+	// Returns the registration of a user with the state of its protection and the history of
+	// repayments.
+	api.GET("/registrations/:address", func(ctx *gin.Context) {
+		hexAddr := ctx.Param("address")
+		if !common.IsHexAddress(hexAddr) {
+			abort(ctx, invalid(codeBadAddress, "%q is not a hex address", hexAddr))
+			return
+		}
+		user := common.HexToAddress(hexAddr)
+		stored, err := s.store.Get(user)
 		if err != nil {
-			ctx.AbortWithError(400, fmt.Errorf("invalid registration: %w", err))
+			abort(ctx, err)
+			return
+		}
+		if stored == nil || stored.Signature == nil {
+			// Revoking without registering leaves a registration without a certificate.
+			abort(ctx, &apiError{status: http.StatusNotFound, code: codeNotRegistered,
+				message: fmt.Sprintf("%v isn't registered", user.Hex())})
+			return
 		}
+		ctx.JSON(http.StatusOK, s.registrationJSON(stored))
+	})
 
-		go s.process(reg)
+	api.DELETE("/register", s.handleRevoke)
 
-		ctx.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
-		ctx.Status(http.StatusOK)
-	})
+	if err := s.resume(); err != nil {
+		return nil, err
+	}
+	// The monitor and the funds watcher serve all registrations for the lifetime of the process, so
+	// they only start once nothing can fail.
+	go supervise(context.Background(), "monitor", s.monitor.Run)
+	go supervise(context.Background(), "funds watcher", s.funds.Run)
 	return s, nil
 }
 
-// Run runs the service. NB: this method does not return.
+// resume restarts monitoring for all active registrations in the store.
+func (s *Service) resume() error {
+	regs, err := s.store.List()
+	if err != nil {
+		return fmt.Errorf("loading stored registrations: %w", err)
+	}
+	for _, r := range regs {
+		if r.Status != StatusActive {
+			continue
+		}
+		log.Printf("Resuming monitoring for %v at threshold %d", r.User, r.Threshold)
+		reg, err := s.newRegistration(r.User, r.Signature, r.Threshold, r.Target, delegation.Terms{
+			Deadline:      r.Deadline,
+			Nonce:         r.Nonce,
+			MaxCollateral: r.MaxCollateral,
+		})
+		if err != nil {
+			// The other registrations are still protected.
+			log.Printf("ALERT: can't resume registration for %v: %v", r.User, err)
+			continue
+		}
+		go s.process(reg)
+	}
+	return nil
+}
+
+// persist writes the registration to the store with the given status.
+func (s *Service) persist(r *registration, status RegistrationStatus) error {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+	prev, err := s.store.Get(r.user)
+	if err != nil {
+		return fmt.Errorf("loading registration for %v: %w", r.user, err)
+	}
+	stored := &StoredRegistration{
+		User:          r.user,
+		Signature:     r.signature,
+		Threshold:     uint16(atomic.LoadInt32(&r.threshold)),
+		Target:        uint16(atomic.LoadInt32(&r.target)),
+		Deadline:      r.terms.Deadline,
+		Nonce:         r.terms.Nonce,
+		MaxCollateral: r.terms.MaxCollateral,
+		Status:        status,
+	}
+	if prev != nil {
+		stored.RevokedNonce = prev.RevokedNonce
+		stored.History = prev.History
+	}
+	if err := s.store.Put(stored); err != nil {
+		return fmt.Errorf("storing registration for %v: %w", r.user, err)
+	}
+	return nil
+}
+
+// record appends a repayment to the history of the user.
+func (s *Service) record(user common.Address, e *Execution) error {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+	stored, err := s.store.Get(user)
+	if err != nil {
+		return fmt.Errorf("loading registration for %v: %w", user, err)
+	}
+	if stored == nil {
+		return fmt.Errorf("no registration for %v", user)
+	}
+	// A new slice so stores holding the previous one aren't modified.
+	history := append(make([]*Execution, 0, len(stored.History)+1), stored.History...)
+	history = append(history, e)
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	stored.History = history
+	if err := s.store.Put(stored); err != nil {
+		return fmt.Errorf("storing history of %v: %w", user, err)
+	}
+	return nil
+}
+
+// revoke records the revocation of the user's certificates up to `nonce` and stops monitoring
+// their loan if its certificate is revoked.
+func (s *Service) revoke(user common.Address, nonce uint64) error {
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+	stored, err := s.store.Get(user)
+	if err != nil {
+		return fmt.Errorf("loading registration for %v: %w", user, err)
+	}
+	if stored == nil {
+		stored = &StoredRegistration{User: user, Status: StatusRevoked}
+	}
+	if stored.RevokedNonce != nil && *stored.RevokedNonce > nonce {
+		// An earlier revocation already covers this one.
+		nonce = *stored.RevokedNonce
+	}
+	stored.RevokedNonce = &nonce
+	if stored.Nonce <= nonce {
+		stored.Status = StatusRevoked
+	}
+	if err := s.store.Put(stored); err != nil {
+		return fmt.Errorf("storing revocation for %v: %w", user, err)
+	}
+
+	if v, ok := s.users.Load(user); ok {
+		if reg := v.(*registration); reg.terms.Nonce <= nonce {
+			log.Printf("Certificate %d of %v revoked, stopping monitoring", reg.terms.Nonce, user)
+			reg.cancel()
+			s.users.CompareAndDelete(user, reg)
+		}
+	}
+	return nil
+}
+
+// requestedTerms returns the certificate terms requested by the query parameters of `ctx`.
+func (s *Service) requestedTerms(ctx *gin.Context) (*delegation.Terms, error) {
+	terms := &delegation.Terms{
+		Deadline:      uint64(time.Now().Add(defaultValidity).Unix()),
+		MaxCollateral: new(big.Int),
+	}
+	var err error
+	if v := ctx.Query("deadline"); v != "" {
+		if terms.Deadline, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("deadline parse error: %w", err)
+		}
+	}
+	if v := ctx.Query("max-collateral"); v != "" {
+		if _, ok := terms.MaxCollateral.SetString(v, 10); !ok || terms.MaxCollateral.Sign() < 0 {
+			return nil, fmt.Errorf("max-collateral %q is not a non-negative integer", v)
+		}
+	}
+	if v := ctx.Query("nonce"); v != "" {
+		if terms.Nonce, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("nonce parse error: %w", err)
+		}
+	} else if hexAddr := ctx.Query("address"); common.IsHexAddress(hexAddr) {
+		stored, err := s.store.Get(common.HexToAddress(hexAddr))
+		if err != nil {
+			return nil, fmt.Errorf("loading registration for %v: %w", hexAddr, err)
+		}
+		if stored != nil {
+			terms.Nonce = stored.Nonce + 1
+			if stored.RevokedNonce != nil && *stored.RevokedNonce >= terms.Nonce {
+				terms.Nonce = *stored.RevokedNonce + 1
+			}
+		}
+	}
+	return terms, nil
+}
+
+// registrationJSON returns a stored registration for the registrations API. The protection fields
+// come from the monitored registration, if any.
+func (s *Service) registrationJSON(stored *StoredRegistration) gin.H {
+	ret := gin.H{
+		"user":       stored.User.String(),
+		"status":     stored.Status,
+		"threshold":  fmt.Sprintf("%.4f", float64(stored.Threshold)/10000),
+		"deadline":   stored.Deadline,
+		"nonce":      stored.Nonce,
+		"protection": nil,
+		"history":    historyJSON(stored.History),
+	}
+	if stored.Target > 0 {
+		ret["target"] = fmt.Sprintf("%.4f", float64(stored.Target)/10000)
+	}
+	if stored.MaxCollateral != nil && stored.MaxCollateral.Sign() > 0 {
+		ret["max-collateral"] = stored.MaxCollateral.String()
+	}
+	v, ok := s.users.Load(stored.User)
+	if !ok {
+		return ret
+	}
+	reg := v.(*registration)
+	// The monitored registration is more recent than the stored one until they are in sync.
+	ret["threshold"] = fmt.Sprintf("%.4f", float64(atomic.LoadInt32(&reg.threshold))/10000)
+	ret["protection"] = s.protectionJSON(stored.User)
+	if checked := atomic.LoadInt64(&reg.checked); checked > 0 {
+		ret["last-ratio"] = fmt.Sprintf("%.4f", float64(atomic.LoadInt32(&reg.ratio))/10000)
+		ret["last-check"] = checked
+	}
+	return ret
+}
+
+// historyJSON returns the repayment history of a user for the registrations API, newest first.
+func historyJSON(history []*Execution) []gin.H {
+	ret := make([]gin.H, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		entry := gin.H{
+			"time":             e.Time,
+			"succeeded":        e.Succeeded,
+			"collateral-asset": e.CollateralAsset.String(),
+			"collateral-sold":  e.CollateralSold.String(),
+			"debt-asset":       e.DebtAsset.String(),
+			"debt-repaid":      e.DebtRepaid.String(),
+		}
+		if e.TxHash != (common.Hash{}) {
+			entry["tx-hash"] = e.TxHash.Hex()
+			entry["block"] = e.Block
+			entry["gas-used"] = e.GasUsed
+		}
+		if e.Error != "" {
+			entry["error"] = e.Error
+		}
+		ret = append(ret, entry)
+	}
+	return ret
+}
+
+// protectionJSON returns the state of the protection of the user for the state API, or nil if the
+// user isn't monitored.
+func (s *Service) protectionJSON(user common.Address) gin.H {
+	v, ok := s.users.Load(user)
+	if !ok {
+		return nil
+	}
+	st := v.(*registration).prot.status()
+	ret := gin.H{
+		"state":    st.State,
+		"attempts": st.Attempts,
+		"updated":  st.Updated.Unix(),
+	}
+	if st.LastErr != nil {
+		ret["last-error"] = st.LastErr.Error()
+	}
+	if !st.NextRetry.IsZero() {
+		ret["next-retry"] = st.NextRetry.Unix()
+	}
+	return ret
+}
+
+// assetsJSON returns the per-reserve breakdown of a loan for the state API.
+func assetsJSON(amounts []*clients.AssetAmount) []gin.H {
+	ret := make([]gin.H, 0, len(amounts))
+	for _, a := range amounts {
+		ret = append(ret, gin.H{
+			"name":                  a.Name,
+			"address":               a.Asset.String(),
+			"a-token-address":       a.AToken.String(),
+			"amount":                a.Amount.String(),
+			"eth-value":             a.ETHValue.FloatString(18),
+			"liquidation-threshold": fmt.Sprintf("%.4f", float64(a.LiquidationThreshold)/float64(10000)),
+		})
+	}
+	return ret
+}
+
+// Run runs the service on the client's listen address. NB: this method does not return.
 func (s *Service) Run() {
-	s.router.Run(":3000")
+	s.router.Run(s.client.ListenAddress())
 }
 
 type registration struct {
-	user      common.Address
-	signature []byte
+	user common.Address
+	// signature is the user's signature of the certificate with `terms`, and `delegation` is the
+	// two packed for the repayment contract. They don't change after creation.
+	signature  []byte
+	terms      delegation.Terms
+	delegation []byte
 	// threshold is the ratio at which to liquidate in units of 1/10000. Its value is uint16, but that
 	// type is not supported by atomic.
 	threshold int32
+	// target is the ratio to restore on repayment in units of 1/10000. If 0, the debt is repaid in
+	// full. Stored as int32 for the same reason as threshold.
+	target int32
+	// ratio is the loan's ratio in units of 1/10000 as of its last evaluation, or 0 if it wasn't
+	// evaluated yet.
+	ratio int32
+	// checked is the Unix time of the last evaluation of the loan, or 0.
+	checked int64
+	// prot tracks the state of the protection for the API.
+	prot protection
 
+	// ctx is cancelled to stop monitoring, such as when the certificate is revoked.
+	ctx     context.Context
+	cancel  context.CancelFunc
 	runOnce sync.Once
 }
 
+// newRegistration creates a registration for the user's signature of the certificate with the
+// given terms.
+func (s *Service) newRegistration(user common.Address, signature []byte, threshold, target uint16,
+	terms delegation.Terms) (*registration, error) {
+	cert, err := delegation.New(s.bot, s.domain, terms)
+	if err != nil {
+		return nil, err
+	}
+	packed, err := cert.Pack(signature)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &registration{
+		user:       user,
+		signature:  signature,
+		terms:      cert.Terms(),
+		delegation: packed,
+		threshold:  int32(threshold),
+		target:     int32(target),
+		ctx:        ctx,
+		cancel:     cancel,
+	}, nil
+}
+
 func (s *Service) process(r *registration) {
-	v, ok := s.users.Load(r.user)
-	if !ok {
-		var loaded bool
+	v, loaded := s.users.LoadOrStore(r.user, r)
+	reg := v.(*registration)
+	if loaded && r.terms.Nonce > reg.terms.Nonce {
+		// A newer certificate replaces the monitored one.
+		reg.cancel()
+		s.users.CompareAndDelete(r.user, reg)
 		v, loaded = s.users.LoadOrStore(r.user, r)
-		if loaded {
-			// Only the threshold value can change.
-			atomic.StoreInt32(&(v.(*registration).threshold), r.threshold)
-		}
+		reg = v.(*registration)
+	}
+	if loaded && r.terms.Nonce < reg.terms.Nonce {
+		// An older certificate never replaces a newer one.
+		r.cancel()
+		return
+	}
+	if loaded {
+		// Only the threshold values can change.
+		atomic.StoreInt32(&reg.threshold, r.threshold)
+		atomic.StoreInt32(&reg.target, r.target)
+		r.cancel()
 	}
-	ctx := context.Background()
-	reg := v.(*registration)
 	reg.runOnce.Do(func() {
-		loan, err := s.client.Loan(ctx, reg.user)
-		if err != nil {
-			log.Fatalf("Error retrieving loan for %v: %v", reg.user, err)
-		}
-		for {
-			data, err := loan.Data(ctx, s.client)
-			if err != nil {
-				// Logs an error message. The query will be retried on the next cycle.
-				log.Printf("Error getting loan amounts for %v: %v", reg.user, err)
-			} else {
-				log.Printf("Collateral = %s %v, Debt = %s %v", loan.CollateralName, data.CollateralAmount, loan.DebtName, data.DebtAmount)
-				threshold := uint16(atomic.LoadInt32(&reg.threshold))
-				ratio := data.Ratio()
-				if ratio >= threshold {
-					log.Printf("ratio %d >= threshold %d, repaying", ratio, threshold)
-					break
-				}
-				log.Printf("ratio %d < threshold %d", ratio, threshold)
+		defer s.users.CompareAndDelete(reg.user, reg)
+		updates := s.monitor.Watch(reg.user)
+		defer s.monitor.Unwatch(reg.user, updates)
+		supervise(reg.ctx, fmt.Sprintf("protection of %v", reg.user.Hex()), func(ctx context.Context) {
+			s.protect(ctx, reg, updates)
+		})
+	})
+}
+
+// protect watches the loan of the registration and repays it when it reaches the threshold, until
+// the registration ends.
+func (s *Service) protect(ctx context.Context, reg *registration, updates <-chan *clients.LoanState) {
+	for {
+		reg.prot.set(StateWatching, nil)
+		loan := s.waitForThreshold(ctx, reg, updates)
+		if loan == nil {
+			return
+		}
+		if s.trigger(ctx, reg, loan) {
+			return
+		}
+	}
+}
+
+// trigger repays a loan that reached the threshold, retrying failures with backoff until the retry
+// budget runs out. It reports whether the registration ended.
+func (s *Service) trigger(ctx context.Context, reg *registration, loan *clients.Loan) bool {
+	if reg.terms.Expired(time.Now()) {
+		log.Printf("ALERT: certificate of %v expired, can't repay", reg.user)
+		reg.prot.set(StateFailed, fmt.Errorf("certificate expired at %d", reg.terms.Deadline))
+		if err := s.persist(reg, StatusExpired); err != nil {
+			log.Printf("Error recording expiry: %v", err)
+		}
+		return true
+	}
+	if reg.prot.held(loanKey(reg, loan), time.Now()) {
+		return false
+	}
+	// The contract rejects certificates the user revoked on-chain.
+	if minNonce, err := s.minNonce(ctx, reg.user); err != nil {
+		log.Printf("Error checking on-chain revocations of %v: %v", reg.user, err)
+	} else if reg.terms.Nonce < minNonce {
+		log.Printf("Certificate %d of %v was revoked on-chain, stopping monitoring", reg.terms.Nonce, reg.user)
+		if err := s.revoke(reg.user, minNonce-1); err != nil {
+			log.Printf("Error recording revocation: %v", err)
+		}
+		return true
+	}
+	for attempt := 1; ; attempt++ {
+		reg.prot.set(StateTriggered, nil)
+		done, err := s.repay(ctx, reg, loan)
+		if err == nil {
+			return done
+		}
+		if ctx.Err() != nil {
+			return true
+		}
+		if attempt >= s.retry.maxAttempts {
+			log.Printf("ALERT: giving up repaying %v after %d attempts: %v", reg.user, attempt, err)
+			reg.prot.set(StateFailed, err)
+			if err := s.persist(reg, StatusFailed); err != nil {
+				log.Printf("Error recording failure: %v", err)
 			}
-			time.Sleep(time.Second * 5)
+			return true
+		}
+		wait := s.retry.backoff(attempt)
+		log.Printf("Error repaying %v on attempt %d of %d, retrying in %v: %v", reg.user, attempt,
+			s.retry.maxAttempts, wait, err)
+		reg.prot.retry(err, attempt, time.Now().Add(wait))
+		select {
+		case <-ctx.Done():
+			return true
+		case <-time.After(wait):
+		}
+
+		// The loan may have changed or recovered while waiting.
+		next, triggered, err := s.evaluate(ctx, reg, nil)
+		switch {
+		case err != nil:
+			log.Printf("Error re-evaluating loan of %v, retrying with the previous one: %v", reg.user, err)
+		case !triggered:
+			log.Printf("Loan of %v recovered while waiting to retry", reg.user)
+			return false
+		default:
+			loan = next
 		}
-		exec, err := repayment.NewExecution(ctx, s.client, loan, s.repAddr, reg.signature)
+	}
+}
+
+// repay prepares and executes the repayment of the loan. It reports whether the registration
+// ended, which it does once the whole debt is repaid (see `finish`).
+func (s *Service) repay(ctx context.Context, reg *registration, loan *clients.Loan) (bool, error) {
+	if err := s.client.ConfirmLoan(ctx, loan, uint16(atomic.LoadInt32(&reg.threshold))); err != nil {
+		if errors.Is(err, clients.ErrUnconfirmed) {
+			// The node the loan was read from is likely faulty. Monitoring goes on.
+			log.Printf("ALERT: not repaying %v: %v", reg.user, err)
+			return false, nil
+		}
+		return false, fmt.Errorf("confirming loan with a quorum of nodes: %w", err)
+	}
+	target := uint16(atomic.LoadInt32(&reg.target))
+	exec, err := repayment.NewExecution(ctx, s.client, loan, s.repAddr, reg.delegation, target, s.quoters)
+	if err != nil {
+		return false, fmt.Errorf("preparing repayment: %w", err)
+	}
+	if value := exec.CollateralValue(); !reg.terms.AllowsCollateral(value) {
+		// The loan may shrink or the user may register a higher limit.
+		err := fmt.Errorf("repaying would sell %v wei of collateral, above the limit of %v", value,
+			reg.terms.MaxCollateral)
+		until := reg.prot.hold(loanKey(reg, loan), err)
+		log.Printf("ALERT: not repaying %v until the loan changes or %v: %v", reg.user,
+			until.Format(time.RFC3339), err)
+		return false, nil
+	}
+	if s.client.DryRun() {
+		sim, err := exec.Simulate(ctx, s.client)
 		if err != nil {
-			log.Fatalf("Error preparing repayment execution: %v", err)
+			log.Printf("Dry run: repayment of %v would fail: %v", reg.user, err)
+			err = fmt.Errorf("dry run: repayment would fail: %w", err)
+		} else {
+			log.Printf("Dry run: would %v", sim)
+		}
+		// Monitoring continues so the logs show what would happen as the loan changes.
+		reg.prot.hold(loanKey(reg, loan), err)
+		return false, nil
+	}
+
+	reg.prot.set(StateExecuting, nil)
+	result, err := exec.Execute(ctx, s.client, s.rep)
+	if result != nil {
+		s.recordResult(reg.user, result, err)
+	}
+	if err != nil {
+		if funds.IsInsufficientFunds(err) {
+			log.Printf("ALERT: bot can't pay for repaying %v: %v", reg.user, err)
+			if _, err := s.funds.Check(ctx); err != nil {
+				log.Printf("Error checking bot funds: %v", err)
+			}
+		}
+		return false, fmt.Errorf("executing repayment: %w", err)
+	}
+	reg.prot.set(StateSucceeded, nil)
+	if target > 0 {
+		// The loan is still open after a partial repayment, so monitoring continues.
+		log.Printf("Partially repaid loan of %v down to ratio %d", reg.user, target)
+		return false, nil
+	}
+	return s.finish(ctx, reg, loan, result.DebtAsset)
+}
+
+// finish ends the registration after the full repayment of the `repaid` debt of the loan if no
+// debt is left. Only the largest debt is repaid at a time, so a loan borrowing from several
+// reserves is re-evaluated and its next debt repaid while it still reaches the threshold. Otherwise
+// monitoring continues.
+func (s *Service) finish(ctx context.Context, reg *registration, loan *clients.Loan, repaid common.Address) (bool,
+	error) {
+	// The repaid reserve may no longer be part of the loan.
+	s.client.ForgetLoan(reg.user)
+	account, err := s.client.AccountData(ctx, reg.user)
+	if err != nil {
+		log.Printf("Error checking the debt left by %v, monitoring continues: %v", reg.user, err)
+		return false, nil
+	}
+	if account.TotalDebtETH.Sign() == 0 {
+		if err := s.persist(reg, StatusRepaid); err != nil {
+			// The loan is repaid, so resuming it after a restart is harmless.
+			log.Printf("Error recording repayment: %v", err)
+		}
+		return true, nil
+	}
+	for _, r := range loan.Debt {
+		if r.Asset != repaid {
+			continue
 		}
-		if err := exec.Execute(ctx, s.client, s.rep); err != nil {
-			log.Fatalf("Error executing repayment: %v", err)
+		// Repaying the same reserve again right away could loop without end.
+		left, err := loan.DebtAmount(ctx, s.client, r)
+		if err != nil || left.Sign() > 0 {
+			log.Printf("ALERT: debt of %v to %s is left after repaying it, monitoring continues (err: %v)",
+				reg.user, r.Name, err)
+			return false, nil
+		}
+	}
+	log.Printf("Repaid the %v debt of %v, %v wei of debt is left in other reserves", repaid.Hex(), reg.user,
+		account.TotalDebtETH)
+	next, triggered, err := s.evaluate(ctx, reg, nil)
+	if err != nil {
+		log.Printf("Error re-evaluating loan of %v, monitoring continues: %v", reg.user, err)
+		return false, nil
+	}
+	if !triggered {
+		return false, nil
+	}
+	return s.repay(ctx, reg, next)
+}
+
+// recordResult adds a repayment transaction to the history of the user.
+func (s *Service) recordResult(user common.Address, r *repayment.Result, err error) {
+	e := &Execution{
+		Time:            time.Now().Unix(),
+		TxHash:          r.TxHash,
+		Block:           r.Block,
+		Succeeded:       r.Succeeded,
+		CollateralAsset: r.CollateralAsset,
+		CollateralSold:  r.CollateralSold,
+		DebtAsset:       r.DebtAsset,
+		DebtRepaid:      r.DebtRepaid,
+		GasUsed:         r.GasUsed,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := s.record(user, e); err != nil {
+		log.Printf("Error recording repayment of %v in tx %v: %v", user, r.TxHash.Hex(), err)
+	}
+}
+
+// atRisk returns the number of monitored loans whose ratio is within `atRiskFraction` of their
+// threshold. Loans without collateral can't be repaid, so they don't count.
+func (s *Service) atRisk() int {
+	n := 0
+	s.users.Range(func(_, v interface{}) bool {
+		reg := v.(*registration)
+		threshold := float64(atomic.LoadInt32(&reg.threshold))
+		ratio := atomic.LoadInt32(&reg.ratio)
+		if ratio != clients.NoCollateralRatio && float64(ratio) >= threshold*atRiskFraction {
+			n++
 		}
+		return true
 	})
+	return n
 }
 
+// checkDivergence logs an alert if the loan ratio computed from the Chainlink feeds differs from
+// the Lending Pool's by more than `divergenceTolerance`.
+func (s *Service) checkDivergence(state *clients.LoanState) {
+	if state.AmountErr != nil {
+		log.Printf("Error getting loan amounts for %v: %v", state.User, state.AmountErr)
+		return
+	}
+	data, account := state.Amount, state.Account
+	log.Printf("Collateral = %s ETH, Debt = %s ETH", data.CollateralETH.FloatString(6), data.DebtETH.FloatString(6))
+	if d := account.Divergence(data); d > divergenceTolerance {
+		log.Printf("ALERT: ratio for %v from price feeds (%d) diverges from the Lending Pool's (%d) by %.2f%%",
+			state.User, data.Ratio(), account.Ratio(), d*100)
+	}
+}
+
+// waitForThreshold evaluates the loan each time the monitor signals a change until its ratio reaches
+// the registration threshold. It returns the loan as of that evaluation, or nil if `ctx` is done
+// first.
+func (s *Service) waitForThreshold(ctx context.Context, reg *registration,
+	updates <-chan *clients.LoanState) *clients.Loan {
+	for {
+		var state *clients.LoanState
+		select {
+		case <-ctx.Done():
+			return nil
+		case state = <-updates:
+		}
+		loan, triggered, err := s.evaluate(ctx, reg, state)
+		if err != nil {
+			// Logs an error message. The evaluation will be retried on the next update.
+			log.Printf("Error evaluating loan of %v: %v", reg.user, err)
+			continue
+		}
+		if triggered {
+			return loan
+		}
+	}
+}
+
+// evaluate reports whether the ratio of the registration's loan reached the threshold. The loan is
+// the one in `state`, as read by the monitor, or looked up and read if `state` is nil.
+func (s *Service) evaluate(ctx context.Context, reg *registration, state *clients.LoanState) (*clients.Loan, bool,
+	error) {
+	if state == nil {
+		// The loan is looked up every time since the monitor drops it when the user changes reserves.
+		loan, err := s.client.Loan(ctx, reg.user)
+		if err != nil {
+			s.client.ForgetLoan(reg.user)
+			return nil, false, fmt.Errorf("retrieving loan: %w", err)
+		}
+		state = s.client.ReadLoan(ctx, loan)
+	}
+	// The Lending Pool's own account data is authoritative since it's what liquidation uses.
+	if state.Err != nil {
+		return nil, false, state.Err
+	}
+	account, loan := state.Account, state.Loan
+	s.checkDivergence(state)
+	threshold := uint16(atomic.LoadInt32(&reg.threshold))
+	ratio := account.Ratio()
+	atomic.StoreInt32(&reg.ratio, int32(ratio))
+	atomic.StoreInt64(&reg.checked, time.Now().Unix())
+	if ratio == clients.NoCollateralRatio {
+		// Repayment sells collateral, so there's nothing to repay with.
+		log.Printf("ALERT: %v owes %v wei of debt without any collateral, can't repay", reg.user,
+			account.TotalDebtETH)
+		return loan, false, nil
+	}
+	if ratio >= threshold {
+		log.Printf("ratio %d >= threshold %d, repaying", ratio, threshold)
+		return loan, true, nil
+	}
+	log.Printf("ratio %d < threshold %d", ratio, threshold)
+	reg.prot.release()
+	return loan, false, nil
+}
+
+// loanKey identifies the loan of the registration as repaid: its reserves and the registration's
+// threshold and target. A repayment withheld for the loan is held until it changes (see
+// `protection.hold`).
+func loanKey(reg *registration, loan *clients.Loan) string {
+	key := fmt.Sprintf("%d/%d", atomic.LoadInt32(&reg.threshold), atomic.LoadInt32(&reg.target))
+	for _, rs := range [][]*clients.Reserve{loan.Collateral, loan.Debt} {
+		key += "/"
+		for _, r := range rs {
+			key += r.Asset.Hex()
+		}
+	}
+	return key
+}
+
+// handleRegister registers a user's signed certificate and starts protecting their loan.
+func (s *Service) handleRegister(ctx *gin.Context) {
+	rr := &rawRegistration{}
+	if err := decodeBody(ctx, rr); err != nil {
+		abort(ctx, err)
+		return
+	}
+	reg, err := s.verify(ctx, rr)
+	if err != nil {
+		abort(ctx, fmt.Errorf("invalid registration: %w", err))
+		return
+	}
+	// Protection the bot can't pay for would fail silently when the loan needs repaying.
+	if err := s.funds.Admit(ctx); err != nil {
+		if errors.Is(err, funds.ErrLowFunds) {
+			err = &apiError{status: http.StatusServiceUnavailable, code: codeLowFunds,
+				message: fmt.Sprintf("not accepting registrations: %v", err)}
+		}
+		abort(ctx, err)
+		return
+	}
+
+	if err := s.persist(reg, StatusActive); err != nil {
+		abort(ctx, err)
+		return
+	}
+	go s.process(reg)
+
+	stored, err := s.store.Get(reg.user)
+	if err != nil || stored == nil {
+		// The registration is active. Only its description is missing.
+		ctx.Status(http.StatusOK)
+		return
+	}
+	ctx.JSON(http.StatusOK, s.registrationJSON(stored))
+}
+
+// handleRevoke revokes the certificates of a user up to a signed nonce.
+func (s *Service) handleRevoke(ctx *gin.Context) {
+	rr := &rawRevocation{}
+	if err := decodeBody(ctx, rr); err != nil {
+		abort(ctx, err)
+		return
+	}
+	user, nonce, err := s.verifyRevocation(rr)
+	if err != nil {
+		abort(ctx, fmt.Errorf("invalid revocation: %w", err))
+		return
+	}
+	if err := s.revoke(user, nonce); err != nil {
+		abort(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// verify checks a registration and returns it ready for processing. Errors about the request wrap
+// an `*apiError`. The checks that don't need the chain come first.
 func (s *Service) verify(ctx context.Context, r *rawRegistration) (*registration, error) {
 	if !common.IsHexAddress(r.User) {
-		return nil, fmt.Errorf("user was not a hex address: %v", r)
+		return nil, invalid(codeBadAddress, "user %q is not a hex address", r.User)
 	}
 	user := common.HexToAddress(r.User)
 
+	// Verifies the terms of the certificate.
+	terms, err := s.verifyTerms(user, r)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verifies the signature is the user-signed delegation certificate for the bot (this process).
-	sig, err := hexutil.Decode(r.Signature)
+	sig, err := decodeSignature(r.Signature)
 	if err != nil {
-		return nil, fmt.Errorf("signature was not hex %v: %w", r, err)
+		return nil, err
 	}
-	// crypto.Ecrecover seems to expect 0, 1 instead of 27 or 28.
-	switch sig[64] {
-	case '\x1b':
-		sig[64] = '\x00'
-	case '\x1c':
-		sig[64] = '\x01'
+	cert, err := delegation.New(s.bot, s.domain, *terms)
+	if err != nil {
+		return nil, err
 	}
-	rpk, err := crypto.Ecrecover(s.cert.Hash().Bytes(), sig)
+	signer, err := cert.Verify(sig)
 	if err != nil {
-		return nil, fmt.Errorf("recovering signer %v: %w", r, err)
+		return nil, invalid(codeBadSignature, "verifying signature: %v", err)
+	}
+	if signer != user {
+		return nil, invalid(codeWrongSigner, "recovered signer %v didn't match user %v", signer.Hex(), user.Hex())
+	}
+
+	// Verifies the threshold and target values.
+	threshold, ok := parseRatio(r.Threshold)
+	if !ok {
+		return nil, invalid(codeBadThreshold, "threshold %q is not a number between 0 and 1", r.Threshold)
 	}
-	pubKey, err := crypto.UnmarshalPubkey(rpk)
+	var target uint16
+	if r.Target != "" {
+		if target, ok = parseRatio(r.Target); !ok || target >= threshold {
+			return nil, invalid(codeBadTarget, "target %q must be between 0 and threshold %q", r.Target,
+				r.Threshold)
+		}
+	}
+	if _, err := s.client.Loan(ctx, user); err != nil {
+		return nil, invalid(codeNoLoan, "looking up loan for %v: %v", user.Hex(), err)
+	}
+	minNonce, err := s.minNonce(ctx, user)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshalling public key %v: %w", pubKey, err)
+		return nil, err
 	}
-	if signer := crypto.PubkeyToAddress(*pubKey); signer != user {
-		return nil, fmt.Errorf("recovered signer %v didn't match user %v: %v", signer, user, r)
+	if terms.Nonce < minNonce {
+		return nil, invalid(codeRevoked, "certificate %d was revoked on-chain", terms.Nonce)
+	}
+	account, err := s.client.AccountData(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("looking up account data for %v: %w", user, err)
+	}
+	if threshold >= account.LiquidationThreshold {
+		return nil, invalid(codeBadThreshold, "threshold %v >= liquidation threshold %v", threshold,
+			account.LiquidationThreshold)
 	}
 
-	// Verifies the threshold value.
-	thresholdF, err := strconv.ParseFloat(r.Threshold, 64)
+	return s.newRegistration(user, sig, threshold, target, *terms)
+}
+
+// minNonce returns the lowest nonce of the user's certificates that the repayment contract accepts.
+// Users revoke the certificates below it by calling the contract's `revoke`.
+func (s *Service) minNonce(ctx context.Context, user common.Address) (uint64, error) {
+	n, err := s.rep.MinNonce(&bind.CallOpts{Context: ctx}, user)
 	if err != nil {
-		return nil, fmt.Errorf("threshold parse error %v: %w", r, err)
+		return 0, fmt.Errorf("reading on-chain revocations of %v: %w", user, err)
 	}
-	threshold := uint16(thresholdF * 10000)
-	if threshold <= 0 {
-		return nil, fmt.Errorf("threshold too small: %v", r)
+	if !n.IsUint64() {
+		// Every certificate is revoked since nonces are uint64.
+		return math.MaxUint64, nil
 	}
-	loan, err := s.client.Loan(ctx, user)
+	return n.Uint64(), nil
+}
+
+// parseRatio parses a ratio strictly between 0 and 1 in basis points.
+func parseRatio(v string) (uint16, bool) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || !(f > 0 && f < 1) {
+		return 0, false
+	}
+	bp := uint16(f * 10000)
+	return bp, bp > 0
+}
+
+// decodeSignature decodes a hex signature, checking its length before anything indexes it.
+func decodeSignature(v string) ([]byte, error) {
+	sig, err := hexutil.Decode(v)
 	if err != nil {
-		return nil, fmt.Errorf("looking up loan for %v: %w", user, err)
+		return nil, invalid(codeBadSignature, "signature is not hex: %v", err)
 	}
-	if threshold >= loan.LiquidationThreshold {
-		return nil, fmt.Errorf("threshold %v >= liquidation threshold %v", threshold, loan.LiquidationThreshold)
+	if len(sig) != crypto.SignatureLength {
+		return nil, invalid(codeBadSignature, "signature has %d bytes, want %d", len(sig), crypto.SignatureLength)
 	}
+	return sig, nil
+}
 
-	return &registration{
-		user:      user,
-		signature: sig,
-		threshold: int32(threshold),
-	}, nil
+// verifyTerms parses the certificate terms of a registration and checks that the certificate is
+// neither expired, revoked nor older than the user's active certificate.
+func (s *Service) verifyTerms(user common.Address, r *rawRegistration) (*delegation.Terms, error) {
+	terms := &delegation.Terms{MaxCollateral: new(big.Int)}
+	var err error
+	if terms.Deadline, err = strconv.ParseUint(r.Deadline, 10, 64); err != nil {
+		return nil, invalid(codeBadTerms, "deadline %q is not an unsigned integer", r.Deadline)
+	}
+	if terms.Expired(time.Now()) {
+		return nil, invalid(codeExpired, "certificate expired at %d", terms.Deadline)
+	}
+	if terms.Nonce, err = strconv.ParseUint(r.Nonce, 10, 64); err != nil {
+		return nil, invalid(codeBadTerms, "nonce %q is not an unsigned integer", r.Nonce)
+	}
+	if r.MaxCollateral != "" {
+		if _, ok := terms.MaxCollateral.SetString(r.MaxCollateral, 10); !ok || terms.MaxCollateral.Sign() < 0 {
+			return nil, invalid(codeBadTerms, "max-collateral %q is not a non-negative integer", r.MaxCollateral)
+		}
+	}
+	stored, err := s.store.Get(user)
+	if err != nil {
+		return nil, fmt.Errorf("loading registration for %v: %w", user, err)
+	}
+	if stored != nil && stored.RevokedNonce != nil && terms.Nonce <= *stored.RevokedNonce {
+		return nil, invalid(codeRevoked, "certificate %d was revoked", terms.Nonce)
+	}
+	// A replayed older certificate mustn't replace the user's current one.
+	if stored != nil && stored.Status == StatusActive && terms.Nonce < stored.Nonce {
+		return nil, invalid(codeSuperseded, "certificate %d was superseded by certificate %d", terms.Nonce,
+			stored.Nonce)
+	}
+	return terms, nil
+}
+
+// verifyRevocation checks that the revocation is signed by its user and returns the user and the
+// revoked nonce.
+func (s *Service) verifyRevocation(r *rawRevocation) (common.Address, uint64, error) {
+	if !common.IsHexAddress(r.User) {
+		return common.Address{}, 0, invalid(codeBadAddress, "user %q is not a hex address", r.User)
+	}
+	user := common.HexToAddress(r.User)
+	nonce, err := strconv.ParseUint(r.Nonce, 10, 64)
+	if err != nil {
+		return common.Address{}, 0, invalid(codeBadTerms, "nonce %q is not an unsigned integer", r.Nonce)
+	}
+	sig, err := decodeSignature(r.Signature)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	rev, err := delegation.NewRevocation(s.bot, s.domain, nonce)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	signer, err := rev.Verify(sig)
+	if err != nil {
+		return common.Address{}, 0, invalid(codeBadSignature, "verifying signature: %v", err)
+	}
+	if signer != user {
+		return common.Address{}, 0, invalid(codeWrongSigner, "recovered signer %v didn't match user %v",
+			signer.Hex(), user.Hex())
+	}
+	return user, nonce, nil
 }
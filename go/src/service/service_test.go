@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"clients"
+	"delegation"
+	"repayment"
+	"simulated"
+	"swap"
+)
+
+func TestRecordBoundsHistory(t *testing.T) {
+	s := &Service{store: NewMemoryStore()}
+	user := common.HexToAddress("0x01")
+	if err := s.record(user, &Execution{}); err == nil {
+		t.Errorf("record(...) for an unknown user = nil, want error")
+	}
+	if err := s.store.Put(&StoredRegistration{User: user, Signature: []byte{1}, Status: StatusActive}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxHistory+5; i++ {
+		if err := s.record(user, &Execution{Time: int64(i)}); err != nil {
+			t.Fatalf("record(...) = %v, want nil", err)
+		}
+	}
+	stored, err := s.store.Get(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.History) != maxHistory || stored.History[0].Time != 5 {
+		t.Errorf("history has %d entries starting at %d, want %d starting at 5", len(stored.History),
+			stored.History[0].Time, maxHistory)
+	}
+}
+
+func TestRegistrationJSON(t *testing.T) {
+	s := &Service{store: NewMemoryStore()}
+	stored := &StoredRegistration{
+		User:      common.HexToAddress("0x01"),
+		Signature: []byte{1},
+		Threshold: 7000,
+		Target:    6000,
+		Status:    StatusRepaid,
+		History: []*Execution{
+			{Time: 1, Succeeded: false, Error: "reverted", CollateralSold: big.NewInt(1), DebtRepaid: big.NewInt(2)},
+			{Time: 2, Succeeded: true, TxHash: common.HexToHash("0xab"), CollateralSold: big.NewInt(3),
+				DebtRepaid: big.NewInt(4)},
+		},
+	}
+	got := s.registrationJSON(stored)
+	if got["threshold"] != "0.7000" || got["target"] != "0.6000" || got["status"] != StatusRepaid {
+		t.Errorf("registrationJSON(...) = %v, want threshold 0.7000, target 0.6000 and status repaid", got)
+	}
+	if got["protection"] != nil {
+		t.Errorf("protection = %v for an unmonitored user, want nil", got["protection"])
+	}
+	if _, ok := got["last-check"]; ok {
+		t.Errorf("last-check set for an unmonitored user")
+	}
+	history := historyJSON(stored.History)
+	if len(history) != 2 || history[0]["time"] != int64(2) || history[1]["error"] != "reverted" {
+		t.Errorf("historyJSON(...) = %v, want newest first", history)
+	}
+	if _, ok := history[1]["tx-hash"]; ok {
+		t.Errorf("historyJSON(...) has a hash for an execution without one: %v", history[1])
+	}
+}
+
+// triggerTest is a service protecting a user loan of 10 ETH against 10000 Dai worth 5 ETH on a
+// simulated chain, through a mock RepaymentExecutor.
+type triggerTest struct {
+	s        *Service
+	reg      *registration
+	executor *simulated.Mock
+	chain    *simulated.Chain
+}
+
+func newTriggerTest(t *testing.T, threshold, target uint16) *triggerTest {
+	ctx := context.Background()
+	chain := simulated.New(t)
+	ether := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+	if err := chain.Borrow(ctx, ether(10), ether(10000)); err != nil {
+		t.Fatal(err)
+	}
+	c, err := clients.NewClient(chain.Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor, err := chain.DeployMock(ctx, repayment.RepaymentABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := executor.Accepts(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := executor.Returns(ctx, "minNonce", nil, new(big.Int)); err != nil {
+		t.Fatal(err)
+	}
+	rep, err := repayment.NewRepayment(executor.Address, c.ETH())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		client:  c,
+		bot:     c.BotAddress(),
+		repAddr: executor.Address,
+		rep:     rep,
+		domain: delegation.Domain{
+			ChainID:           simulated.ChainID,
+			VerifyingContract: executor.Address,
+			Version:           delegation.DefaultVersion,
+			Salt:              delegation.DefaultSalt,
+		},
+		store:   NewMemoryStore(),
+		quoters: []swap.Quoter{chain.Router},
+		retry:   retryPolicy{maxAttempts: 2, minDelay: time.Millisecond, maxDelay: time.Millisecond},
+	}
+	// The mock executor doesn't check the certificate.
+	sig := make([]byte, crypto.SignatureLength)
+	reg, err := s.newRegistration(chain.User.Address(), sig, threshold, target, delegation.Terms{
+		Deadline:      uint64(time.Now().Add(time.Hour).Unix()),
+		Nonce:         1,
+		MaxCollateral: new(big.Int),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.persist(reg, StatusActive); err != nil {
+		t.Fatal(err)
+	}
+	return &triggerTest{s: s, reg: reg, executor: executor, chain: chain}
+}
+
+// trigger evaluates the loan, which must have reached the threshold, and triggers its repayment.
+func (tt *triggerTest) trigger(t *testing.T) (bool, *StoredRegistration) {
+	ctx := context.Background()
+	loan, triggered, err := tt.s.evaluate(ctx, tt.reg, nil)
+	if err != nil || !triggered {
+		t.Fatalf("evaluate(...) = _, %v, %v, want _, true, nil", triggered, err)
+	}
+	done := tt.s.trigger(ctx, tt.reg, loan)
+	stored, err := tt.s.store.Get(tt.reg.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return done, stored
+}
+
+func TestTriggerRepays(t *testing.T) {
+	tt := newTriggerTest(t, 4000, 0)
+	done, stored := tt.trigger(t)
+	// The mock executor doesn't change any balance, so the debt is left.
+	if done {
+		t.Errorf("trigger(...) = true, want false while debt is left")
+	}
+	if stored.Status != StatusActive {
+		t.Errorf("status = %q, want %q", stored.Status, StatusActive)
+	}
+	if len(stored.History) != 1 || !stored.History[0].Succeeded {
+		t.Errorf("history = %+v, want one successful repayment", stored.History)
+	}
+	if state := tt.reg.prot.status().State; state != StateSucceeded {
+		t.Errorf("state = %q, want %q", state, StateSucceeded)
+	}
+
+	ctx := context.Background()
+	loan, err := tt.s.client.Loan(ctx, tt.reg.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tt.chain.Market.SetDebt(ctx, tt.reg.user, tt.chain.DAI, new(big.Int)); err != nil {
+		t.Fatal(err)
+	}
+	done, err = tt.s.finish(ctx, tt.reg, loan, tt.chain.DAI.Asset.Address)
+	if err != nil || !done {
+		t.Errorf("finish(...) = %v, %v, want true, nil once the debt is repaid", done, err)
+	}
+	if stored, err = tt.s.store.Get(tt.reg.user); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != StatusRepaid {
+		t.Errorf("status = %q, want %q", stored.Status, StatusRepaid)
+	}
+}
+
+func TestTriggerRepaysNextDebt(t *testing.T) {
+	ctx := context.Background()
+	tt := newTriggerTest(t, 4000, 0)
+	usdc, err := tt.chain.Market.AddReserve(ctx, "USD Coin", 6, 8000, 7500, simulated.DAIPrice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The USDC debt alone is worth 4.5 ETH against 10 ETH of collateral.
+	if err := tt.chain.Market.SetDebt(ctx, tt.reg.user, usdc, big.NewInt(9000e6)); err != nil {
+		t.Fatal(err)
+	}
+	loan, err := tt.s.client.Loan(ctx, tt.reg.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Stands in for the repayment of the DAI debt.
+	if err := tt.chain.Market.SetDebt(ctx, tt.reg.user, tt.chain.DAI, new(big.Int)); err != nil {
+		t.Fatal(err)
+	}
+	done, err := tt.s.finish(ctx, tt.reg, loan, tt.chain.DAI.Asset.Address)
+	if err != nil || done {
+		t.Errorf("finish(...) = %v, %v, want false, nil while the USDC debt is left", done, err)
+	}
+	stored, err := tt.s.store.Get(tt.reg.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != StatusActive {
+		t.Errorf("status = %q, want %q", stored.Status, StatusActive)
+	}
+	if len(stored.History) != 1 || stored.History[0].DebtAsset != usdc.Asset.Address {
+		t.Errorf("history = %+v, want one repayment of the USDC debt", stored.History)
+	}
+}
+
+func TestTriggerRepaysPartially(t *testing.T) {
+	tt := newTriggerTest(t, 4000, 3000)
+	done, stored := tt.trigger(t)
+	if done {
+		t.Errorf("trigger(...) = true, want false so monitoring continues")
+	}
+	if stored.Status != StatusActive {
+		t.Errorf("status = %q, want %q", stored.Status, StatusActive)
+	}
+	if len(stored.History) != 1 || !stored.History[0].Succeeded {
+		t.Errorf("history = %+v, want one successful repayment", stored.History)
+	}
+}
+
+// countingQuoter counts the quotes requested from the quoter it wraps.
+type countingQuoter struct {
+	swap.Quoter
+	quotes int
+}
+
+func (q *countingQuoter) Quote(ctx context.Context, req *swap.Request) (*swap.Quote, error) {
+	q.quotes++
+	return q.Quoter.Quote(ctx, req)
+}
+
+func TestTriggerHoldsAboveCollateralLimit(t *testing.T) {
+	tt := newTriggerTest(t, 4000, 0)
+	reg, err := tt.s.newRegistration(tt.reg.user, make([]byte, crypto.SignatureLength), 4000, 0, delegation.Terms{
+		Deadline:      uint64(time.Now().Add(time.Hour).Unix()),
+		Nonce:         2,
+		MaxCollateral: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tt.s.persist(reg, StatusActive); err != nil {
+		t.Fatal(err)
+	}
+	tt.reg = reg
+	quoter := &countingQuoter{Quoter: tt.chain.Router}
+	tt.s.quoters = []swap.Quoter{quoter}
+
+	var quotes int
+	for i := 0; i < 2; i++ {
+		done, stored := tt.trigger(t)
+		if done || stored.Status != StatusActive || len(stored.History) != 0 {
+			t.Errorf("trigger(...) = %t, %+v, want false and no repayment above the limit", done, stored)
+		}
+		if i == 0 {
+			quotes = quoter.quotes
+		}
+	}
+	// The held repayment isn't quoted again for the same loan.
+	if quotes == 0 || quoter.quotes != quotes {
+		t.Errorf("quoted %d times after the first trigger and %d after the second, want the same", quotes,
+			quoter.quotes)
+	}
+	status := tt.reg.prot.status()
+	if status.State != StateHeld || status.LastErr == nil || status.NextRetry.IsZero() {
+		t.Errorf("protection = %+v, want held with the error and the end of the hold", status)
+	}
+	// A loan that recovered is quoted again when it next reaches the threshold.
+	tt.reg.prot.release()
+	tt.trigger(t)
+	if quoter.quotes == quotes {
+		t.Errorf("repayment wasn't quoted again after the loan recovered")
+	}
+}
+
+func TestTriggerStopsOnRevocation(t *testing.T) {
+	tt := newTriggerTest(t, 4000, 0)
+	// The user revoked certificates 0 to 2 through the contract.
+	if err := tt.executor.Returns(context.Background(), "minNonce", nil, big.NewInt(3)); err != nil {
+		t.Fatal(err)
+	}
+	done, stored := tt.trigger(t)
+	if !done {
+		t.Errorf("trigger(...) = false, want true for a revoked certificate")
+	}
+	if stored.Status != StatusRevoked || stored.RevokedNonce == nil || *stored.RevokedNonce != 2 {
+		t.Errorf("stored = %+v, want revoked up to nonce 2", stored)
+	}
+	if len(stored.History) != 0 {
+		t.Errorf("history = %+v, want no repayment", stored.History)
+	}
+}
+
+func TestTriggerGivesUp(t *testing.T) {
+	tt := newTriggerTest(t, 4000, 0)
+	if err := tt.executor.Reverts(context.Background(), "execute", nil, "Invalid delegation"); err != nil {
+		t.Fatal(err)
+	}
+	done, stored := tt.trigger(t)
+	if !done {
+		t.Errorf("trigger(...) = false, want true after running out of attempts")
+	}
+	if stored.Status != StatusFailed {
+		t.Errorf("status = %q, want %q", stored.Status, StatusFailed)
+	}
+	// The revert is caught by the simulation, so no transaction is recorded.
+	if len(stored.History) != 0 {
+		t.Errorf("history = %+v, want none", stored.History)
+	}
+	status := tt.reg.prot.status()
+	if status.State != StateFailed || status.Attempts != 1 || status.LastErr == nil {
+		t.Errorf("protection = %+v, want failed after one retry", status)
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RegistrationStatus describes where a registration is in its lifecycle.
+type RegistrationStatus string
+
+const (
+	// StatusActive registrations are monitored and resumed on startup.
+	StatusActive RegistrationStatus = "active"
+	// StatusRepaid registrations have had their loan repaid by the bot.
+	StatusRepaid RegistrationStatus = "repaid"
+	// StatusRevoked registrations were revoked by the user.
+	StatusRevoked RegistrationStatus = "revoked"
+	// StatusExpired registrations reached the deadline of their delegation certificate.
+	StatusExpired RegistrationStatus = "expired"
+	// StatusFailed registrations ran out of repayment attempts. The user needs to register again.
+	StatusFailed RegistrationStatus = "failed"
+)
+
+// StoredRegistration is the persisted form of a registration.
+type StoredRegistration struct {
+	User      common.Address `json:"user"`
+	Signature hexutil.Bytes  `json:"signature"`
+	// Threshold is the ratio at which to repay in units of 1/10000.
+	Threshold uint16 `json:"threshold"`
+	// Target is the ratio to restore on repayment in units of 1/10000. Zero repays the whole debt.
+	Target uint16 `json:"target,omitempty"`
+	// Deadline, Nonce and MaxCollateral are the terms of the signed delegation certificate.
+	Deadline      uint64   `json:"deadline"`
+	Nonce         uint64   `json:"nonce"`
+	MaxCollateral *big.Int `json:"max-collateral,omitempty"`
+	// RevokedNonce is the highest nonce the user revoked, if any. It outlives the registration it
+	// revoked so that revoked certificates can't be registered again.
+	RevokedNonce *uint64            `json:"revoked-nonce,omitempty"`
+	Status       RegistrationStatus `json:"status"`
+	// History lists the repayments executed for the user, oldest first. Like `RevokedNonce`, it
+	// outlives the registration it belongs to.
+	History []*Execution `json:"history,omitempty"`
+}
+
+// Execution records a repayment transaction.
+type Execution struct {
+	// Time is the Unix time the transaction was mined or failed.
+	Time   int64       `json:"time"`
+	TxHash common.Hash `json:"tx-hash,omitempty"`
+	Block  uint64      `json:"block,omitempty"`
+	// Succeeded is false if the transaction reverted or wasn't mined, in which case `Error` says why.
+	Succeeded       bool           `json:"succeeded"`
+	Error           string         `json:"error,omitempty"`
+	CollateralAsset common.Address `json:"collateral-asset"`
+	CollateralSold  *big.Int       `json:"collateral-sold"`
+	DebtAsset       common.Address `json:"debt-asset"`
+	DebtRepaid      *big.Int       `json:"debt-repaid"`
+	GasUsed         uint64         `json:"gas-used,omitempty"`
+}
+
+// RegistrationStore persists registrations so that monitoring survives restarts.
+type RegistrationStore interface {
+	// Put inserts or replaces the registration for `r.User`.
+	Put(r *StoredRegistration) error
+	// Get returns the registration for `user` or nil if there is none.
+	Get(user common.Address) (*StoredRegistration, error)
+	// List returns all registrations ordered by user address.
+	List() ([]*StoredRegistration, error)
+}
+
+// memoryStore is a RegistrationStore that keeps registrations in memory only.
+type memoryStore struct {
+	mu   sync.Mutex
+	regs map[common.Address]StoredRegistration
+}
+
+// NewMemoryStore returns a RegistrationStore that does not persist anything. Used for testing.
+func NewMemoryStore() RegistrationStore {
+	return &memoryStore{regs: make(map[common.Address]StoredRegistration)}
+}
+
+func (m *memoryStore) Put(r *StoredRegistration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.regs[r.User] = *r
+	return nil
+}
+
+func (m *memoryStore) Get(user common.Address) (*StoredRegistration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.regs[user]
+	if !ok {
+		return nil, nil
+	}
+	return &r, nil
+}
+
+func (m *memoryStore) List() ([]*StoredRegistration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]*StoredRegistration, 0, len(m.regs))
+	for _, r := range m.regs {
+		r := r
+		ret = append(ret, &r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i].User.Bytes(), ret[j].User.Bytes()) < 0
+	})
+	return ret, nil
+}
+
+// fileStore is a RegistrationStore backed by a single JSON file.
+//
+// The whole file is rewritten on every `Put`. Registrations change rarely, so this is simpler than
+// an embedded database and the file stays human readable.
+type fileStore struct {
+	path string
+	// mem holds the current contents of the file.
+	mem *memoryStore
+}
+
+// NewFileStore returns a RegistrationStore persisted to the file at `path`. Existing contents are
+// loaded if the file exists.
+func NewFileStore(path string) (RegistrationStore, error) {
+	s := &fileStore{
+		path: path,
+		mem:  NewMemoryStore().(*memoryStore),
+	}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading registrations from %s: %w", path, err)
+	}
+	var regs []*StoredRegistration
+	if err := json.Unmarshal(content, &regs); err != nil {
+		return nil, fmt.Errorf("decoding registrations from %s: %w", path, err)
+	}
+	for _, r := range regs {
+		s.mem.regs[r.User] = *r
+	}
+	return s, nil
+}
+
+func (s *fileStore) Put(r *StoredRegistration) error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	prev, existed := s.mem.regs[r.User]
+	s.mem.regs[r.User] = *r
+	if err := s.flushLocked(); err != nil {
+		// Keeps memory consistent with what is on disk.
+		if existed {
+			s.mem.regs[r.User] = prev
+		} else {
+			delete(s.mem.regs, r.User)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *fileStore) Get(user common.Address) (*StoredRegistration, error) {
+	return s.mem.Get(user)
+}
+
+func (s *fileStore) List() ([]*StoredRegistration, error) {
+	return s.mem.List()
+}
+
+// flushLocked writes all registrations to disk. The caller must hold `s.mem.mu`.
+func (s *fileStore) flushLocked() error {
+	regs := make([]StoredRegistration, 0, len(s.mem.regs))
+	for _, r := range s.mem.regs {
+		regs = append(regs, r)
+	}
+	sort.Slice(regs, func(i, j int) bool {
+		return bytes.Compare(regs[i].User.Bytes(), regs[j].User.Bytes()) < 0
+	})
+	content, err := json.MarshalIndent(regs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding registrations: %w", err)
+	}
+	// Writes to a temporary file first so a crash can't leave a truncated store behind.
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %s: %w", s.path, err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("closing %s: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("replacing %s: %w", s.path, err)
+	}
+	return nil
+}
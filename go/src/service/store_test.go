@@ -0,0 +1,63 @@
+package service
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFileStoreReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(...) = _, %v, want _, nil", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "registrations.json")
+
+	s, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore(%s) = _, %v, want _, nil", path, err)
+	}
+	want := []*StoredRegistration{
+		{User: common.HexToAddress("0x01"), Signature: []byte{1, 2, 3}, Threshold: 7000, Status: StatusActive},
+		{User: common.HexToAddress("0x02"), Signature: []byte{4, 5, 6}, Threshold: 7500, Status: StatusRepaid,
+			History: []*Execution{{
+				Time:            1700000000,
+				TxHash:          common.HexToHash("0xabcd"),
+				Block:           12,
+				Succeeded:       true,
+				CollateralAsset: common.HexToAddress("0x03"),
+				CollateralSold:  big.NewInt(1000),
+				DebtAsset:       common.HexToAddress("0x04"),
+				DebtRepaid:      big.NewInt(900),
+				GasUsed:         700000,
+			}}},
+	}
+	for _, r := range want {
+		if err := s.Put(r); err != nil {
+			t.Fatalf("Put(%v) = %v, want nil", r, err)
+		}
+	}
+
+	reloaded, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore(%s) = _, %v, want _, nil", path, err)
+	}
+	got, err := reloaded.List()
+	if err != nil {
+		t.Fatalf("List() = _, %v, want _, nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	r, err := reloaded.Get(common.HexToAddress("0x03"))
+	if r != nil || err != nil {
+		t.Errorf("Get(unknown) = %v, %v, want nil, nil", r, err)
+	}
+}
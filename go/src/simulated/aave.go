@@ -0,0 +1,273 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+
+	"aaveoracle"
+	"addressesprovider"
+	"aggregator"
+	"erc20"
+	"lendingpool"
+)
+
+// feedDecimals are the decimals of the mock Chainlink aggregators, which quote in ETH like the
+// mainnet ones.
+const feedDecimals = 18
+
+// Market is a mock AAVE v2 market: a Lending Pool, its addresses provider and price oracle, and
+// reserves with their tokens and Chainlink aggregators. The Lending Pool's views are derived from
+// the balances and prices set through the market, the way AAVE computes them.
+type Market struct {
+	LendingPool *Mock
+	Provider    *Mock
+	Oracle      *Mock
+	// WETH is the reserve of the chain's wrapped ETH, whose price is 1 by definition.
+	WETH     *Reserve
+	Reserves []*Reserve
+
+	node *Node
+	// users maps the users of the market to their balances.
+	users map[common.Address]*position
+}
+
+// Reserve is a mock reserve of the market.
+type Reserve struct {
+	Name     string
+	Decimals uint8
+	// LiquidationThreshold and LTV are in units of 1/10000.
+	LiquidationThreshold, LTV uint16
+	// Price is in wei per whole token.
+	Price *big.Int
+
+	Asset, AToken, StableDebt, VariableDebt *Mock
+	// Feed is the Chainlink aggregator the oracle gets the price from.
+	Feed *Mock
+
+	id int
+	// round is the ID of the latest round of the feed.
+	round int64
+}
+
+// position is the balances of a user in each reserve, indexed by reserve ID.
+type position struct {
+	collateral, debt map[int]*big.Int
+}
+
+// DeployMarket deploys a market with a WETH reserve. Its Lending Pool is the mock at `LendingPool`,
+// so a node has a single market.
+func (n *Node) DeployMarket(ctx context.Context) (*Market, error) {
+	pool, err := n.mock(LendingPool, lendingpool.LendingpoolABI)
+	if err != nil {
+		return nil, err
+	}
+	m := &Market{LendingPool: pool, node: n, users: map[common.Address]*position{}}
+	for _, c := range []struct {
+		mock **Mock
+		abi  string
+	}{
+		{&m.Provider, addressesprovider.AddressesproviderABI},
+		{&m.Oracle, aaveoracle.AaveoracleABI},
+	} {
+		mock, err := n.DeployMock(ctx, c.abi)
+		if err != nil {
+			return nil, err
+		}
+		*c.mock = mock
+	}
+	if err := m.LendingPool.Returns(ctx, "getAddressesProvider", nil, m.Provider.Address); err != nil {
+		return nil, err
+	}
+	if err := m.Provider.Returns(ctx, "getPriceOracle", nil, m.Oracle.Address); err != nil {
+		return nil, err
+	}
+	if err := m.Provider.Returns(ctx, "getLendingPool", nil, m.LendingPool.Address); err != nil {
+		return nil, err
+	}
+	weth, err := m.AddReserve(ctx, "Wrapped Ether", 18, 8250, 8000, big.NewInt(1e18))
+	if err != nil {
+		return nil, err
+	}
+	m.WETH = weth
+	return m, nil
+}
+
+// AddReserve deploys the tokens and the aggregator of a reserve and lists it in the market.
+func (m *Market) AddReserve(ctx context.Context, name string, decimals uint8, threshold, ltv uint16,
+	price *big.Int) (*Reserve, error) {
+	n := m.node
+	r := &Reserve{
+		Name:                 name,
+		Decimals:             decimals,
+		LiquidationThreshold: threshold,
+		LTV:                  ltv,
+		id:                   len(m.Reserves),
+	}
+	for _, mock := range []**Mock{&r.Asset, &r.AToken, &r.StableDebt, &r.VariableDebt} {
+		var err error
+		if *mock, err = n.DeployMock(ctx, erc20.Erc20ABI); err != nil {
+			return nil, err
+		}
+		// Balances are zero until set.
+		if err := (*mock).Returns(ctx, "balanceOf", nil, new(big.Int)); err != nil {
+			return nil, err
+		}
+		if err := (*mock).Returns(ctx, "decimals", nil, decimals); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.Asset.Returns(ctx, "name", nil, name); err != nil {
+		return nil, err
+	}
+	feed, err := n.DeployMock(ctx, aggregator.AggregatorABI)
+	if err != nil {
+		return nil, err
+	}
+	r.Feed = feed
+	if err := r.Feed.Returns(ctx, "decimals", nil, uint8(feedDecimals)); err != nil {
+		return nil, err
+	}
+	if err := m.Oracle.Returns(ctx, "getSourceOfAsset", []interface{}{r.Asset.Address}, r.Feed.Address); err != nil {
+		return nil, err
+	}
+	config := new(big.Int).Lsh(big.NewInt(int64(decimals)), 48)
+	config.Or(config, new(big.Int).Lsh(big.NewInt(int64(threshold)), 16))
+	config.Or(config, big.NewInt(int64(ltv)))
+	if err := m.LendingPool.Returns(ctx, "getReserveData", []interface{}{r.Asset.Address},
+		lendingpool.DataTypesReserveData{
+			Configuration:             lendingpool.DataTypesReserveConfigurationMap{Data: config},
+			LiquidityIndex:            math.BigPow(10, 27),
+			VariableBorrowIndex:       math.BigPow(10, 27),
+			CurrentLiquidityRate:      new(big.Int),
+			CurrentVariableBorrowRate: new(big.Int),
+			CurrentStableBorrowRate:   new(big.Int),
+			LastUpdateTimestamp:       new(big.Int),
+			ATokenAddress:             r.AToken.Address,
+			StableDebtTokenAddress:    r.StableDebt.Address,
+			VariableDebtTokenAddress:  r.VariableDebt.Address,
+			Id:                        uint8(r.id),
+		}); err != nil {
+		return nil, err
+	}
+
+	m.Reserves = append(m.Reserves, r)
+	var assets []common.Address
+	for _, r := range m.Reserves {
+		assets = append(assets, r.Asset.Address)
+	}
+	if err := m.LendingPool.Returns(ctx, "getReservesList", nil, assets); err != nil {
+		return nil, err
+	}
+	if err := m.SetPrice(ctx, r, price); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetPrice sets the price of the reserve in the oracle and as a new round of its aggregator, and
+// updates the accounts of the users.
+func (m *Market) SetPrice(ctx context.Context, r *Reserve, price *big.Int) error {
+	r.Price = price
+	if err := m.Oracle.Returns(ctx, "getAssetPrice", []interface{}{r.Asset.Address}, price); err != nil {
+		return err
+	}
+	// Each price is a new round, which stays available to `getRoundData`.
+	r.round++
+	round, updated := big.NewInt(r.round), big.NewInt(time.Now().Unix())
+	data := []interface{}{round, price, updated, updated, round}
+	if err := r.Feed.Returns(ctx, "latestRoundData", nil, data...); err != nil {
+		return err
+	}
+	if err := r.Feed.Returns(ctx, "getRoundData", []interface{}{round}, data...); err != nil {
+		return err
+	}
+	for user := range m.users {
+		if err := m.update(ctx, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetCollateral sets the collateral the user deposited in the reserve, in units of the asset.
+func (m *Market) SetCollateral(ctx context.Context, user common.Address, r *Reserve, amount *big.Int) error {
+	if err := r.AToken.Returns(ctx, "balanceOf", []interface{}{user}, amount); err != nil {
+		return err
+	}
+	m.position(user).collateral[r.id] = amount
+	return m.update(ctx, user)
+}
+
+// SetDebt sets the variable debt the user owes to the reserve, in units of the asset.
+func (m *Market) SetDebt(ctx context.Context, user common.Address, r *Reserve, amount *big.Int) error {
+	if err := r.VariableDebt.Returns(ctx, "balanceOf", []interface{}{user}, amount); err != nil {
+		return err
+	}
+	m.position(user).debt[r.id] = amount
+	return m.update(ctx, user)
+}
+
+func (m *Market) position(user common.Address) *position {
+	p, ok := m.users[user]
+	if !ok {
+		p = &position{collateral: map[int]*big.Int{}, debt: map[int]*big.Int{}}
+		m.users[user] = p
+	}
+	return p
+}
+
+// update programs the user configuration and account data of the user from their balances.
+func (m *Market) update(ctx context.Context, user common.Address) error {
+	p := m.position(user)
+	config := new(big.Int)
+	collateral, debt, weightedThreshold, weightedLTV := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	for _, r := range m.Reserves {
+		if amount := p.collateral[r.id]; amount != nil && amount.Sign() > 0 {
+			config.SetBit(config, 2*r.id+1, 1)
+			value := r.value(amount)
+			collateral.Add(collateral, value)
+			weightedThreshold.Add(weightedThreshold, new(big.Int).Mul(value, big.NewInt(int64(r.LiquidationThreshold))))
+			weightedLTV.Add(weightedLTV, new(big.Int).Mul(value, big.NewInt(int64(r.LTV))))
+		}
+		if amount := p.debt[r.id]; amount != nil && amount.Sign() > 0 {
+			config.SetBit(config, 2*r.id, 1)
+			debt.Add(debt, r.value(amount))
+		}
+	}
+	threshold, ltv := new(big.Int), new(big.Int)
+	if collateral.Sign() > 0 {
+		threshold.Quo(weightedThreshold, collateral)
+		ltv.Quo(weightedLTV, collateral)
+	}
+	// The health factor has 18 decimals, and is the maximum uint256 without debt.
+	health := new(big.Int).Set(math.MaxBig256)
+	if debt.Sign() > 0 {
+		health.Mul(weightedThreshold, big.NewInt(1e18))
+		health.Quo(health, new(big.Int).Mul(debt, big.NewInt(10000)))
+	}
+	available := new(big.Int).Quo(new(big.Int).Mul(collateral, ltv), big.NewInt(10000))
+	if available.Sub(available, debt).Sign() < 0 {
+		available.SetInt64(0)
+	}
+
+	if err := m.LendingPool.Returns(ctx, "getUserConfiguration", []interface{}{user},
+		lendingpool.DataTypesUserConfigurationMap{Data: config}); err != nil {
+		return fmt.Errorf("setting configuration of %v: %w", user, err)
+	}
+	if err := m.LendingPool.Returns(ctx, "getUserAccountData", []interface{}{user}, collateral, debt, available,
+		threshold, ltv, health); err != nil {
+		return fmt.Errorf("setting account data of %v: %w", user, err)
+	}
+	return nil
+}
+
+// value returns the value in wei of `amount` of the reserve asset.
+func (r *Reserve) value(amount *big.Int) *big.Int {
+	value := new(big.Int).Mul(amount, r.Price)
+	return value.Quo(value, math.BigPow(10, int64(r.Decimals)))
+}
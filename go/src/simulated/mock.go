@@ -0,0 +1,286 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mockRuntime is the code of a contract answering calls with programmed responses, in the
+// assembly of go-ethereum's `core/asm`. There's no Solidity source since the bot is built without
+// solc.
+//
+// A call of the selector 0xffffffff followed by a key, a revert flag and the response data programs
+// the response for the calls whose key matches. The key of a call is the hash of its whole data, then
+// the hash of its selector, then the hash of no data, so responses can be programmed for exact
+// calls, for any call of a method or for any call at all. The response is stored as its length
+// plus one, with the revert flag in bit 128, at the key, and its words at the slots that follow.
+// Calls without a response revert without data.
+const mockRuntime = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	PUSH 0xffffffff
+	EQ
+	JUMPI @program
+
+	;; Looks the response up by the hash of the data, the selector, then nothing.
+	CALLDATASIZE
+	PUSH 0
+	PUSH 0
+	CALLDATACOPY
+	CALLDATASIZE
+	PUSH 0
+	SHA3
+	DUP1
+	SLOAD
+	DUP1
+	JUMPI @respond
+	POP
+	POP
+	PUSH 4
+	PUSH 0
+	SHA3
+	DUP1
+	SLOAD
+	DUP1
+	JUMPI @respond
+	POP
+	POP
+	PUSH 0
+	PUSH 0
+	SHA3
+	DUP1
+	SLOAD
+	DUP1
+	JUMPI @respond
+	PUSH 0
+	PUSH 0
+	REVERT
+
+	;; [value, key]: copies the words of the response to memory and returns or reverts with them.
+respond:
+	DUP1
+	PUSH 0xffffffffffffffffffffffffffffffff
+	AND
+	PUSH 1
+	SWAP1
+	SUB
+	PUSH 0
+copy:
+	DUP2
+	DUP2
+	LT
+	ISZERO
+	JUMPI @copied
+	DUP4
+	PUSH 1
+	ADD
+	PUSH 32
+	DUP3
+	DIV
+	ADD
+	SLOAD
+	DUP2
+	MSTORE
+	PUSH 32
+	ADD
+	JUMP @copy
+copied:
+	POP
+	SWAP1
+	PUSH 128
+	SHR
+	JUMPI @revert
+	PUSH 0
+	RETURN
+revert:
+	PUSH 0
+	REVERT
+
+	;; Stores the response after the selector, the key and the revert flag.
+program:
+	PUSH 4
+	CALLDATALOAD
+	PUSH 68
+	CALLDATASIZE
+	SUB
+	PUSH 36
+	CALLDATALOAD
+	PUSH 128
+	SHL
+	DUP2
+	PUSH 1
+	ADD
+	OR
+	DUP3
+	SSTORE
+	PUSH 0
+store:
+	DUP2
+	DUP2
+	LT
+	ISZERO
+	JUMPI @stored
+	PUSH 68
+	DUP2
+	ADD
+	CALLDATALOAD
+	DUP4
+	PUSH 1
+	ADD
+	PUSH 32
+	DUP4
+	DIV
+	ADD
+	SSTORE
+	PUSH 32
+	ADD
+	JUMP @store
+stored:
+	STOP
+`
+
+// mockCode is the runtime code of the mock contract.
+var mockCode = assemble("mock", mockRuntime)
+
+// assemble assembles the runtime code of a contract.
+func assemble(name, runtimeAsm string) []byte {
+	c := asm.NewCompiler(false)
+	c.Feed(asm.Lex([]byte(runtimeAsm), false))
+	bin, errs := c.Compile()
+	if len(errs) > 0 {
+		panic(fmt.Sprintf("compiling the %s contract: %v", name, errs))
+	}
+	return common.FromHex(bin)
+}
+
+// compile assembles the runtime code of a contract and returns its creation code.
+func compile(name, runtimeAsm string) []byte {
+	return creation(assemble(name, runtimeAsm))
+}
+
+// creation returns the creation code of a contract with the given runtime code.
+func creation(runtime []byte) []byte {
+	// PUSH2 len DUP1 PUSH1 12 PUSH1 0 CODECOPY PUSH1 0 RETURN, followed by the runtime code.
+	return append([]byte{
+		0x61, byte(len(runtime) >> 8), byte(len(runtime)),
+		0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3,
+	}, runtime...)
+}
+
+// Mock is a deployed contract answering calls with programmed responses. It stands in for any
+// contract, given the ABI of the real one.
+type Mock struct {
+	Address common.Address
+	abi     abi.ABI
+	node    *Node
+}
+
+// DeployMock deploys a mock answering calls to the contract with the given JSON ABI.
+func (n *Node) DeployMock(ctx context.Context, abiJSON string) (*Mock, error) {
+	addr, err := n.deploy(ctx, creation(mockCode))
+	if err != nil {
+		return nil, fmt.Errorf("deploying mock: %w", err)
+	}
+	return n.mock(addr, abiJSON)
+}
+
+// mock returns the mock at `addr`, which is either deployed or in the genesis, answering calls to
+// the contract with the given JSON ABI.
+func (n *Node) mock(addr common.Address, abiJSON string) (*Mock, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("parsing mock ABI: %w", err)
+	}
+	return &Mock{Address: addr, abi: parsed, node: n}, nil
+}
+
+// Returns makes calls of `method` with `args` return `results`. Without `args`, it applies to the
+// calls of `method` whose arguments have no response of their own.
+func (m *Mock) Returns(ctx context.Context, method string, args []interface{}, results ...interface{}) error {
+	data, err := m.ReturnsCall(method, args, results...)
+	if err != nil {
+		return err
+	}
+	_, err = m.node.transact(ctx, &m.Address, big.NewInt(0), data)
+	return err
+}
+
+// ReturnsCall returns the data of a call programming the mock like `Returns`, so that a contract
+// can program it, such as a swap crediting a mock token.
+func (m *Mock) ReturnsCall(method string, args []interface{}, results ...interface{}) ([]byte, error) {
+	key, err := m.key(method, args)
+	if err != nil {
+		return nil, err
+	}
+	output, err := m.abi.Methods[method].Outputs.Pack(results...)
+	if err != nil {
+		return nil, fmt.Errorf("packing results of %s: %w", method, err)
+	}
+	return programData(key, false, output), nil
+}
+
+// Reverts makes calls of `method` with `args` revert with `reason`. Without `args`, it applies to
+// the calls of `method` whose arguments have no response of their own.
+func (m *Mock) Reverts(ctx context.Context, method string, args []interface{}, reason string) error {
+	key, err := m.key(method, args)
+	if err != nil {
+		return err
+	}
+	return m.program(ctx, key, true, RevertData(reason))
+}
+
+// Accepts makes calls without a response of their own succeed without output, such as the calls
+// of a swap router.
+func (m *Mock) Accepts(ctx context.Context) error {
+	return m.program(ctx, crypto.Keccak256Hash(), false, nil)
+}
+
+// key returns the key of the calls of `method` with `args`, or of any call of `method` if `args` is
+// nil.
+func (m *Mock) key(method string, args []interface{}) (common.Hash, error) {
+	abiMethod, ok := m.abi.Methods[method]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("mock has no method %s", method)
+	}
+	if args == nil {
+		return crypto.Keccak256Hash(abiMethod.ID), nil
+	}
+	input, err := m.abi.Pack(method, args...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("packing arguments of %s: %w", method, err)
+	}
+	return crypto.Keccak256Hash(input), nil
+}
+
+// program stores the response for `key`.
+func (m *Mock) program(ctx context.Context, key common.Hash, revert bool, output []byte) error {
+	_, err := m.node.transact(ctx, &m.Address, big.NewInt(0), programData(key, revert, output))
+	return err
+}
+
+// programData returns the data of a call storing the response for `key`.
+func programData(key common.Hash, revert bool, output []byte) []byte {
+	flag := common.Hash{}
+	if revert {
+		flag[31] = 1
+	}
+	data := append([]byte{0xff, 0xff, 0xff, 0xff}, key.Bytes()...)
+	data = append(data, flag.Bytes()...)
+	return append(data, output...)
+}
+
+// RevertData returns the data of a revert with `reason`, as Solidity's `require` produces it.
+func RevertData(reason string) []byte {
+	str, _ := abi.NewType("string", "", nil)
+	data, _ := abi.Arguments{{Type: str}}.Pack(reason)
+	return append(crypto.Keccak256([]byte("Error(string)"))[:4], data...)
+}
@@ -0,0 +1,190 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// multicallRuntime is the code of a contract implementing `tryBlockAndAggregate` of Multicall2 and
+// Multicall3, in the assembly of go-ethereum's `core/asm`. Other methods revert.
+//
+// The results are built in memory as they're returned: the block number and hash, the offset and
+// length of the results array, the offsets of the results, then each result's success flag, data
+// offset, data length and padded data. The data of each call is copied to where its result goes
+// before making the call.
+const multicallRuntime = `
+	PUSH 0
+	CALLDATALOAD
+	PUSH 224
+	SHR
+	PUSH 0x399542e9
+	EQ
+	JUMPI @aggregate
+	PUSH 0
+	PUSH 0
+	REVERT
+
+aggregate:
+	NUMBER
+	PUSH 0
+	MSTORE
+	NUMBER
+	BLOCKHASH
+	PUSH 32
+	MSTORE
+	PUSH 96
+	PUSH 64
+	MSTORE
+	;; The calls array is at 4 plus its offset, and starts with its length.
+	PUSH 36
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	CALLDATALOAD
+	DUP1
+	PUSH 96
+	MSTORE
+	PUSH 5
+	SHL
+	PUSH 128
+	ADD
+	PUSH 0
+
+	;; [i, p]: makes call i and writes its result at p.
+loop:
+	PUSH 36
+	CALLDATALOAD
+	PUSH 4
+	ADD
+	CALLDATALOAD
+	DUP2
+	LT
+	ISZERO
+	JUMPI @done
+	PUSH 128
+	DUP3
+	SUB
+	DUP2
+	PUSH 5
+	SHL
+	PUSH 128
+	ADD
+	MSTORE
+	;; [t, i, p]: the call tuple is at the start of the array's elements plus its offset.
+	PUSH 36
+	CALLDATALOAD
+	PUSH 36
+	ADD
+	DUP1
+	DUP3
+	PUSH 5
+	SHL
+	ADD
+	CALLDATALOAD
+	ADD
+	;; [len, b, t, i, p]: the call data is at the tuple plus its offset, and starts with its length.
+	DUP1
+	PUSH 32
+	ADD
+	CALLDATALOAD
+	DUP2
+	ADD
+	DUP1
+	CALLDATALOAD
+	DUP1
+	DUP3
+	PUSH 32
+	ADD
+	DUP7
+	CALLDATACOPY
+	;; [success, len, b, t, i, p]
+	PUSH 0
+	PUSH 0
+	DUP3
+	DUP8
+	PUSH 0
+	DUP8
+	CALLDATALOAD
+	GAS
+	CALL
+	DUP1
+	ISZERO
+	PUSH 4
+	CALLDATALOAD
+	AND
+	JUMPI @fail
+	DUP6
+	MSTORE
+	POP
+	POP
+	POP
+	;; [i, p]: writes the data offset, length, zero padding and data of the result.
+	PUSH 64
+	DUP3
+	PUSH 32
+	ADD
+	MSTORE
+	RETURNDATASIZE
+	DUP3
+	PUSH 64
+	ADD
+	MSTORE
+	PUSH 0
+	RETURNDATASIZE
+	DUP4
+	PUSH 96
+	ADD
+	ADD
+	MSTORE
+	RETURNDATASIZE
+	PUSH 0
+	DUP4
+	PUSH 96
+	ADD
+	RETURNDATACOPY
+	RETURNDATASIZE
+	PUSH 31
+	ADD
+	PUSH 5
+	SHR
+	PUSH 5
+	SHL
+	PUSH 96
+	ADD
+	DUP3
+	ADD
+	SWAP2
+	POP
+	PUSH 1
+	ADD
+	JUMP @loop
+
+done:
+	POP
+	PUSH 0
+	RETURN
+
+	;; Forwards the revert of a call when all calls must succeed.
+fail:
+	RETURNDATASIZE
+	PUSH 0
+	PUSH 0
+	RETURNDATACOPY
+	RETURNDATASIZE
+	PUSH 0
+	REVERT
+`
+
+// multicallCode is the creation code of the Multicall contract.
+var multicallCode = compile("multicall", multicallRuntime)
+
+// DeployMulticall deploys a Multicall contract for the bot to batch its calls through.
+func (n *Node) DeployMulticall(ctx context.Context) (common.Address, error) {
+	addr, err := n.deploy(ctx, multicallCode)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("deploying multicall: %w", err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,365 @@
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"env"
+)
+
+const (
+	// gasLimit is the gas limit of simulated blocks.
+	gasLimit = 30000000
+	// tip is the priority fee of the transactions sent by the node's own account.
+	tip = 1e9
+)
+
+var (
+	// ChainID is the chain ID of simulated chains.
+	ChainID = big.NewInt(1337)
+	// Funds is the balance of the accounts funded at genesis.
+	Funds = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	// LendingPool is the address of the Lending Pool of the local test network, where the
+	// RepaymentExecutor contract calls it. Nodes have a mock there from genesis.
+	LendingPool = env.LocalTestNet().LendingPoolAddress()
+)
+
+// Node is a simulated chain served over JSON-RPC, so the bot talks to it the way it talks to a real
+// node. Transactions are mined as soon as they're sent, like a hardhat node does.
+type Node struct {
+	sim    *backends.SimulatedBackend
+	server *rpc.Server
+	http   *httptest.Server
+	// key is the account deploying and programming mocks.
+	key *ecdsa.PrivateKey
+	// mu serializes sending transactions with mining them.
+	mu sync.Mutex
+}
+
+// NewNode starts a node whose genesis funds `accounts` with `Funds` and has a mock at
+// `LendingPool`.
+func NewNode(accounts ...common.Address) (*Node, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	alloc := core.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: Funds},
+		LendingPool:                           {Code: mockCode, Balance: new(big.Int)},
+	}
+	for _, a := range accounts {
+		alloc[a] = core.GenesisAccount{Balance: Funds}
+	}
+	n := &Node{
+		sim:    backends.NewSimulatedBackend(alloc, gasLimit),
+		server: rpc.NewServer(),
+		key:    key,
+	}
+	if err := n.server.RegisterName("eth", &ethAPI{n}); err != nil {
+		n.sim.Close()
+		return nil, fmt.Errorf("registering eth API: %w", err)
+	}
+	ws := n.server.WebsocketHandler([]string{"*"})
+	n.http = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		n.server.ServeHTTP(w, r)
+	}))
+	return n, nil
+}
+
+// URL returns the websocket URL of the node.
+func (n *Node) URL() string {
+	return "ws" + strings.TrimPrefix(n.http.URL, "http")
+}
+
+// Close stops the node.
+func (n *Node) Close() {
+	n.http.Close()
+	n.server.Stop()
+	n.sim.Close()
+}
+
+// Backend gives direct access to the chain of a node, without JSON-RPC. Unlike go-ethereum's
+// simulated backend it embeds, it mines transactions as soon as they're sent.
+type Backend struct {
+	*backends.SimulatedBackend
+	n *Node
+}
+
+// Backend returns direct access to the chain of the node, for `clients.NewClientWithBackend`.
+func (n *Node) Backend() *Backend {
+	return &Backend{SimulatedBackend: n.sim, n: n}
+}
+
+// SendTransaction sends a transaction and mines it.
+func (b *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return b.n.send(ctx, tx)
+}
+
+// BlockNumber returns the number of the latest block.
+func (b *Backend) BlockNumber(ctx context.Context) (uint64, error) {
+	head, err := b.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return head.Number.Uint64(), nil
+}
+
+// send sends a transaction and mines it.
+func (n *Node) send(ctx context.Context, tx *types.Transaction) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err := n.sim.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	n.sim.Commit()
+	return nil
+}
+
+// transact sends a transaction from the node's account, or creates a contract if `to` is nil, and
+// returns its receipt.
+func (n *Node) transact(ctx context.Context, to *common.Address, value *big.Int, data []byte) (*types.Receipt,
+	error) {
+	from := crypto.PubkeyToAddress(n.key.PublicKey)
+	nonce, err := n.sim.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	head, err := n.sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := n.sim.EstimateGas(ctx, ethereum.CallMsg{From: from, To: to, Value: value, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("estimating gas: %w", err)
+	}
+	tx, err := types.SignNewTx(n.key, types.LatestSignerForChainID(ChainID), &types.DynamicFeeTx{
+		ChainID:   ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), big.NewInt(tip)),
+		Gas:       gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := n.send(ctx, tx); err != nil {
+		return nil, err
+	}
+	receipt, err := n.sim.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %v reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// deploy creates a contract with the creation code `code` and returns its address.
+func (n *Node) deploy(ctx context.Context, code []byte) (common.Address, error) {
+	receipt, err := n.transact(ctx, nil, big.NewInt(0), code)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return receipt.ContractAddress, nil
+}
+
+// ethAPI serves the eth namespace methods the bot uses from the simulated backend.
+type ethAPI struct {
+	n *Node
+}
+
+// callArgs are the arguments of eth_call and eth_estimateGas.
+type callArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+	Input    hexutil.Bytes   `json:"input"`
+}
+
+func (a *callArgs) msg() ethereum.CallMsg {
+	data := a.Data
+	if a.Input != nil {
+		data = a.Input
+	}
+	return ethereum.CallMsg{
+		From:     a.From,
+		To:       a.To,
+		Gas:      uint64(a.Gas),
+		GasPrice: (*big.Int)(a.GasPrice),
+		Value:    (*big.Int)(a.Value),
+		Data:     data,
+	}
+}
+
+// number returns the block number of a block parameter, or nil for the latest or pending block.
+func number(n rpc.BlockNumber) *big.Int {
+	if n < 0 {
+		return nil
+	}
+	return big.NewInt(n.Int64())
+}
+
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(ChainID)
+}
+
+func (api *ethAPI) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	number, err := api.n.Backend().BlockNumber(ctx)
+	return hexutil.Uint64(number), err
+}
+
+// GetBlockByNumber returns the header of the block, which is all the bot reads of blocks.
+func (api *ethAPI) GetBlockByNumber(ctx context.Context, n rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	if fullTx {
+		return nil, errors.New("full transactions aren't supported")
+	}
+	return api.n.sim.HeaderByNumber(ctx, number(n))
+}
+
+func (api *ethAPI) GetBalance(ctx context.Context, account common.Address, n rpc.BlockNumber) (*hexutil.Big,
+	error) {
+	balance, err := api.n.sim.BalanceAt(ctx, account, number(n))
+	return (*hexutil.Big)(balance), err
+}
+
+func (api *ethAPI) GetCode(ctx context.Context, account common.Address, n rpc.BlockNumber) (hexutil.Bytes, error) {
+	if n == rpc.PendingBlockNumber {
+		return api.n.sim.PendingCodeAt(ctx, account)
+	}
+	return api.n.sim.CodeAt(ctx, account, number(n))
+}
+
+func (api *ethAPI) GetTransactionCount(ctx context.Context, account common.Address, n rpc.BlockNumber) (
+	hexutil.Uint64, error) {
+	var nonce uint64
+	var err error
+	if n == rpc.PendingBlockNumber {
+		nonce, err = api.n.sim.PendingNonceAt(ctx, account)
+	} else {
+		nonce, err = api.n.sim.NonceAt(ctx, account, number(n))
+	}
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *ethAPI) Call(ctx context.Context, args callArgs, n rpc.BlockNumber) (hexutil.Bytes, error) {
+	return api.n.sim.CallContract(ctx, args.msg(), number(n))
+}
+
+func (api *ethAPI) EstimateGas(ctx context.Context, args callArgs) (hexutil.Uint64, error) {
+	gas, err := api.n.sim.EstimateGas(ctx, args.msg())
+	return hexutil.Uint64(gas), err
+}
+
+func (api *ethAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	price, err := api.n.sim.SuggestGasPrice(ctx)
+	return (*hexutil.Big)(price), err
+}
+
+func (api *ethAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tip, err := api.n.sim.SuggestGasTipCap(ctx)
+	return (*hexutil.Big)(tip), err
+}
+
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), api.n.send(ctx, tx)
+}
+
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return api.n.sim.TransactionReceipt(ctx, hash)
+}
+
+func (api *ethAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]types.Log, error) {
+	logs, err := api.n.sim.FilterLogs(ctx, ethereum.FilterQuery(crit))
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, err
+}
+
+// NewHeads serves eth_subscribe("newHeads").
+func (api *ethAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	heads := make(chan *types.Header)
+	sub, err := api.n.sim.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case head := <-heads:
+				notifier.Notify(rpcSub.ID, head)
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// Logs serves eth_subscribe("logs").
+func (api *ethAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	logs := make(chan types.Log)
+	sub, err := api.n.sim.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery(crit), logs)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case l := <-logs:
+				notifier.Notify(rpcSub.ID, &l)
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
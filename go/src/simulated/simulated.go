@@ -0,0 +1,135 @@
+// Package simulated runs the bot against go-ethereum's simulated backend instead of a hardhat node
+// forking mainnet, so clients, repayment and service logic can be tested offline with `go test`.
+//
+// A `Node` serves the simulated chain over JSON-RPC, mining transactions as they're sent, and its
+// `Backend` gives clients direct access to the chain for tests that don't need RPC. Contracts
+// are stood in for by `Mock`s answering calls with programmed responses: a `Market` is a mock AAVE
+// market whose Lending Pool views follow the balances and prices set through it, and a `Router` is
+// a mock DEX aggregator. The Lending Pool is at its mainnet address, so the RepaymentExecutor
+// contract runs from its real bytecode against it. A Multicall contract batches calls like the one
+// deployed on mainnet.
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"env"
+	"wallets"
+)
+
+// Chain is a node with a mock market, a DAI reserve, a Multicall contract and funded bot and user
+// accounts.
+type Chain struct {
+	*Node
+	Market    *Market
+	DAI       *Reserve
+	Router    *Router
+	Multicall common.Address
+	// Params point the bot at the chain. The bot and the user have the keys of the local test
+	// network.
+	Params    env.Params
+	Bot, User *wallets.Wallet
+}
+
+// DAIPrice is the initial price of DAI in wei.
+var DAIPrice = big.NewInt(5e14)
+
+// New starts a chain, which is closed at the end of the test.
+func New(t testing.TB) *Chain {
+	t.Helper()
+	ctx := context.Background()
+	local := env.LocalTestNet()
+	bot, err := wallets.NewWallet(local.BotKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := wallets.NewWallet(local.UserKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := NewNode(bot.Address(), user.Address())
+	if err != nil {
+		t.Fatalf("starting simulated node: %v", err)
+	}
+	t.Cleanup(node.Close)
+	market, err := node.DeployMarket(ctx)
+	if err != nil {
+		t.Fatalf("deploying market: %v", err)
+	}
+	dai, err := market.AddReserve(ctx, "Dai Stablecoin", 18, 8000, 7500, DAIPrice)
+	if err != nil {
+		t.Fatalf("adding DAI reserve: %v", err)
+	}
+	router, err := market.DeployRouter(ctx)
+	if err != nil {
+		t.Fatalf("deploying router: %v", err)
+	}
+	multicall, err := node.DeployMulticall(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Chain{
+		Node:      node,
+		Market:    market,
+		DAI:       dai,
+		Router:    router,
+		Multicall: multicall,
+		Params: &params{
+			Params:    local,
+			url:       node.URL(),
+			multicall: multicall,
+			weth:      market.WETH.Asset.Address,
+			dai:       dai.Asset.Address,
+		},
+		Bot:  bot,
+		User: user,
+	}
+}
+
+// Borrow gives the user a loan of `debt` DAI against `collateral` WETH, both in wei.
+func (c *Chain) Borrow(ctx context.Context, collateral, debt *big.Int) error {
+	if err := c.Market.SetCollateral(ctx, c.User.Address(), c.Market.WETH, collateral); err != nil {
+		return err
+	}
+	return c.Market.SetDebt(ctx, c.User.Address(), c.DAI, debt)
+}
+
+// params are the parameters of the local test network pointed at a simulated chain.
+type params struct {
+	env.Params
+	url                  string
+	multicall, weth, dai common.Address
+}
+
+func (p *params) ETHURI() string {
+	return p.url
+}
+
+func (p *params) ETHURIs() []string {
+	return []string{p.url}
+}
+
+func (p *params) ChainID() *big.Int {
+	return ChainID
+}
+
+func (p *params) MulticallAddress() common.Address {
+	return p.multicall
+}
+
+func (p *params) WETH9Address() common.Address {
+	return p.weth
+}
+
+func (p *params) DaiAddress() common.Address {
+	return p.dai
+}
+
+// GasStrategy uses the node's suggestions since the simulated backend has no fee history.
+func (p *params) GasStrategy() string {
+	return env.GasSuggested
+}
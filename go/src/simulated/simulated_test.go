@@ -0,0 +1,197 @@
+package simulated
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"erc20"
+	"lendingpool"
+	"multicall"
+)
+
+func TestMock(t *testing.T) {
+	ctx := context.Background()
+	node, err := NewNode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.Close()
+	eth, err := ethclient.Dial(node.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eth.Close()
+	mock, err := node.DeployMock(ctx, erc20.Erc20ABI)
+	if err != nil {
+		t.Fatalf("DeployMock(...) = %v", err)
+	}
+	token, err := erc20.NewErc20(mock.Address, eth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	holder, other := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+
+	if _, err := token.BalanceOf(opts, holder); err == nil {
+		t.Errorf("BalanceOf(...) without a response = nil error, want error")
+	}
+	if err := mock.Returns(ctx, "balanceOf", nil, big.NewInt(1)); err != nil {
+		t.Fatal(err)
+	}
+	// Responses longer than a word are stored over several slots.
+	name := "a name longer than thirty-two bytes"
+	if err := mock.Returns(ctx, "name", nil, name); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.Returns(ctx, "balanceOf", []interface{}{holder}, big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := token.BalanceOf(opts, holder); err != nil || got.Int64() != 5 {
+		t.Errorf("BalanceOf(holder) = %v, %v, want 5, nil", got, err)
+	}
+	if got, err := token.BalanceOf(opts, other); err != nil || got.Int64() != 1 {
+		t.Errorf("BalanceOf(other) = %v, %v, want the default of 1, nil", got, err)
+	}
+	if got, err := token.Name(opts); err != nil || got != name {
+		t.Errorf("Name() = %q, %v, want %q, nil", got, err, name)
+	}
+
+	if err := mock.Reverts(ctx, "symbol", nil, "no symbol"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = token.Symbol(opts)
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) || dataErr.ErrorData() != "0x"+common.Bytes2Hex(RevertData("no symbol")) {
+		t.Errorf("Symbol() = %v, want a revert with the reason", err)
+	}
+
+	if err := mock.Accepts(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := token.TotalSupply(opts); err == nil {
+		t.Errorf("TotalSupply() of an accepting mock = nil error, want an error decoding no output")
+	}
+}
+
+func TestMarket(t *testing.T) {
+	ctx := context.Background()
+	c := New(t)
+	eth, err := ethclient.Dial(c.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eth.Close()
+	lp, err := lendingpool.NewLendingpool(c.Market.LendingPool.Address, eth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	user := c.User.Address()
+	// 10 ETH of collateral for 10000 DAI worth 5 ETH.
+	tenETH := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	if err := c.Borrow(ctx, tenETH, new(big.Int).Mul(big.NewInt(10000), big.NewInt(1e18))); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := lp.GetUserConfiguration(opts, user)
+	if err != nil || config.Data.Int64() != 0x6 {
+		t.Errorf("GetUserConfiguration(...) = %v, %v, want 0x6 (WETH collateral, DAI debt)", config.Data, err)
+	}
+	data, err := lp.GetUserAccountData(opts, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.TotalCollateralETH.Cmp(tenETH) != 0 || data.TotalDebtETH.Cmp(big.NewInt(5e18)) != 0 ||
+		data.CurrentLiquidationThreshold.Int64() != 8250 {
+		t.Errorf("GetUserAccountData(...) = %+v, want 10 ETH of collateral, 5 ETH of debt, threshold 8250", data)
+	}
+	// Debt doubling in value halves the health factor from 1.65.
+	if err := c.Market.SetPrice(ctx, c.DAI, big.NewInt(1e15)); err != nil {
+		t.Fatal(err)
+	}
+	data, err = lp.GetUserAccountData(opts, user)
+	if err != nil || data.HealthFactor.Cmp(big.NewInt(825e15)) != 0 {
+		t.Errorf("health factor = %v, %v, want 0.825", data.HealthFactor, err)
+	}
+}
+
+func TestMulticall(t *testing.T) {
+	ctx := context.Background()
+	chain := New(t)
+	if err := chain.Market.SetCollateral(ctx, chain.User.Address(), chain.Market.WETH, Funds); err != nil {
+		t.Fatal(err)
+	}
+	// The name takes more than a word, so the results after it must be padded right.
+	name := "a name longer than thirty-two bytes"
+	if err := chain.DAI.Asset.Returns(ctx, "name", nil, name); err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.DAI.Asset.Reverts(ctx, "symbol", nil, "no symbol"); err != nil {
+		t.Fatal(err)
+	}
+	token, err := abi.JSON(strings.NewReader(erc20.Erc20ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := token.Pack(method, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	calls := []multicall.Multicall2Call{
+		{Target: chain.DAI.Asset.Address, CallData: pack("name")},
+		{Target: chain.Market.WETH.AToken.Address, CallData: pack("balanceOf", chain.User.Address())},
+		{Target: chain.DAI.Asset.Address, CallData: pack("symbol")},
+		{Target: chain.DAI.Asset.Address, CallData: pack("totalSupply")},
+	}
+	caller, err := multicall.NewMulticallCaller(chain.Multicall, chain.Backend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc := &multicall.MulticallCallerRaw{Contract: caller}
+	var out []interface{}
+	if err := mc.Call(&bind.CallOpts{Context: ctx}, &out, "tryBlockAndAggregate", false, calls); err != nil {
+		t.Fatalf("tryBlockAndAggregate(false, ...) = %v", err)
+	}
+	head, err := chain.Backend().BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out[0].(*big.Int).Uint64(); got != head {
+		t.Errorf("block number = %d, want the head %d", got, head)
+	}
+	results := *abi.ConvertType(out[2], new([]multicall.Multicall2Result)).(*[]multicall.Multicall2Result)
+	if len(results) != len(calls) {
+		t.Fatalf("got %d results, want %d", len(results), len(calls))
+	}
+	if got, err := token.Unpack("name", results[0].ReturnData); !results[0].Success || err != nil ||
+		got[0] != name {
+		t.Errorf("name result = %v, %v, want %q", results[0].Success, got, name)
+	}
+	if got := new(big.Int).SetBytes(results[1].ReturnData); !results[1].Success || got.Cmp(Funds) != 0 {
+		t.Errorf("balanceOf result = %v, %v, want %v", results[1].Success, got, Funds)
+	}
+	if reason, err := abi.UnpackRevert(results[2].ReturnData); results[2].Success || err != nil ||
+		reason != "no symbol" {
+		t.Errorf("symbol result = %v, %q, want a revert with the reason", results[2].Success, reason)
+	}
+	if results[3].Success || len(results[3].ReturnData) != 0 {
+		t.Errorf("totalSupply result = %v, %x, want a revert without data", results[3].Success,
+			results[3].ReturnData)
+	}
+
+	if err := mc.Call(&bind.CallOpts{Context: ctx}, &out, "tryBlockAndAggregate", true, calls); err == nil {
+		t.Errorf("tryBlockAndAggregate(true, ...) with failing calls = nil, want a revert")
+	}
+}
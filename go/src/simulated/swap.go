@@ -0,0 +1,78 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"swap"
+)
+
+// Router is a mock DEX aggregator. Its quotes follow the market prices and its router contract
+// accepts any call, so repayments can be executed without a real DEX.
+type Router struct {
+	Contract *Mock
+	market   *Market
+	// Impact is the fraction of value its quotes lose compared to market prices.
+	Impact float64
+}
+
+// DeployRouter deploys the router contract of a mock aggregator quoting from the market's prices.
+func (m *Market) DeployRouter(ctx context.Context) (*Router, error) {
+	contract, err := m.node.DeployMock(ctx, "[]")
+	if err != nil {
+		return nil, err
+	}
+	if err := contract.Accepts(ctx); err != nil {
+		return nil, err
+	}
+	return &Router{Contract: contract, market: m}, nil
+}
+
+func (r *Router) Name() string {
+	return "simulated"
+}
+
+func (r *Router) Router() common.Address {
+	return r.Contract.Address
+}
+
+func (r *Router) Spender() common.Address {
+	return r.Contract.Address
+}
+
+// Quote converts the amount at the market prices of the tokens, less `Impact`.
+func (r *Router) Quote(ctx context.Context, req *swap.Request) (*swap.Quote, error) {
+	from, to := r.market.reserve(req.From), r.market.reserve(req.To)
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("no market for %v to %v", req.From.Hex(), req.To.Hex())
+	}
+	out := new(big.Rat).SetFrac(from.value(req.Amount), big.NewInt(1))
+	out.Mul(out, new(big.Rat).SetFrac(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to.Decimals)), nil), to.Price))
+	out.Mul(out, new(big.Rat).SetFloat64(1-r.Impact))
+	return &swap.Quote{ToAmount: new(big.Int).Quo(out.Num(), out.Denom())}, nil
+}
+
+// Build returns calldata the router contract accepts.
+func (r *Router) Build(ctx context.Context, req *swap.Request, q *swap.Quote) (*swap.Tx, error) {
+	return &swap.Tx{
+		Backend:   r.Name(),
+		Router:    r.Router(),
+		Spender:   r.Spender(),
+		Calldata:  append(req.From.Bytes(), req.To.Bytes()...),
+		ToAmount:  q.ToAmount,
+		MinAmount: swap.MinAmount(q.ToAmount),
+	}, nil
+}
+
+// reserve returns the reserve of `asset`, or nil if the market has none.
+func (m *Market) reserve(asset common.Address) *Reserve {
+	for _, r := range m.Reserves {
+		if r.Asset.Address == asset {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package swap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"backoff"
+)
+
+const (
+	userAgent = "AAVE Liquidation Protection Bot"
+	// maxDrain bounds how much of a discarded response body is read so its connection can be reused.
+	maxDrain = 64 << 10
+)
+
+// Defaults of `HTTPClient`.
+const (
+	DefaultMaxAttempts = 5
+	DefaultMinBackoff  = 250 * time.Millisecond
+	DefaultMaxBackoff  = 5 * time.Second
+	// DefaultBudget bounds the time spent on a request including retries if the context has no
+	// earlier deadline.
+	DefaultBudget  = 30 * time.Second
+	requestTimeout = 10 * time.Second
+)
+
+// HTTPClient performs JSON requests to aggregator APIs, retrying rate limiting and server errors
+// with exponential backoff.
+type HTTPClient struct {
+	Client *http.Client
+	// MaxAttempts bounds the number of requests, including the first.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry. It doubles with each retry up to MaxBackoff.
+	// Waits are jittered and servers may ask for longer ones with Retry-After, up to the budget.
+	MinBackoff, MaxBackoff time.Duration
+	// Budget bounds the time spent on a request including retries.
+	Budget time.Duration
+
+	attempts, retries, failures uint64
+}
+
+// HTTPStats counts the requests of an `HTTPClient`.
+type HTTPStats struct {
+	// Attempts counts every request sent.
+	Attempts uint64
+	// Retries counts the attempts that were retried.
+	Retries uint64
+	// Failures counts the calls that gave up with an error.
+	Failures uint64
+}
+
+// NewHTTPClient returns a client with the default retry policy.
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{
+		Client:      &http.Client{Timeout: requestTimeout},
+		MaxAttempts: DefaultMaxAttempts,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		Budget:      DefaultBudget,
+	}
+}
+
+// Stats returns the request counts so far.
+func (h *HTTPClient) Stats() HTTPStats {
+	return HTTPStats{
+		Attempts: atomic.LoadUint64(&h.attempts),
+		Retries:  atomic.LoadUint64(&h.retries),
+		Failures: atomic.LoadUint64(&h.failures),
+	}
+}
+
+// retryable reports whether a response status is worth retrying.
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// DoJSON performs a request with an optional JSON body and decodes the JSON response into `out`.
+// Rate limiting, server errors and transport errors are retried until the attempts or the budget
+// run out, or `ctx` is done.
+func (h *HTTPClient) DoJSON(ctx context.Context, method, url string, header http.Header, body,
+	out interface{}) error {
+	content, err := h.do(ctx, method, url, header, body)
+	if err != nil {
+		atomic.AddUint64(&h.failures, 1)
+		return err
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		atomic.AddUint64(&h.failures, 1)
+		return fmt.Errorf("decoding response %s: %w", content, err)
+	}
+	return nil
+}
+
+func (h *HTTPClient) do(ctx context.Context, method, url string, header http.Header,
+	body interface{}) ([]byte, error) {
+	var encoded []byte
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+	}
+	if h.Budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Budget)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		atomic.AddUint64(&h.attempts, 1)
+		content, wait, err := h.attempt(ctx, method, url, header, encoded)
+		if err == nil {
+			return content, nil
+		}
+		if wait < 0 {
+			return nil, err
+		}
+		if attempt >= h.MaxAttempts {
+			return nil, fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		if backoff := h.backoff(attempt); wait < backoff {
+			wait = backoff
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, fmt.Errorf("no time left to retry in %v: %w", wait, err)
+		}
+		log.Printf("Retrying %s %s in %v: %v", method, url, wait, err)
+		atomic.AddUint64(&h.retries, 1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%v; retry cancelled: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt performs a single request and returns the response content. On failure, it returns
+// the minimum wait before retrying, which is negative if the error isn't retryable.
+func (h *HTTPClient) attempt(ctx context.Context, method, url string, header http.Header,
+	encoded []byte) ([]byte, time.Duration, error) {
+	var reader io.Reader
+	if encoded != nil {
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, -1, fmt.Errorf("preparing request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if encoded != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+
+	res, err := h.Client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, -1, fmt.Errorf("performing request: %w", err)
+		}
+		return nil, 0, fmt.Errorf("performing request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		content, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("reading response: %w", err)
+		}
+		return content, 0, nil
+	}
+	content, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxDrain))
+	err = fmt.Errorf("request failed with %s: %s", res.Status, content)
+	if !retryable(res.StatusCode) {
+		return nil, -1, err
+	}
+	return nil, retryAfter(res.Header.Get("Retry-After"), time.Now()), err
+}
+
+// backoff returns the jittered wait before retrying after `attempt` attempts.
+func (h *HTTPClient) backoff(attempt int) time.Duration {
+	return backoff.Wait(h.MinBackoff, h.MaxBackoff, attempt)
+}
+
+// retryAfter parses a Retry-After header, which holds either seconds or an HTTP date. It returns 0
+// if the header is missing or invalid.
+func retryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+package swap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first `failures` requests with `status` and then serves `{"ok": true}`.
+func flakyServer(t *testing.T, failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			http.Error(w, "try again", status)
+			return
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	t.Cleanup(s.Close)
+	return s, &requests
+}
+
+func testHTTPClient() *HTTPClient {
+	h := NewHTTPClient()
+	h.MinBackoff = time.Millisecond
+	h.MaxBackoff = 4 * time.Millisecond
+	return h
+}
+
+func TestHTTPClientRetries(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		s, requests := flakyServer(t, 2, status, "")
+		h := testHTTPClient()
+		var out struct{ OK bool }
+		if err := h.DoJSON(context.Background(), http.MethodGet, s.URL, nil, nil, &out); err != nil || !out.OK {
+			t.Errorf("DoJSON after %d = %v with %+v, want nil with OK", status, err, out)
+		}
+		if got := atomic.LoadInt32(requests); got != 3 {
+			t.Errorf("requests after %d = %d, want 3", status, got)
+		}
+		if got, want := h.Stats(), (HTTPStats{Attempts: 3, Retries: 2}); got != want {
+			t.Errorf("Stats() after %d = %+v, want %+v", status, got, want)
+		}
+	}
+}
+
+func TestHTTPClientGivesUp(t *testing.T) {
+	ctx := context.Background()
+	var out struct{ OK bool }
+
+	// Client errors aren't retried.
+	s, requests := flakyServer(t, 1, http.StatusBadRequest, "")
+	h := testHTTPClient()
+	if err := h.DoJSON(ctx, http.MethodGet, s.URL, nil, nil, &out); err == nil {
+		t.Errorf("DoJSON after 400 = nil, want error")
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("requests after 400 = %d, want 1", got)
+	}
+
+	// Attempts are bounded.
+	s, requests = flakyServer(t, 100, http.StatusInternalServerError, "")
+	h = testHTTPClient()
+	h.MaxAttempts = 3
+	if err := h.DoJSON(ctx, http.MethodGet, s.URL, nil, nil, &out); err == nil {
+		t.Errorf("DoJSON with failing server = nil, want error")
+	}
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("requests with failing server = %d, want 3", got)
+	}
+	if got, want := h.Stats(), (HTTPStats{Attempts: 3, Retries: 2, Failures: 1}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// A Retry-After beyond the budget fails right away instead of waiting.
+	s, requests = flakyServer(t, 1, http.StatusTooManyRequests, "60")
+	h = testHTTPClient()
+	h.Budget = time.Second
+	start := time.Now()
+	if err := h.DoJSON(ctx, http.MethodGet, s.URL, nil, nil, &out); err == nil {
+		t.Errorf("DoJSON with Retry-After beyond the budget = nil, want error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("DoJSON with Retry-After beyond the budget took %v", elapsed)
+	}
+
+	// Cancellation stops retries.
+	s, _ = flakyServer(t, 100, http.StatusBadGateway, "")
+	h = testHTTPClient()
+	h.MinBackoff, h.MaxBackoff = time.Second, time.Second
+	h.MaxAttempts = 100
+	cctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	if err := h.DoJSON(cctx, http.MethodGet, s.URL, nil, nil, &out); err == nil {
+		t.Errorf("DoJSON with cancelled context = nil, want error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("DoJSON with cancelled context took %v", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 2, 8, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"Mon, 08 Feb 2021 12:00:10 GMT", 10 * time.Second},
+		{"Mon, 08 Feb 2021 11:00:00 GMT", 0},
+		{"soon", 0},
+	} {
+		if got := retryAfter(tc.header, now); got != tc.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	h := &HTTPClient{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for _, tc := range []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			if got := h.backoff(tc.attempt); got < tc.min || got > tc.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", tc.attempt, got, tc.min, tc.max)
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package swap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// OneInchVersion selects a version of the 1inch API and its router.
+type OneInchVersion int
+
+// Supported 1inch API versions.
+const (
+	OneInchV4 OneInchVersion = 4
+	OneInchV5 OneInchVersion = 5
+)
+
+var oneInchRouters = map[OneInchVersion]common.Address{
+	OneInchV4: common.HexToAddress("0x1111111254fb6c44bAC0beD2854e76F90643097d"),
+	OneInchV5: common.HexToAddress("0x1111111254EEB25477B68fb85Ed929f73A960582"),
+}
+
+// OneInch quotes swaps with the 1inch API. Its router is also the spender.
+type OneInch struct {
+	// BaseURL is the API root including the version and chain, such as
+	// "https://api.1inch.io/v5.0/1".
+	BaseURL string
+	HTTP    *HTTPClient
+	version OneInchVersion
+}
+
+// NewOneInch returns a quoter for the given API version on the given chain.
+func NewOneInch(version OneInchVersion, chainID *big.Int) *OneInch {
+	return &OneInch{
+		BaseURL: fmt.Sprintf("https://api.1inch.io/v%d.0/%v", version, chainID),
+		HTTP:    NewHTTPClient(),
+		version: version,
+	}
+}
+
+func (o *OneInch) Name() string {
+	return fmt.Sprintf("1inch v%d", o.version)
+}
+
+func (o *OneInch) Router() common.Address {
+	return oneInchRouters[o.version]
+}
+
+func (o *OneInch) Spender() common.Address {
+	return o.Router()
+}
+
+type oneInchToken struct {
+	Symbol   string         `json:"symbol"`
+	Address  common.Address `json:"address"`
+	Decimals uint8          `json:"decimals"`
+}
+
+type oneInchQuote struct {
+	FromToken       oneInchToken `json:"fromToken"`
+	ToToken         oneInchToken `json:"toToken"`
+	FromTokenAmount string       `json:"fromTokenAmount"`
+	ToTokenAmount   string       `json:"toTokenAmount"`
+	EstimatedGas    uint64       `json:"estimatedGas"`
+}
+
+type oneInchTx struct {
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Data     hexutil.Bytes  `json:"data"`
+	Value    string         `json:"value"`
+	Gas      uint64         `json:"gas"`
+	GasPrice string         `json:"gasPrice"`
+}
+
+type oneInchSwap struct {
+	oneInchQuote
+	Tx oneInchTx `json:"tx"`
+}
+
+// check verifies that the response is for `req` and returns the bought amount.
+func (q *oneInchQuote) check(req *Request) (*big.Int, error) {
+	if q.FromToken.Address != req.From || q.ToToken.Address != req.To {
+		return nil, fmt.Errorf("response swaps %v to %v, want %v to %v", q.FromToken.Address.Hex(),
+			q.ToToken.Address.Hex(), req.From.Hex(), req.To.Hex())
+	}
+	if q.FromTokenAmount != req.Amount.String() {
+		return nil, fmt.Errorf("response sells %s, want %v", q.FromTokenAmount, req.Amount)
+	}
+	return parseAmount(q.ToTokenAmount)
+}
+
+func (o *OneInch) params(req *Request) url.Values {
+	return url.Values{
+		"fromTokenAddress": {req.From.Hex()},
+		"toTokenAddress":   {req.To.Hex()},
+		"amount":           {req.Amount.String()},
+	}
+}
+
+func (o *OneInch) Quote(ctx context.Context, req *Request) (*Quote, error) {
+	var res oneInchQuote
+	if err := o.HTTP.DoJSON(ctx, http.MethodGet, o.BaseURL+"/quote?"+o.params(req).Encode(), nil, nil,
+		&res); err != nil {
+		return nil, fmt.Errorf("1inch quote: %w", err)
+	}
+	amount, err := res.check(req)
+	if err != nil {
+		return nil, fmt.Errorf("1inch quote: %w", err)
+	}
+	return &Quote{ToAmount: amount, Gas: res.EstimatedGas}, nil
+}
+
+func (o *OneInch) Build(ctx context.Context, req *Request, q *Quote) (*Tx, error) {
+	params := o.params(req)
+	params.Set("fromAddress", req.Taker.Hex())
+	params.Set("slippage", fmt.Sprint(SlippagePercent))
+	// The taker only holds the tokens once the flash loan executes, so 1inch can't estimate gas.
+	params.Set("disableEstimate", "true")
+
+	var res oneInchSwap
+	if err := o.HTTP.DoJSON(ctx, http.MethodGet, o.BaseURL+"/swap?"+params.Encode(), nil, nil,
+		&res); err != nil {
+		return nil, fmt.Errorf("1inch swap: %w", err)
+	}
+	amount, err := res.check(req)
+	if err != nil {
+		return nil, fmt.Errorf("1inch swap: %w", err)
+	}
+	if res.Tx.From != req.Taker {
+		return nil, fmt.Errorf("1inch swap: transaction from %v, want %v", res.Tx.From.Hex(), req.Taker.Hex())
+	}
+	if value, ok := new(big.Int).SetString(res.Tx.Value, 10); !ok || value.Sign() != 0 {
+		return nil, fmt.Errorf("1inch swap: transaction sends value %q", res.Tx.Value)
+	}
+	gas := res.Tx.Gas
+	if gas == 0 {
+		gas = q.Gas
+	}
+	return &Tx{
+		Backend:   o.Name(),
+		Router:    res.Tx.To,
+		Spender:   o.Spender(),
+		Calldata:  res.Tx.Data,
+		ToAmount:  amount,
+		MinAmount: MinAmount(amount),
+		Gas:       gas,
+	}, nil
+}
+
+// parseAmount parses a decimal token amount.
+func parseAmount(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	return amount, nil
+}
@@ -0,0 +1,134 @@
+package swap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// paraswapAugustus executes Paraswap swaps.
+	paraswapAugustus = common.HexToAddress("0xDEF171Fe48CF0115B1d80b88dc8eAB59176FEe57")
+	// paraswapProxy is approved for the sold tokens and transfers them to Augustus.
+	paraswapProxy = common.HexToAddress("0x216B4B4Ba9F3e719726886d34a177484278Bfcae")
+)
+
+// Paraswap quotes swaps with the Paraswap API.
+type Paraswap struct {
+	// BaseURL is the API root, such as "https://apiv5.paraswap.io".
+	BaseURL string
+	HTTP    *HTTPClient
+	chainID *big.Int
+}
+
+// NewParaswap returns a quoter for the given chain.
+func NewParaswap(chainID *big.Int) *Paraswap {
+	return &Paraswap{
+		BaseURL: "https://apiv5.paraswap.io",
+		HTTP:    NewHTTPClient(),
+		chainID: new(big.Int).Set(chainID),
+	}
+}
+
+func (p *Paraswap) Name() string {
+	return "Paraswap"
+}
+
+func (p *Paraswap) Router() common.Address {
+	return paraswapAugustus
+}
+
+func (p *Paraswap) Spender() common.Address {
+	return paraswapProxy
+}
+
+type paraswapPrices struct {
+	// PriceRoute is passed back unmodified to build the transaction.
+	PriceRoute json.RawMessage `json:"priceRoute"`
+}
+
+type paraswapRoute struct {
+	DestAmount string `json:"destAmount"`
+	GasCost    string `json:"gasCost"`
+}
+
+type paraswapTx struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+func (p *Paraswap) Quote(ctx context.Context, req *Request) (*Quote, error) {
+	params := url.Values{
+		"srcToken":     {req.From.Hex()},
+		"destToken":    {req.To.Hex()},
+		"srcDecimals":  {fmt.Sprint(req.FromDecimals)},
+		"destDecimals": {fmt.Sprint(req.ToDecimals)},
+		"amount":       {req.Amount.String()},
+		"side":         {"SELL"},
+		"network":      {p.chainID.String()},
+	}
+	var res paraswapPrices
+	if err := p.HTTP.DoJSON(ctx, http.MethodGet, p.BaseURL+"/prices?"+params.Encode(), nil, nil,
+		&res); err != nil {
+		return nil, fmt.Errorf("Paraswap prices: %w", err)
+	}
+	var route paraswapRoute
+	if err := json.Unmarshal(res.PriceRoute, &route); err != nil {
+		return nil, fmt.Errorf("Paraswap prices: decoding route: %w", err)
+	}
+	amount, err := parseAmount(route.DestAmount)
+	if err != nil {
+		return nil, fmt.Errorf("Paraswap prices: %w", err)
+	}
+	var gas uint64
+	if route.GasCost != "" {
+		if gas, err = strconv.ParseUint(route.GasCost, 10, 64); err != nil {
+			return nil, fmt.Errorf("Paraswap prices: invalid gas cost %q", route.GasCost)
+		}
+	}
+	return &Quote{ToAmount: amount, Gas: gas, route: res.PriceRoute}, nil
+}
+
+func (p *Paraswap) Build(ctx context.Context, req *Request, q *Quote) (*Tx, error) {
+	route, ok := q.route.(json.RawMessage)
+	if !ok {
+		return nil, fmt.Errorf("Paraswap transaction: quote has no price route")
+	}
+	// The minimum output is sent instead of the slippage so the swap is known to return it.
+	min := MinAmount(q.ToAmount)
+	body := struct {
+		SrcToken     common.Address  `json:"srcToken"`
+		DestToken    common.Address  `json:"destToken"`
+		SrcAmount    string          `json:"srcAmount"`
+		DestAmount   string          `json:"destAmount"`
+		SrcDecimals  uint8           `json:"srcDecimals"`
+		DestDecimals uint8           `json:"destDecimals"`
+		UserAddress  common.Address  `json:"userAddress"`
+		PriceRoute   json.RawMessage `json:"priceRoute"`
+	}{
+		req.From, req.To, req.Amount.String(), min.String(), req.FromDecimals, req.ToDecimals, req.Taker, route,
+	}
+	// The taker only holds the tokens once the flash loan executes, so Paraswap can't check
+	// balances or estimate gas.
+	u := fmt.Sprintf("%s/transactions/%v?ignoreChecks=true&ignoreGasEstimate=true", p.BaseURL, p.chainID)
+	var res paraswapTx
+	if err := p.HTTP.DoJSON(ctx, http.MethodPost, u, nil, body, &res); err != nil {
+		return nil, fmt.Errorf("Paraswap transaction: %w", err)
+	}
+	return &Tx{
+		Backend:   p.Name(),
+		Router:    res.To,
+		Spender:   p.Spender(),
+		Calldata:  res.Data,
+		ToAmount:  new(big.Int).Set(q.ToAmount),
+		MinAmount: min,
+		Gas:       q.Gas,
+	}, nil
+}
@@ -0,0 +1,166 @@
+// Package swap obtains swap calldata from DEX aggregators.
+//
+// Each aggregator is a `Quoter`. Quotes are requested from all of them and the swap is built with
+// the one offering the best output net of gas costs.
+package swap
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SlippagePercent is the maximum slippage accepted for swaps.
+const SlippagePercent = 1
+
+// MinAmount returns the least output accepted by a swap expected to return `amount`, given
+// `SlippagePercent`.
+func MinAmount(amount *big.Int) *big.Int {
+	min := new(big.Int).Mul(amount, big.NewInt(100-SlippagePercent))
+	return min.Quo(min, big.NewInt(100))
+}
+
+// Request describes a swap of `Amount` units of `From` into `To`.
+type Request struct {
+	From, To common.Address
+	// FromDecimals and ToDecimals are the decimals of the tokens. Some aggregators need them.
+	FromDecimals, ToDecimals uint8
+	Amount                   *big.Int
+	// Taker is the address that performs the swap and holds the tokens.
+	Taker common.Address
+}
+
+// Quote is the expected outcome of a swap.
+type Quote struct {
+	// ToAmount is the expected amount of `To` tokens received.
+	ToAmount *big.Int
+	// Gas is the estimated gas of the swap, or 0 if unknown.
+	Gas uint64
+	// route is backend specific data needed to build the swap.
+	route interface{}
+}
+
+// Tx is a swap ready to be executed.
+type Tx struct {
+	// Backend is the name of the Quoter that built the swap.
+	Backend string
+	// Router is the contract to call with `Calldata`.
+	Router common.Address
+	// Spender is the contract to approve for the sold tokens.
+	Spender  common.Address
+	Calldata []byte
+	// ToAmount is the expected amount of `To` tokens received.
+	ToAmount *big.Int
+	// MinAmount is the least amount of `To` tokens received, below which the swap reverts.
+	MinAmount *big.Int
+	// Gas is the estimated gas of the swap, or 0 if unknown.
+	Gas uint64
+}
+
+// Quoter is a DEX aggregator.
+type Quoter interface {
+	// Name identifies the aggregator in logs.
+	Name() string
+	// Router returns the contract executing the aggregator's swaps. Calldata for any other contract
+	// is rejected.
+	Router() common.Address
+	// Spender returns the contract the seller needs to approve. Swaps approving any other contract
+	// are rejected.
+	Spender() common.Address
+	// Quote returns the expected outcome of the swap.
+	Quote(ctx context.Context, req *Request) (*Quote, error)
+	// Build returns calldata for the swap described by a quote of the same request.
+	Build(ctx context.Context, req *Request, q *Quote) (*Tx, error)
+}
+
+// Defaults returns the supported aggregators for the given chain.
+func Defaults(chainID *big.Int) []Quoter {
+	return []Quoter{
+		NewOneInch(OneInchV5, chainID),
+		NewZeroEx(chainID),
+		NewParaswap(chainID),
+	}
+}
+
+// GasCost converts an amount of gas into units of the bought token so it can be subtracted from a
+// quote.
+type GasCost func(gas uint64) *big.Int
+
+type ranked struct {
+	quoter Quoter
+	quote  *Quote
+	net    *big.Int
+}
+
+// Best requests quotes from all `quoters` and builds the swap with the one offering the highest
+// output net of `gasCost`, which may be nil to ignore gas. If building fails, the next best quote
+// is tried.
+func Best(ctx context.Context, quoters []Quoter, req *Request, gasCost GasCost) (*Tx, error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []ranked
+		errs    []string
+	)
+	for _, q := range quoters {
+		wg.Add(1)
+		go func(q Quoter) {
+			defer wg.Done()
+			quote, err := q.Quote(ctx, req)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", q.Name(), err))
+				return
+			}
+			results = append(results, ranked{q, quote, netOutput(quote, gasCost)})
+		}(q)
+	}
+	wg.Wait()
+	rank(results)
+
+	for _, r := range results {
+		tx, err := r.quoter.Build(ctx, req, r.quote)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.quoter.Name(), err))
+			continue
+		}
+		if tx.Router != r.quoter.Router() {
+			errs = append(errs, fmt.Sprintf("%s: swap targets %v instead of router %v", r.quoter.Name(),
+				tx.Router, r.quoter.Router()))
+			continue
+		}
+		if tx.Spender != r.quoter.Spender() {
+			errs = append(errs, fmt.Sprintf("%s: swap approves %v instead of spender %v", r.quoter.Name(),
+				tx.Spender, r.quoter.Spender()))
+			continue
+		}
+		log.Printf("Swapping %v %v for %v %v through %s", req.Amount, req.From.Hex(), tx.ToAmount, req.To.Hex(),
+			tx.Backend)
+		return tx, nil
+	}
+	return nil, fmt.Errorf("no swap for %v %v to %v: %s", req.Amount, req.From.Hex(), req.To.Hex(),
+		strings.Join(errs, "; "))
+}
+
+// netOutput returns the quoted output minus the gas cost.
+func netOutput(q *Quote, gasCost GasCost) *big.Int {
+	net := new(big.Int).Set(q.ToAmount)
+	if gasCost != nil && q.Gas > 0 {
+		net.Sub(net, gasCost(q.Gas))
+	}
+	return net
+}
+
+// rank sorts the results by decreasing net output.
+func rank(results []ranked) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].net.Cmp(results[j].net) > 0
+	})
+}
@@ -0,0 +1,288 @@
+package swap
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	weth  = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	dai   = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	taker = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+)
+
+// fixtureServer serves the recorded responses in testdata by request path and records the requests.
+type fixtureServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string]*http.Request
+	bodies   map[string][]byte
+}
+
+func newFixtureServer(t *testing.T, fixtures map[string]string) *fixtureServer {
+	s := &fixtureServer{requests: map[string]*http.Request{}, bodies: map[string][]byte{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests[r.URL.Path] = r
+		s.bodies[r.URL.Path] = body
+		s.mu.Unlock()
+
+		name, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		content, err := ioutil.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Errorf("reading fixture: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fixtureServer) query(t *testing.T, path, key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.requests[path]
+	if !ok {
+		t.Fatalf("no request to %s", path)
+	}
+	return r.URL.Query().Get(key)
+}
+
+// testQuoters returns a quoter of each kind backed by fixtures.
+func testQuoters(t *testing.T) (*OneInch, *ZeroEx, *Paraswap) {
+	chainID := big.NewInt(1)
+	o := NewOneInch(OneInchV5, chainID)
+	o.BaseURL = newFixtureServer(t, map[string]string{
+		"/quote": "oneinch_quote.json",
+		"/swap":  "oneinch_swap.json",
+	}).URL
+	z := NewZeroEx(chainID)
+	z.BaseURL = newFixtureServer(t, map[string]string{
+		"/swap/v1/price": "zeroex_price.json",
+		"/swap/v1/quote": "zeroex_quote.json",
+	}).URL
+	p := NewParaswap(chainID)
+	p.BaseURL = newFixtureServer(t, map[string]string{
+		"/prices":         "paraswap_prices.json",
+		"/transactions/1": "paraswap_transactions.json",
+	}).URL
+	return o, z, p
+}
+
+func testRequest() *Request {
+	return &Request{
+		From:         weth,
+		To:           dai,
+		FromDecimals: 18,
+		ToDecimals:   18,
+		Amount:       big.NewInt(1e18),
+		Taker:        taker,
+	}
+}
+
+func daiAmount(dai int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(dai), big.NewInt(1e18))
+}
+
+func TestBackends(t *testing.T) {
+	o, z, p := testQuoters(t)
+	ctx := context.Background()
+	req := testRequest()
+	for _, tc := range []struct {
+		quoter    Quoter
+		wantQuote *big.Int
+		wantGas   uint64
+		wantSwap  *big.Int
+	}{
+		{o, daiAmount(3000), 200000, daiAmount(30001).Div(daiAmount(30001), big.NewInt(10))},
+		{z, daiAmount(3005), 400000, daiAmount(3005)},
+		{p, daiAmount(3002), 150000, daiAmount(3002)},
+	} {
+		q, err := tc.quoter.Quote(ctx, req)
+		if err != nil {
+			t.Errorf("%s: Quote: %v", tc.quoter.Name(), err)
+			continue
+		}
+		if q.ToAmount.Cmp(tc.wantQuote) != 0 || q.Gas != tc.wantGas {
+			t.Errorf("%s: Quote = %v with %d gas, want %v with %d gas", tc.quoter.Name(), q.ToAmount, q.Gas,
+				tc.wantQuote, tc.wantGas)
+		}
+		tx, err := tc.quoter.Build(ctx, req, q)
+		if err != nil {
+			t.Errorf("%s: Build: %v", tc.quoter.Name(), err)
+			continue
+		}
+		if tx.Router != tc.quoter.Router() || tx.Spender != tc.quoter.Spender() {
+			t.Errorf("%s: Build targets %v approving %v, want %v approving %v", tc.quoter.Name(), tx.Router,
+				tx.Spender, tc.quoter.Router(), tc.quoter.Spender())
+		}
+		if len(tx.Calldata) == 0 {
+			t.Errorf("%s: Build returned no calldata", tc.quoter.Name())
+		}
+		if tx.ToAmount.Cmp(tc.wantSwap) != 0 {
+			t.Errorf("%s: Build expects %v, want %v", tc.quoter.Name(), tx.ToAmount, tc.wantSwap)
+		}
+		if want := MinAmount(tc.wantSwap); tx.MinAmount.Cmp(want) != 0 {
+			t.Errorf("%s: Build accepts %v, want %v", tc.quoter.Name(), tx.MinAmount, want)
+		}
+	}
+}
+
+func TestOneInchRejectsMismatchedResponse(t *testing.T) {
+	o, _, _ := testQuoters(t)
+	ctx := context.Background()
+
+	req := testRequest()
+	req.Amount = big.NewInt(2e18)
+	if _, err := o.Quote(ctx, req); err == nil {
+		t.Errorf("Quote accepted a response for a different amount")
+	}
+	req = testRequest()
+	req.Taker = weth
+	if _, err := o.Build(ctx, req, &Quote{ToAmount: daiAmount(3000)}); err == nil {
+		t.Errorf("Build accepted a transaction from a different taker")
+	}
+	req = testRequest()
+	req.From, req.To = req.To, req.From
+	if _, err := o.Build(ctx, req, &Quote{ToAmount: daiAmount(3000)}); err == nil {
+		t.Errorf("Build accepted a response for different tokens")
+	}
+}
+
+func TestBackendRequests(t *testing.T) {
+	ctx := context.Background()
+	req := testRequest()
+	chainID := big.NewInt(1)
+
+	oServer := newFixtureServer(t, map[string]string{"/swap": "oneinch_swap.json"})
+	o := NewOneInch(OneInchV5, chainID)
+	o.BaseURL = oServer.URL
+	if _, err := o.Build(ctx, req, &Quote{ToAmount: daiAmount(3000)}); err != nil {
+		t.Fatalf("1inch Build: %v", err)
+	}
+	for key, want := range map[string]string{
+		"fromTokenAddress": weth.Hex(),
+		"toTokenAddress":   dai.Hex(),
+		"amount":           "1000000000000000000",
+		"fromAddress":      taker.Hex(),
+		"slippage":         "1",
+	} {
+		if got := oServer.query(t, "/swap", key); got != want {
+			t.Errorf("1inch %s = %q, want %q", key, got, want)
+		}
+	}
+
+	zServer := newFixtureServer(t, map[string]string{"/swap/v1/quote": "zeroex_quote.json"})
+	z := NewZeroEx(chainID)
+	z.BaseURL = zServer.URL
+	z.APIKey = "key"
+	if _, err := z.Build(ctx, req, &Quote{ToAmount: daiAmount(3000)}); err != nil {
+		t.Fatalf("0x Build: %v", err)
+	}
+	if got := zServer.query(t, "/swap/v1/quote", "slippagePercentage"); got != "0.01" {
+		t.Errorf("0x slippagePercentage = %q, want 0.01", got)
+	}
+	if got := zServer.requests["/swap/v1/quote"].Header.Get("0x-api-key"); got != "key" {
+		t.Errorf("0x-api-key = %q, want key", got)
+	}
+
+	pServer := newFixtureServer(t, map[string]string{
+		"/prices":         "paraswap_prices.json",
+		"/transactions/1": "paraswap_transactions.json",
+	})
+	p := NewParaswap(chainID)
+	p.BaseURL = pServer.URL
+	q, err := p.Quote(ctx, req)
+	if err != nil {
+		t.Fatalf("Paraswap Quote: %v", err)
+	}
+	if _, err := p.Build(ctx, req, q); err != nil {
+		t.Fatalf("Paraswap Build: %v", err)
+	}
+	var body struct {
+		DestAmount  string
+		UserAddress common.Address
+		PriceRoute  struct{ Hmac string }
+	}
+	if err := json.Unmarshal(pServer.bodies["/transactions/1"], &body); err != nil {
+		t.Fatalf("decoding Paraswap transaction request: %v", err)
+	}
+	// The request sets the minimum output the swap reports.
+	if body.DestAmount != MinAmount(q.ToAmount).String() || body.UserAddress != taker || body.PriceRoute.Hmac == "" {
+		t.Errorf("Paraswap transaction request = %+v, want a minimum of %v for %v with the quoted route",
+			body, MinAmount(q.ToAmount), taker)
+	}
+}
+
+func TestBest(t *testing.T) {
+	o, z, p := testQuoters(t)
+	ctx := context.Background()
+	quoters := []Quoter{o, z, p}
+
+	// Without gas costs, 0x quotes the most DAI.
+	tx, err := Best(ctx, quoters, testRequest(), nil)
+	if err != nil {
+		t.Fatalf("Best: %v", err)
+	}
+	if tx.Backend != z.Name() {
+		t.Errorf("Best without gas costs = %s, want %s", tx.Backend, z.Name())
+	}
+
+	// At 2e13 DAI wei per gas, 0x's extra gas costs more than its better price.
+	gasCost := func(gas uint64) *big.Int {
+		return new(big.Int).Mul(new(big.Int).SetUint64(gas), big.NewInt(2e13))
+	}
+	if tx, err = Best(ctx, quoters, testRequest(), gasCost); err != nil {
+		t.Fatalf("Best: %v", err)
+	}
+	if tx.Backend != p.Name() {
+		t.Errorf("Best with gas costs = %s, want %s", tx.Backend, p.Name())
+	}
+
+	// A quoter failing to build falls back to the next best one.
+	p.BaseURL = newFixtureServer(t, map[string]string{"/prices": "paraswap_prices.json"}).URL
+	if tx, err = Best(ctx, quoters, testRequest(), gasCost); err != nil {
+		t.Fatalf("Best: %v", err)
+	}
+	if tx.Backend != z.Name() {
+		t.Errorf("Best with a failing Paraswap = %s, want %s", tx.Backend, z.Name())
+	}
+
+	// Calldata for a contract other than the router is rejected. The fixtures target the v5 router.
+	v4 := NewOneInch(OneInchV4, big.NewInt(1))
+	v4.BaseURL = o.BaseURL
+	if _, err := Best(ctx, []Quoter{v4}, testRequest(), nil); err == nil {
+		t.Errorf("Best accepted a swap through the wrong router")
+	}
+
+	// So is a swap approving a contract other than the spender.
+	if _, err := Best(ctx, []Quoter{otherSpender{z}}, testRequest(), nil); err == nil {
+		t.Errorf("Best accepted a swap approving the wrong spender")
+	}
+}
+
+// otherSpender is a quoter expecting its swaps to approve another spender.
+type otherSpender struct {
+	*ZeroEx
+}
+
+func (otherSpender) Spender() common.Address {
+	return common.HexToAddress("0x01")
+}
@@ -0,0 +1,139 @@
+package swap
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// zeroExProxy is the 0x Exchange Proxy, which executes swaps and is approved for the sold tokens.
+var zeroExProxy = common.HexToAddress("0xDef1C0ded9bec7F1a1670819833240f027b25EfF")
+
+var zeroExHosts = map[uint64]string{
+	1:   "https://api.0x.org",
+	137: "https://polygon.api.0x.org",
+}
+
+// ZeroEx quotes swaps with the 0x API.
+type ZeroEx struct {
+	// BaseURL is the API root, such as "https://api.0x.org".
+	BaseURL string
+	// APIKey is sent in the 0x-api-key header if set.
+	APIKey string
+	HTTP   *HTTPClient
+}
+
+// NewZeroEx returns a quoter for the given chain. Chains without a known API host default to
+// mainnet's.
+func NewZeroEx(chainID *big.Int) *ZeroEx {
+	host, ok := zeroExHosts[chainID.Uint64()]
+	if !ok {
+		host = zeroExHosts[1]
+	}
+	return &ZeroEx{BaseURL: host, HTTP: NewHTTPClient()}
+}
+
+func (z *ZeroEx) Name() string {
+	return "0x"
+}
+
+func (z *ZeroEx) Router() common.Address {
+	return zeroExProxy
+}
+
+func (z *ZeroEx) Spender() common.Address {
+	return zeroExProxy
+}
+
+type zeroExPrice struct {
+	BuyAmount    string `json:"buyAmount"`
+	EstimatedGas string `json:"estimatedGas"`
+}
+
+type zeroExQuote struct {
+	zeroExPrice
+	To              common.Address `json:"to"`
+	Data            hexutil.Bytes  `json:"data"`
+	AllowanceTarget common.Address `json:"allowanceTarget"`
+}
+
+func (z *ZeroEx) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	header := http.Header{}
+	if z.APIKey != "" {
+		header.Set("0x-api-key", z.APIKey)
+	}
+	return z.HTTP.DoJSON(ctx, http.MethodGet, z.BaseURL+path+"?"+params.Encode(), header, nil, out)
+}
+
+func (z *ZeroEx) params(req *Request) url.Values {
+	return url.Values{
+		"sellToken":  {req.From.Hex()},
+		"buyToken":   {req.To.Hex()},
+		"sellAmount": {req.Amount.String()},
+	}
+}
+
+// parse returns the bought amount and gas estimate of a price.
+func (p *zeroExPrice) parse() (*big.Int, uint64, error) {
+	amount, err := parseAmount(p.BuyAmount)
+	if err != nil {
+		return nil, 0, err
+	}
+	var gas uint64
+	if p.EstimatedGas != "" {
+		if gas, err = strconv.ParseUint(p.EstimatedGas, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("invalid gas estimate %q", p.EstimatedGas)
+		}
+	}
+	return amount, gas, nil
+}
+
+func (z *ZeroEx) Quote(ctx context.Context, req *Request) (*Quote, error) {
+	var res zeroExPrice
+	if err := z.get(ctx, "/swap/v1/price", z.params(req), &res); err != nil {
+		return nil, fmt.Errorf("0x price: %w", err)
+	}
+	amount, gas, err := res.parse()
+	if err != nil {
+		return nil, fmt.Errorf("0x price: %w", err)
+	}
+	return &Quote{ToAmount: amount, Gas: gas}, nil
+}
+
+func (z *ZeroEx) Build(ctx context.Context, req *Request, q *Quote) (*Tx, error) {
+	params := z.params(req)
+	params.Set("takerAddress", req.Taker.Hex())
+	params.Set("slippagePercentage", fmt.Sprint(float64(SlippagePercent)/100))
+	// The taker only holds the tokens once the flash loan executes, so 0x can't validate the swap.
+	params.Set("skipValidation", "true")
+
+	var res zeroExQuote
+	if err := z.get(ctx, "/swap/v1/quote", params, &res); err != nil {
+		return nil, fmt.Errorf("0x quote: %w", err)
+	}
+	amount, gas, err := res.parse()
+	if err != nil {
+		return nil, fmt.Errorf("0x quote: %w", err)
+	}
+	if res.AllowanceTarget != zeroExProxy {
+		return nil, fmt.Errorf("0x quote: unexpected allowance target %v", res.AllowanceTarget)
+	}
+	if gas == 0 {
+		gas = q.Gas
+	}
+	return &Tx{
+		Backend:   z.Name(),
+		Router:    res.To,
+		Spender:   res.AllowanceTarget,
+		Calldata:  res.Data,
+		ToAmount:  amount,
+		MinAmount: MinAmount(amount),
+		Gas:       gas,
+	}, nil
+}
@@ -31,7 +31,7 @@ func TestContract(t *testing.T) {
 	}
 
 	// Deploys the contract.
-	rep, repAddr, err := repayment.Deploy(ctx, client)
+	rep, repAddr, err := repayment.Deploy(ctx, client, nil)
 	if err != nil {
 		t.Fatalf("deploying repayment contract failed: %v", err)
 	}
@@ -53,40 +53,53 @@ func TestContract(t *testing.T) {
 	}
 
 	// The user signs a delegation certificate allowing the bot to execute the repayment contract.
-	cert, err := delegation.New(client.BotAddress())
+	// The contract was deployed above, so the parameters don't configure it.
+	domain := delegation.DomainFor(params)
+	domain.VerifyingContract = repAddr
+	cert, err := delegation.New(client.BotAddress(), domain, delegation.Terms{
+		Deadline: uint64(time.Now().Add(time.Hour).Unix()),
+	})
 	if err != nil {
 		t.Fatalf("delegation.New(%v) = _, %v, want _, nil", client.BotAddress(), err)
 	}
-	signature, err := user.Sign(cert.Hash())
+	signature, err := user.SignDigest(cert.Hash())
 	if err != nil {
 		t.Fatalf("Error signing certificate: %v", err)
 	}
+	packed, err := cert.Pack(signature)
+	if err != nil {
+		t.Fatalf("cert.Pack(...) = _, %v, want _, nil", err)
+	}
 
 	// Executes the swap.
-	loan, err := client.Loan(ctx, user.Address)
+	loan, err := client.Loan(ctx, user.Address())
 	if err != nil {
-		t.Fatalf("client.Loan(ctx, %v) = _, %v, want _, nil", user.Address, err)
+		t.Fatalf("client.Loan(ctx, %v) = _, %v, want _, nil", user.Address(), err)
 	}
-	exec, err := repayment.NewExecution(ctx, client, loan, repAddr, signature)
+	exec, err := repayment.NewExecution(ctx, client, loan, repAddr, packed, 0, nil)
 	if err != nil {
 		t.Fatalf("repayment.NewExecution(...) = _, %v, want _, nil", err)
 	}
-	if err := exec.Execute(ctx, client, rep); err != nil {
+	result, err := exec.Execute(ctx, client, rep)
+	if err != nil {
 		t.Fatalf("exec.Execute(...) = _, %v, want _, nil", err)
 	}
+	if !result.Succeeded || result.DebtRepaid.Sign() == 0 {
+		t.Errorf("exec.Execute(...) = %+v, want a successful repayment", result)
+	}
 
 	// Verifies that debts are cleared.
-	sDebt, err := client.BalanceOf(ctx, loan.StableDebt, user.Address)
+	sDebt, err := client.BalanceOf(ctx, loan.Debt[0].StableDebt, user.Address())
 	if err != nil {
-		t.Fatalf("client.BalanceOf(%v, %v) = _, %v, want _, nil", loan.StableDebt, user.Address, err)
+		t.Fatalf("client.BalanceOf(%v, %v) = _, %v, want _, nil", loan.Debt[0].StableDebt, user.Address(), err)
 	}
 	if sDebt.Cmp(big.NewInt(0)) != 0 {
 		t.Fatalf("sDebt=%v, want 0", sDebt)
 	}
 
-	vDebt, err := client.BalanceOf(ctx, loan.VariableDebt, user.Address)
+	vDebt, err := client.BalanceOf(ctx, loan.Debt[0].VariableDebt, user.Address())
 	if err != nil {
-		t.Fatalf("client.BalanceOf(%v, %v) = _, %v, want _, nil", loan.VariableDebt, user.Address, err)
+		t.Fatalf("client.BalanceOf(%v, %v) = _, %v, want _, nil", loan.Debt[0].VariableDebt, user.Address(), err)
 	}
 	if vDebt.Cmp(big.NewInt(0)) != 0 {
 		t.Fatalf("vDebt=%v, want 0", sDebt)
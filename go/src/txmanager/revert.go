@@ -0,0 +1,65 @@
+package txmanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RevertError reports a transaction or call that reverted.
+type RevertError struct {
+	// Reason is the message of the failed `require`, or empty if the revert carried none.
+	Reason string
+	Err    error
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("reverted: %v", e.Err)
+	}
+	return fmt.Sprintf("reverted: %s", e.Reason)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeRevert converts a node error into a `*RevertError` if it's a revert, decoding the reason
+// from the revert data or, for nodes that don't return it, from the message. Other errors are
+// returned unchanged.
+func DecodeRevert(err error) error {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if hexData, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(hexData); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+					return &RevertError{Reason: reason, Err: err}
+				}
+				return &RevertError{Err: err}
+			}
+		}
+	}
+	msg := err.Error()
+	// Hardhat reports reasons as "reverted with reason string 'reason'".
+	const hardhatPrefix = "reverted with reason string '"
+	if i := strings.Index(msg, hardhatPrefix); i >= 0 {
+		reason := msg[i+len(hardhatPrefix):]
+		if j := strings.LastIndex(reason, "'"); j >= 0 {
+			reason = reason[:j]
+		}
+		return &RevertError{Reason: reason, Err: err}
+	}
+	// Geth reports them as "execution reverted: reason".
+	const gethPrefix = "execution reverted"
+	if i := strings.Index(msg, gethPrefix); i >= 0 {
+		return &RevertError{Reason: strings.TrimPrefix(msg[i+len(gethPrefix):], ": "), Err: err}
+	}
+	if strings.Contains(msg, "revert") {
+		return &RevertError{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package txmanager
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// dataError mimics the errors returned by nodes for reverted calls.
+type dataError struct {
+	msg  string
+	data interface{}
+}
+
+func (e *dataError) Error() string          { return e.msg }
+func (e *dataError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevert(t *testing.T) {
+	// Error(string) encoding of "debt not found".
+	encoded := hexutil.MustDecode("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000e" +
+		"64656274206e6f7420666f756e64000000000000000000000000000000000000")
+	for _, tc := range []struct {
+		desc       string
+		err        error
+		wantRevert bool
+		wantReason string
+	}{
+		{"revert data", &dataError{"execution reverted: debt not found", hexutil.Encode(encoded)}, true,
+			"debt not found"},
+		{"wrapped revert data", fmt.Errorf("call: %w", &dataError{"execution reverted", hexutil.Encode(encoded)}),
+			true, "debt not found"},
+		{"revert without reason", &dataError{"execution reverted", "0x"}, true, ""},
+		{"geth message", errors.New("execution reverted: swap failed"), true, "swap failed"},
+		{"hardhat message", errors.New(
+			"Error: VM Exception while processing transaction: reverted with reason string 'swap failed'"),
+			true, "swap failed"},
+		{"not a revert", errors.New("connection refused"), false, ""},
+	} {
+		err := DecodeRevert(tc.err)
+		var revert *RevertError
+		if got := errors.As(err, &revert); got != tc.wantRevert {
+			t.Errorf("%s: DecodeRevert(%v) = %v, want a RevertError: %v", tc.desc, tc.err, err, tc.wantRevert)
+			continue
+		}
+		if tc.wantRevert && revert.Reason != tc.wantReason {
+			t.Errorf("%s: DecodeRevert(%v).Reason = %q, want %q", tc.desc, tc.err, revert.Reason, tc.wantReason)
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: DecodeRevert(%v) doesn't wrap the original error", tc.desc, tc.err)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package txmanager sends transactions for an account and follows them until they are confirmed.
+//
+// A `Manager` allocates nonces locally so concurrent transactions don't race, waits for receipts
+// and confirmations, and replaces transactions that aren't mined in time with higher gas prices.
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Defaults of `Config`.
+const (
+	DefaultConfirmations  = 1
+	DefaultPollInterval   = time.Second
+	DefaultStuckAfter     = time.Minute
+	DefaultGasBumpPercent = 15
+	DefaultMaxBumps       = 5
+)
+
+// minBumpPercent is the minimum gas price increase nodes accept for replacements.
+const minBumpPercent = 10
+
+// Backend is the node access needed to send and follow transactions. `*ethclient.Client`
+// implements it.
+type Backend interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Config tunes how transactions are followed. Zero values use the defaults.
+type Config struct {
+	// Confirmations is the number of blocks, including the one with the transaction, to wait for.
+	Confirmations uint64
+	// PollInterval is how often receipts and block numbers are polled.
+	PollInterval time.Duration
+	// StuckAfter is how long to wait for a transaction to be mined before replacing it.
+	StuckAfter time.Duration
+	// GasBumpPercent is the gas price increase of replacements. It's at least 10%, which nodes
+	// require.
+	GasBumpPercent int64
+	// MaxBumps bounds the number of replacements of a transaction.
+	MaxBumps int
+	// MaxFeePerGas caps the gas price or fee cap of replacements. Nil leaves them uncapped.
+	MaxFeePerGas *big.Int
+}
+
+func (c Config) withDefaults() Config {
+	if c.Confirmations == 0 {
+		c.Confirmations = DefaultConfirmations
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.StuckAfter == 0 {
+		c.StuckAfter = DefaultStuckAfter
+	}
+	if c.GasBumpPercent == 0 {
+		c.GasBumpPercent = DefaultGasBumpPercent
+	} else if c.GasBumpPercent < minBumpPercent {
+		c.GasBumpPercent = minBumpPercent
+	}
+	if c.MaxBumps == 0 {
+		c.MaxBumps = DefaultMaxBumps
+	}
+	return c
+}
+
+// Manager sends the transactions of a single account.
+type Manager struct {
+	backend Backend
+	from    common.Address
+	signer  bind.SignerFn
+	cfg     Config
+
+	mu sync.Mutex
+	// next is the next nonce to use, or nil if it must be fetched from the node.
+	next *uint64
+}
+
+// New returns a manager sending transactions from the account of `auth`, which must be able to
+// sign for it.
+func New(backend Backend, auth *bind.TransactOpts, cfg Config) *Manager {
+	return &Manager{
+		backend: backend,
+		from:    auth.From,
+		signer:  auth.Signer,
+		cfg:     cfg.withDefaults(),
+	}
+}
+
+// From returns the sending account.
+func (m *Manager) From() common.Address {
+	return m.from
+}
+
+// allocateNonce returns the next nonce of the account.
+func (m *Manager) allocateNonce(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next == nil {
+		nonce, err := m.backend.PendingNonceAt(ctx, m.from)
+		if err != nil {
+			return 0, fmt.Errorf("obtaining pending nonce: %w", err)
+		}
+		m.next = &nonce
+	}
+	nonce := *m.next
+	*m.next++
+	return nonce, nil
+}
+
+// releaseNonce gives back a nonce that wasn't sent. If later nonces were allocated meanwhile, the
+// nonce is resynchronized with the node on the next allocation so the gap gets filled.
+func (m *Manager) releaseNonce(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next != nil && *m.next == nonce+1 {
+		*m.next = nonce
+		return
+	}
+	m.next = nil
+}
+
+// Report describes a mined transaction.
+type Report struct {
+	Desc string
+	// Tx is the transaction that was mined, which may be a replacement of the original.
+	Tx      *types.Transaction
+	Receipt *types.Receipt
+	// Replacements is the number of times the transaction was replaced.
+	Replacements int
+}
+
+func (r *Report) String() string {
+	status := "succeeded"
+	if r.Receipt.Status != types.ReceiptStatusSuccessful {
+		status = "failed"
+	}
+	return fmt.Sprintf("%s %s in tx %v at block %v using %d gas after %d replacements", r.Desc, status,
+		r.Tx.Hash().Hex(), r.Receipt.BlockNumber, r.Receipt.GasUsed, r.Replacements)
+}
+
+// Send creates a transaction with `t` and follows it until it's confirmed. The transactor passed
+// to `t` has the nonce set and doesn't send the transaction, which Send does. If the transaction
+// reverts, the error is a `*RevertError` and the report is still returned.
+func (m *Manager) Send(ctx context.Context, desc string,
+	t func(*bind.TransactOpts) (*types.Transaction, error)) (*Report, error) {
+	nonce, err := m.allocateNonce(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", desc, err)
+	}
+	tx, err := t(&bind.TransactOpts{
+		From:    m.from,
+		Nonce:   new(big.Int).SetUint64(nonce),
+		Signer:  m.signer,
+		Context: ctx,
+		NoSend:  true,
+	})
+	if err != nil {
+		m.releaseNonce(nonce)
+		return nil, fmt.Errorf("creating %s: %w", desc, err)
+	}
+	if err := m.backend.SendTransaction(ctx, tx); err != nil {
+		m.releaseNonce(nonce)
+		return nil, fmt.Errorf("sending %s: %w", desc, DecodeRevert(err))
+	}
+	log.Printf("Sent %s in tx %v with nonce %d", desc, tx.Hash().Hex(), nonce)
+
+	report, err := m.wait(ctx, desc, tx)
+	if err != nil {
+		return report, err
+	}
+	log.Print(report)
+	if report.Receipt.Status != types.ReceiptStatusSuccessful {
+		return report, fmt.Errorf("%s: %w", desc, m.revertReason(ctx, report))
+	}
+	return report, nil
+}
+
+// wait polls for the receipt of `tx` or any of its replacements until it has enough
+// confirmations, replacing the transaction if it isn't mined in time.
+func (m *Manager) wait(ctx context.Context, desc string, tx *types.Transaction) (*Report, error) {
+	sent := []*types.Transaction{tx}
+	lastSent := time.Now()
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if report := m.confirmed(ctx, desc, sent); report != nil {
+			return report, nil
+		}
+		if len(sent)-1 < m.cfg.MaxBumps && time.Since(lastSent) >= m.cfg.StuckAfter {
+			if replacement, err := m.replace(ctx, sent[len(sent)-1]); err != nil {
+				log.Printf("Error replacing stuck %s: %v", desc, err)
+			} else {
+				log.Printf("Replaced stuck %s with tx %v", desc, replacement.Hash().Hex())
+				sent = append(sent, replacement)
+			}
+			lastSent = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for %s in tx %v: %w", desc, tx.Hash().Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// confirmed returns a report if one of the `sent` transactions is mined with enough
+// confirmations, or nil if none are yet.
+func (m *Manager) confirmed(ctx context.Context, desc string, sent []*types.Transaction) *Report {
+	for i, tx := range sent {
+		receipt, err := m.backend.TransactionReceipt(ctx, tx.Hash())
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			// Retried on the next poll.
+			log.Printf("Error getting receipt of %s in tx %v: %v", desc, tx.Hash().Hex(), err)
+			continue
+		}
+		head, err := m.backend.BlockNumber(ctx)
+		if err != nil {
+			log.Printf("Error getting block number: %v", err)
+			return nil
+		}
+		if head+1 < receipt.BlockNumber.Uint64()+m.cfg.Confirmations {
+			return nil
+		}
+		return &Report{Desc: desc, Tx: tx, Receipt: receipt, Replacements: i}
+	}
+	return nil
+}
+
+// replace signs and sends a copy of `tx` with gas prices raised by `GasBumpPercent`.
+func (m *Manager) replace(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	var data types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		data = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: m.bump(tx.GasPrice()),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.DynamicFeeTxType:
+		data = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  m.bump(tx.GasTipCap()),
+			GasFeeCap:  m.bump(tx.GasFeeCap()),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("can't replace transactions of type %d", tx.Type())
+	}
+	if max := m.cfg.MaxFeePerGas; max != nil && tx.GasFeeCap().Cmp(max) >= 0 {
+		return nil, fmt.Errorf("fees are at the ceiling of %v", max)
+	}
+	replacement, err := m.signer(m.from, types.NewTx(data))
+	if err != nil {
+		return nil, fmt.Errorf("signing replacement: %w", err)
+	}
+	if err := m.backend.SendTransaction(ctx, replacement); err != nil {
+		if strings.Contains(err.Error(), "nonce too low") {
+			// An earlier version was mined meanwhile and its receipt will show up.
+			return nil, fmt.Errorf("already mined: %w", err)
+		}
+		return nil, fmt.Errorf("sending replacement: %w", err)
+	}
+	return replacement, nil
+}
+
+// bump returns `price` raised by `GasBumpPercent`, limited to `MaxFeePerGas`.
+func (m *Manager) bump(price *big.Int) *big.Int {
+	ret := bump(price, m.cfg.GasBumpPercent)
+	if max := m.cfg.MaxFeePerGas; max != nil && ret.Cmp(max) > 0 {
+		ret.Set(max)
+	}
+	return ret
+}
+
+// bump returns `price` raised by `percent`, rounded up.
+func bump(price *big.Int, percent int64) *big.Int {
+	ret := new(big.Int).Mul(price, big.NewInt(100+percent))
+	ret.Add(ret, big.NewInt(99))
+	return ret.Quo(ret, big.NewInt(100))
+}
+
+// revertReason replays a failed transaction at its block to recover the revert reason.
+func (m *Manager) revertReason(ctx context.Context, r *Report) error {
+	failed := &RevertError{Err: fmt.Errorf("tx %v failed", r.Tx.Hash().Hex())}
+	msg := ethereum.CallMsg{
+		From:  m.from,
+		To:    r.Tx.To(),
+		Gas:   r.Tx.Gas(),
+		Value: r.Tx.Value(),
+		Data:  r.Tx.Data(),
+	}
+	// The state before the block is the closest available to the one the transaction ran in.
+	parent := new(big.Int).Sub(r.Receipt.BlockNumber, big.NewInt(1))
+	_, err := m.backend.CallContract(ctx, msg, parent)
+	if err == nil {
+		return failed
+	}
+	var revert *RevertError
+	if errors.As(DecodeRevert(err), &revert) {
+		return revert
+	}
+	return failed
+}
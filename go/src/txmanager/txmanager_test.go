@@ -0,0 +1,261 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// fakeBackend mines transactions paying at least `minGasPrice` into the current block.
+type fakeBackend struct {
+	mu          sync.Mutex
+	pending     uint64
+	nonceCalls  int
+	minGasPrice *big.Int
+	head        uint64
+	// advance is added to the head every time it's queried.
+	advance  uint64
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	failed   bool
+	callErr  error
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{minGasPrice: big.NewInt(0), head: 10, receipts: map[common.Hash]*types.Receipt{}}
+}
+
+func (b *fakeBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nonceCalls++
+	return b.pending, nil
+}
+
+func (b *fakeBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, tx)
+	if tx.GasPrice().Cmp(b.minGasPrice) >= 0 {
+		status := types.ReceiptStatusSuccessful
+		if b.failed {
+			status = types.ReceiptStatusFailed
+		}
+		b.receipts[tx.Hash()] = &types.Receipt{
+			Status:      status,
+			TxHash:      tx.Hash(),
+			BlockNumber: new(big.Int).SetUint64(b.head),
+			GasUsed:     tx.Gas(),
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r, ok := b.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (b *fakeBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.head += b.advance
+	return b.head, nil
+}
+
+func (b *fakeBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, b.callErr
+}
+
+func newTestManager(t *testing.T, b *fakeBackend, cfg Config) *Manager {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("crypto.GenerateKey() = _, %v", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatalf("bind.NewKeyedTransactorWithChainID(...) = _, %v", err)
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = time.Millisecond
+	}
+	return New(b, auth, cfg)
+}
+
+// transfer returns a transaction builder for a plain transfer.
+func transfer(gasPrice int64) func(*bind.TransactOpts) (*types.Transaction, error) {
+	return func(txr *bind.TransactOpts) (*types.Transaction, error) {
+		to := common.HexToAddress("0x01")
+		return txr.Signer(txr.From, types.NewTx(&types.LegacyTx{
+			Nonce:    txr.Nonce.Uint64(),
+			GasPrice: big.NewInt(gasPrice),
+			Gas:      21000,
+			To:       &to,
+		}))
+	}
+}
+
+func TestSendAllocatesNonces(t *testing.T) {
+	b := newFakeBackend()
+	b.pending = 7
+	m := newTestManager(t, b, Config{})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := m.Send(ctx, "transfer", transfer(1)); err != nil {
+				t.Errorf("Send(...) = _, %v, want _, nil", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.nonceCalls != 1 {
+		t.Errorf("PendingNonceAt called %d times, want once", b.nonceCalls)
+	}
+	seen := map[uint64]bool{}
+	for _, tx := range b.sent {
+		seen[tx.Nonce()] = true
+	}
+	for n := uint64(7); n < 17; n++ {
+		if !seen[n] {
+			t.Errorf("nonce %d wasn't used, sent %d transactions", n, len(b.sent))
+		}
+	}
+}
+
+func TestSendReleasesUnsentNonce(t *testing.T) {
+	b := newFakeBackend()
+	m := newTestManager(t, b, Config{})
+	ctx := context.Background()
+
+	if _, err := m.Send(ctx, "broken", func(*bind.TransactOpts) (*types.Transaction, error) {
+		return nil, errors.New("estimation failed")
+	}); err == nil {
+		t.Fatalf("Send(...) with a failing builder = _, nil, want error")
+	}
+	if _, err := m.Send(ctx, "transfer", transfer(1)); err != nil {
+		t.Fatalf("Send(...) = _, %v, want _, nil", err)
+	}
+	if got := b.sent[0].Nonce(); got != 0 {
+		t.Errorf("nonce after a failed build = %d, want 0", got)
+	}
+}
+
+func TestSendReplacesStuckTransaction(t *testing.T) {
+	b := newFakeBackend()
+	b.minGasPrice = big.NewInt(120)
+	m := newTestManager(t, b, Config{StuckAfter: time.Millisecond, GasBumpPercent: 10})
+
+	report, err := m.Send(context.Background(), "transfer", transfer(100))
+	if err != nil {
+		t.Fatalf("Send(...) = _, %v, want _, nil", err)
+	}
+	// 100 -> 110 -> 121.
+	if report.Replacements != 2 {
+		t.Errorf("Replacements = %d, want 2", report.Replacements)
+	}
+	if got := report.Tx.GasPrice().Int64(); got != 121 {
+		t.Errorf("mined gas price = %d, want 121", got)
+	}
+	for _, tx := range b.sent {
+		if tx.Nonce() != report.Tx.Nonce() {
+			t.Errorf("replacement has nonce %d, want %d", tx.Nonce(), report.Tx.Nonce())
+		}
+	}
+}
+
+func TestSendWaitsForConfirmations(t *testing.T) {
+	b := newFakeBackend()
+	b.advance = 1
+	m := newTestManager(t, b, Config{Confirmations: 3})
+
+	report, err := m.Send(context.Background(), "transfer", transfer(1))
+	if err != nil {
+		t.Fatalf("Send(...) = _, %v, want _, nil", err)
+	}
+	if mined := report.Receipt.BlockNumber.Uint64(); b.head < mined+2 {
+		t.Errorf("returned at head %d for a transaction mined at %d, want 3 confirmations", b.head, mined)
+	}
+}
+
+func TestSendGivesUpOnCancel(t *testing.T) {
+	b := newFakeBackend()
+	b.minGasPrice = big.NewInt(1000)
+	m := newTestManager(t, b, Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := m.Send(ctx, "transfer", transfer(1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send(...) of a transaction that isn't mined = _, %v, want deadline exceeded", err)
+	}
+}
+
+func TestSendReportsRevertReason(t *testing.T) {
+	b := newFakeBackend()
+	b.failed = true
+	b.callErr = errors.New("execution reverted: swap failed")
+	m := newTestManager(t, b, Config{})
+
+	report, err := m.Send(context.Background(), "repayment", transfer(1))
+	var revert *RevertError
+	if !errors.As(err, &revert) || revert.Reason != "swap failed" {
+		t.Fatalf("Send(...) of a failing transaction = _, %v, want a revert with reason", err)
+	}
+	if report == nil || report.Receipt.Status != types.ReceiptStatusFailed {
+		t.Errorf("Send(...) of a failing transaction = %v, want the failed receipt", report)
+	}
+}
+
+func TestBump(t *testing.T) {
+	for _, tc := range []struct {
+		price, percent, want int64
+	}{
+		{100, 10, 110},
+		{100, 15, 115},
+		// Rounds up so the increase is never below the minimum.
+		{101, 10, 112},
+	} {
+		if got := bump(big.NewInt(tc.price), tc.percent); got.Int64() != tc.want {
+			t.Errorf("bump(%d, %d) = %v, want %d", tc.price, tc.percent, got, tc.want)
+		}
+	}
+}
+
+func TestReplaceRespectsMaxFee(t *testing.T) {
+	b := newFakeBackend()
+	b.minGasPrice = big.NewInt(1000)
+	m := newTestManager(t, b, Config{GasBumpPercent: 10, MaxFeePerGas: big.NewInt(105)})
+	ctx := context.Background()
+
+	tx, err := transfer(100)(&bind.TransactOpts{From: m.from, Signer: m.signer, Nonce: big.NewInt(0)})
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+	replacement, err := m.replace(ctx, tx)
+	if err != nil {
+		t.Fatalf("replace(...) = _, %v, want _, nil", err)
+	}
+	if got := replacement.GasPrice().Int64(); got != 105 {
+		t.Errorf("replacement gas price = %d, want the ceiling of 105", got)
+	}
+	if _, err := m.replace(ctx, replacement); err == nil {
+		t.Errorf("replace(...) at the ceiling = _, nil, want error")
+	}
+}
@@ -0,0 +1,183 @@
+package wallets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"env"
+)
+
+// Signer signs hashes and transactions for an account without exposing its key.
+type Signer interface {
+	// Address returns the account of the signer.
+	Address() common.Address
+	// SignHash signs `hash` as an EIP-191 personal message, which is what external signers allow.
+	// The signature's V is 27 or 28.
+	SignHash(hash common.Hash) ([]byte, error)
+	// SignTx signs `tx` for the given chain.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Transactor returns transaction options signing with `s` on the given chain. Nonces and sending
+// are left to the caller, usually a `txmanager.Manager`.
+func Transactor(s Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: s.Address(),
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != s.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(tx, chainID)
+		},
+	}
+}
+
+// ForBot returns the signer of the bot selected by the parameters.
+func ForBot(params env.Params) (Signer, error) {
+	switch params.Signer() {
+	case env.SignerKey:
+		w, err := NewWallet(params.BotKey())
+		if err != nil {
+			return nil, fmt.Errorf("bot wallet: %w", err)
+		}
+		return w, nil
+	case env.SignerKeystore:
+		return NewKeystore(params.KeystorePath(), params.KeystorePassphrase())
+	case env.SignerExternal:
+		return NewExternal(params.SignerURL(), params.BotAddress())
+	}
+	return nil, fmt.Errorf("unknown signer %q", params.Signer())
+}
+
+// accountSigner signs with an account of a go-ethereum wallet, such as an unlocked keystore or
+// Clef.
+type accountSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// NewKeystore returns a signer for the encrypted key file at `path`, unlocked with `passphrase`.
+// The key stays in the keystore, which is the only holder of the decrypted key.
+func NewKeystore(path, passphrase string) (Signer, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading keystore: %w", err)
+	}
+	address, err := keyAddress(content)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", path, err)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", path, err)
+	}
+	ks := keystore.NewKeyStore(filepath.Dir(abs), keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.Find(accounts.Account{
+		Address: address,
+		URL:     accounts.URL{Scheme: keystore.KeyStoreScheme, Path: abs},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding %v in keystore %s: %w", address, path, err)
+	}
+	if err := ks.Unlock(account, passphrase); err != nil {
+		return nil, fmt.Errorf("unlocking %v in keystore %s: %w", account.Address, path, err)
+	}
+	for _, w := range ks.Wallets() {
+		if w.Contains(account) {
+			return &accountSigner{wallet: w, account: account}, nil
+		}
+	}
+	return nil, fmt.Errorf("no wallet for %v in keystore %s", account.Address, path)
+}
+
+// NewExternal returns a signer for `address` backed by a Clef compatible signer at `url`. Every
+// signature goes through the external signer's approval rules.
+func NewExternal(url string, address common.Address) (Signer, error) {
+	ext, err := external.NewExternalSigner(url)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to external signer: %w", err)
+	}
+	account := accounts.Account{Address: address, URL: ext.URL()}
+	if !ext.Contains(account) {
+		return nil, fmt.Errorf("external signer doesn't manage %v", address)
+	}
+	return &accountSigner{wallet: ext, account: account}, nil
+}
+
+func (s *accountSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *accountSigner) SignHash(hash common.Hash) ([]byte, error) {
+	signature, err := s.wallet.SignText(s.account, hash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("signing with %v: %w", s.account.Address, err)
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("signing with %v: signature has %d bytes", s.account.Address, len(signature))
+	}
+	return toEthereumV(signature), nil
+}
+
+func (s *accountSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := s.wallet.SignTx(s.account, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("signing tx with %v: %w", s.account.Address, err)
+	}
+	// External signers may change the transaction, so it's checked against the request.
+	if !sameTx(signed, tx) {
+		return nil, fmt.Errorf("signer of %v returned a different transaction", s.account.Address)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, fmt.Errorf("recovering signer of tx: %w", err)
+	}
+	if from != s.account.Address {
+		return nil, fmt.Errorf("tx signed by %v instead of %v", from, s.account.Address)
+	}
+	return signed, nil
+}
+
+// sameTx reports whether the transactions have the same contents, ignoring signatures.
+func sameTx(a, b *types.Transaction) bool {
+	return a.Type() == b.Type() && a.Nonce() == b.Nonce() && a.Gas() == b.Gas() &&
+		a.GasPrice().Cmp(b.GasPrice()) == 0 && a.GasTipCap().Cmp(b.GasTipCap()) == 0 &&
+		a.GasFeeCap().Cmp(b.GasFeeCap()) == 0 && a.Value().Cmp(b.Value()) == 0 &&
+		(a.To() == nil) == (b.To() == nil) && (a.To() == nil || *a.To() == *b.To()) &&
+		bytes.Equal(a.Data(), b.Data())
+}
+
+// toEthereumV converts the recovery id at the end of a signature to the 27 or 28 used by
+// `ecrecover`.
+func toEthereumV(signature []byte) []byte {
+	if v := signature[len(signature)-1]; v < 27 {
+		signature[len(signature)-1] = v + 27
+	}
+	return signature
+}
+
+// keyAddress returns the address of an encrypted key file without decrypting it.
+func keyAddress(content []byte) (common.Address, error) {
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(content, &key); err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(key.Address) {
+		return common.Address{}, errors.New("no address in key file")
+	}
+	return common.HexToAddress(key.Address), nil
+}
@@ -0,0 +1,184 @@
+package wallets
+
+import (
+	"io/ioutil"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const testKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var chainID = big.NewInt(1337)
+
+// checkSigner checks that `s` signs hashes and transactions recoverable to its address.
+func checkSigner(t *testing.T, s Signer) {
+	t.Helper()
+	hash := crypto.Keccak256Hash([]byte("packed params"))
+	signature, err := s.SignHash(hash)
+	if err != nil {
+		t.Fatalf("SignHash(...) = _, %v, want _, nil", err)
+	}
+	if v := signature[64]; v != 27 && v != 28 {
+		t.Errorf("SignHash(...) has V %d, want 27 or 28", v)
+	}
+	// This is what `ecrecover` sees in the contract.
+	signature[64] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), signature)
+	if err != nil {
+		t.Fatalf("crypto.SigToPub(...) = _, %v, want _, nil", err)
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != s.Address() {
+		t.Errorf("SignHash(...) recovers to %v, want %v", got, s.Address())
+	}
+
+	to := common.HexToAddress("0x01")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	})
+	signed, err := Transactor(s, chainID).Signer(s.Address(), tx)
+	if err != nil {
+		t.Fatalf("Signer(...) = _, %v, want _, nil", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil || from != s.Address() {
+		t.Errorf("signed tx sender = %v, %v, want %v, nil", from, err, s.Address())
+	}
+	if _, err := Transactor(s, chainID).Signer(to, tx); err == nil {
+		t.Errorf("Signer(...) for another account = _, nil, want error")
+	}
+}
+
+func TestWallet(t *testing.T) {
+	w, err := NewWallet(testKey)
+	if err != nil {
+		t.Fatalf("NewWallet(...) = _, %v, want _, nil", err)
+	}
+	checkSigner(t, w)
+}
+
+func TestKeystore(t *testing.T) {
+	key, err := crypto.HexToECDSA(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	content, err := keystore.EncryptKey(&keystore.Key{Address: address, PrivateKey: key},
+		"secret", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("keystore.EncryptKey(...) = _, %v, want _, nil", err)
+	}
+	path := filepath.Join(t.TempDir(), "bot.json")
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewKeystore(path, "wrong"); err == nil {
+		t.Errorf("NewKeystore(...) with a wrong passphrase = _, nil, want error")
+	}
+	s, err := NewKeystore(path, "secret")
+	if err != nil {
+		t.Fatalf("NewKeystore(...) = _, %v, want _, nil", err)
+	}
+	if s.Address() != address {
+		t.Errorf("Address() = %v, want %v", s.Address(), address)
+	}
+	checkSigner(t, s)
+}
+
+// fakeClef implements the part of the Clef API used by external signers, signing with a wallet.
+type fakeClef struct {
+	w *Wallet
+	// tamper changes the value of signed transactions.
+	tamper bool
+}
+
+func (c *fakeClef) Version() string {
+	return "6.1.0"
+}
+
+func (c *fakeClef) List() []common.Address {
+	return []common.Address{c.w.Address()}
+}
+
+func (c *fakeClef) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	return c.w.SignHash(common.BytesToHash(data))
+}
+
+func (c *fakeClef) SignTransaction(args apitypes.SendTxArgs) (map[string]interface{}, error) {
+	if c.tamper {
+		args.Value = hexutil.Big(*big.NewInt(1e18))
+	}
+	signed, err := c.w.SignTx(args.ToTransaction(), (*big.Int)(args.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+}
+
+func startClef(t *testing.T, clef *fakeClef) string {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", clef); err != nil {
+		t.Fatalf("registering fake Clef: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(func() {
+		ts.Close()
+		server.Stop()
+	})
+	return ts.URL
+}
+
+func TestExternal(t *testing.T) {
+	w, err := NewWallet(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := startClef(t, &fakeClef{w: w})
+
+	if _, err := NewExternal(url, common.HexToAddress("0x01")); err == nil {
+		t.Errorf("NewExternal(...) for an unmanaged account = _, nil, want error")
+	}
+	s, err := NewExternal(url, w.Address())
+	if err != nil {
+		t.Fatalf("NewExternal(...) = _, %v, want _, nil", err)
+	}
+	checkSigner(t, s)
+}
+
+func TestExternalRejectsChangedTx(t *testing.T) {
+	w, err := NewWallet(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewExternal(startClef(t, &fakeClef{w: w, tamper: true}), w.Address())
+	if err != nil {
+		t.Fatalf("NewExternal(...) = _, %v, want _, nil", err)
+	}
+	to := common.HexToAddress("0x01")
+	tx := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1), Gas: 21000, To: &to})
+	if _, err := s.SignTx(tx, chainID); err == nil {
+		t.Errorf("SignTx(...) with a changed value = _, nil, want error")
+	}
+}
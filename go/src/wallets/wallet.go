@@ -1,83 +1,58 @@
-// Package wallet contains basic functionality for test-account wallets.
+// Package wallets contains the signers of the bot and of test accounts.
 package wallets
 
 import (
-	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// Wallet encapsulates information specific to a wallet.
+// Wallet is a `Signer` holding its private key in memory. It's meant for tests and local nodes;
+// deployments should use a keystore or an external signer.
 type Wallet struct {
-	Address common.Address
-	// TODO(greafilter): this should be moved into a signing service for better security.
+	address    common.Address
 	privateKey *ecdsa.PrivateKey
-	PublicKey  *ecdsa.PublicKey
 }
 
-// NewWallet creates a new wallet.
+// NewWallet creates a new wallet from a hex private key.
 func NewWallet(privateKey string) (*Wallet, error) {
-	ret := &Wallet{}
-	var err error
-	ret.privateKey, err = crypto.HexToECDSA(privateKey)
+	key, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("converting %s to ECDSA: %w", privateKey, err)
+		// The error doesn't include the key, which must not end up in logs.
+		return nil, fmt.Errorf("converting private key to ECDSA: %w", err)
 	}
-	ret.PublicKey = ret.privateKey.Public().(*ecdsa.PublicKey)
-	ret.Address = crypto.PubkeyToAddress(*ret.PublicKey)
-	return ret, nil
+	return &Wallet{address: crypto.PubkeyToAddress(key.PublicKey), privateKey: key}, nil
 }
 
-// NewTransactor creates a transactor from this wallet with some default settings.
-func (w *Wallet) NewTransactor(ctx context.Context, ct bind.ContractTransactor) (*bind.TransactOpts, error) {
-	nonce, err := ct.PendingNonceAt(ctx, w.Address)
-	if err != nil {
-		return nil, fmt.Errorf("obtaining pending nonce: %w", err)
-	}
-	tx := bind.NewKeyedTransactor(w.privateKey)
-	tx.Nonce = big.NewInt(int64(nonce))
-	return tx, nil
+// Address returns the address of the wallet.
+func (w *Wallet) Address() common.Address {
+	return w.address
 }
 
-// Execute performs the given transaction and checks its receipt.
-func (w *Wallet) Execute(ctx context.Context, eth *ethclient.Client, desc string,
-	t func(*bind.TransactOpts) (*types.Transaction, error)) error {
-	txr, err := w.NewTransactor(ctx, eth)
-	if err != nil {
-		return fmt.Errorf("creating transactor for %s: %w", desc, err)
-	}
-	tx, err := t(txr)
-	if err != nil {
-		return fmt.Errorf("executing %s: %w", desc, err)
-	}
-	r, err := eth.TransactionReceipt(ctx, tx.Hash())
+// SignHash signs `hash` as an EIP-191 personal message.
+func (w *Wallet) SignHash(hash common.Hash) ([]byte, error) {
+	return w.SignDigest(common.BytesToHash(accounts.TextHash(hash.Bytes())))
+}
+
+// SignDigest signs `digest` as is, such as the EIP-712 digest of a delegation certificate.
+func (w *Wallet) SignDigest(digest common.Hash) ([]byte, error) {
+	signature, err := crypto.Sign(digest.Bytes(), w.privateKey)
 	if err != nil {
-		return fmt.Errorf("obtaining receipt for %s: %w", desc, err)
+		return nil, fmt.Errorf("signing with %v: %w", w.address, err)
 	}
-	if r.Status != 1 {
-		return fmt.Errorf("%s transaction failed: %v", desc, r)
-	}
-	return nil
+	return toEthereumV(signature), nil
 }
 
-// Sign signs a hash using this wallet.
-func (w *Wallet) Sign(hash common.Hash) ([]byte, error) {
-	signature, err := crypto.Sign(hash.Bytes(), w.privateKey)
+// SignTx signs `tx` for the given chain.
+func (w *Wallet) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), w.privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("signing with %v: %w", w.Address, err)
-	}
-	switch last := signature[len(signature)-1]; last {
-	case '\x00':
-		signature[len(signature)-1] = '\x1B'
-	case '\x01':
-		signature[len(signature)-1] = '\x1C'
+		return nil, fmt.Errorf("signing tx with %v: %w", w.address, err)
 	}
-	return signature, nil
+	return signed, nil
 }
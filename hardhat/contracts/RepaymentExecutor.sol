@@ -1,56 +1,86 @@
 // SPDX-License-Identifier: agpl-3.0.
-pragma solidity 0.6.12;
-pragma experimental ABIEncoderV2;
+pragma solidity 0.8.21;
 
 import "../node_modules/@openzeppelin/contracts/token/ERC20/IERC20.sol";
 import "../flashloan/interfaces/IFlashLoanReceiver.sol";
 import "../interfaces/ILendingPool.sol";
 import "../interfaces/ILendingPoolAddressesProvider.sol";
+import "../interfaces/IPriceOracleGetter.sol";
 
 contract RepaymentExecutor is IFlashLoanReceiver {
-  address constant ADDRESSES_PROVIDER_ADDRESS = 0xB53C1a33016B2DC2fF3653530bfF1848a515c8c5;
-  address constant LENDING_POOL_ADDRESS = 0x7d2768dE32b0b80b7a3454c06BdAc94A69DDc7A9;
-  address constant ONE_INCH = 0x111111125434b319222CdBf8C261674aDB56F3ae;
-
   ILendingPoolAddressesProvider immutable public override ADDRESSES_PROVIDER;
   ILendingPool immutable public override LENDING_POOL;
 
   bytes32 constant EIP712DOMAIN_TYPEHASH = keccak256(
-      "EIP712Domain(string name,string version,uint256 chainId,string salt)"
+      "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract,string salt)"
+  );
+  bytes32 constant DELEGATE_TYPEHASH = keccak256(
+      "Delegate(address delegate,uint256 deadline,uint256 nonce,uint256 maxCollateral)"
   );
-  bytes32 constant DELEGATE_TYPEHASH = keccak256("Delegate(address delegate)");
 
   bytes32 immutable DOMAIN_SEPARATOR;
 
+  // The owner manages the contracts the bot may route swaps through and approve for the
+  // collateral. The bot only signs calldata obtained from aggregator APIs, so a compromised bot or
+  // aggregator must not be able to hand the collateral to an arbitrary contract.
+  address public owner;
+  mapping(address => bool) public routers;
+  mapping(address => bool) public spenders;
+
+  // Delegations of a user with lower nonces are revoked.
+  mapping(address => uint256) public minNonce;
+
+  event RouterAllowed(address indexed router, bool allowed);
+  event SpenderAllowed(address indexed spender, bool allowed);
+  event Revoked(address indexed user, uint256 nonce);
+
   struct EIP712Domain {
     string  name;
     string  version;
     uint256 chainId;
+    address verifyingContract;
     string salt;
   }
 
   struct Delegate {
     address delegate;
+    uint256 deadline;  // Unix time after which the delegation expires.
+    uint256 nonce;  // Must be at least the user's minNonce.
+    uint256 maxCollateral;  // Collateral value limit in wei at the AAVE oracle price, 0 for none.
   }
 
   struct FlashParams {
     address user;  // Loan owner.
     address bot;   // Bot address.
-    bytes botDelegationSignature;
+    // botDelegation encodes (deadline, nonce, maxCollateral, signature) of the bot delegation
+    // message.
+    bytes botDelegation;
     // packedParams encodes (
     //   the AToken,
     //   its underlying asset,
     //   the collateral amount,
     //   the debt underlying asset,
-    //   1inchCalldata  // calldata obtained from the 1inch API
+    //   the debt amount,  // 0 repays the whole debt
+    //   the swap router,  // the aggregator contract performing the swap
+    //   the swap spender,  // the contract the aggregator needs approved for the collateral
+    //   swapCalldata  // calldata obtained from the aggregator API
     // )
     bytes packedParams;
     bytes packedParamsSignature;
   }
 
-  constructor() public {
-    ADDRESSES_PROVIDER = ILendingPoolAddressesProvider(ADDRESSES_PROVIDER_ADDRESS);
-    LENDING_POOL = ILendingPool(LENDING_POOL_ADDRESS);
+  modifier onlyOwner() {
+    require(msg.sender == owner, "caller is not the owner");
+    _;
+  }
+
+  // _provider is the addresses provider of the market the bot protects loans in. The version and
+  // salt must match the domain the bot issues delegation certificates in. The domain includes this
+  // contract, so certificates don't verify in other deployments.
+  constructor(ILendingPoolAddressesProvider _provider, string memory _version, string memory _salt) {
+    owner = msg.sender;
+    ADDRESSES_PROVIDER = _provider;
+    LENDING_POOL = ILendingPool(_provider.getLendingPool());
 
     uint256 cId;
     assembly {
@@ -58,25 +88,63 @@ contract RepaymentExecutor is IFlashLoanReceiver {
     }
     DOMAIN_SEPARATOR = hash(EIP712Domain({
         name: "AAVE Liquidation Protection Bot",
-        version: '1',
+        version: _version,
         chainId: cId,
-        salt: "SU%N6gmumvj.A{@B,SdWXtVgg(Bof9SA"
+        verifyingContract: address(this),
+        salt: _salt
     }));
   }
 
+  /**
+   * @dev Allows or disallows swapping collateral through the given aggregator routers.
+   */
+  function setRouters(address[] calldata _routers, bool _allowed) external onlyOwner {
+    for (uint i = 0; i < _routers.length; i++) {
+      routers[_routers[i]] = _allowed;
+      emit RouterAllowed(_routers[i], _allowed);
+    }
+  }
+
+  /**
+   * @dev Allows or disallows approving the given aggregator contracts for the collateral.
+   */
+  function setSpenders(address[] calldata _spenders, bool _allowed) external onlyOwner {
+    for (uint i = 0; i < _spenders.length; i++) {
+      spenders[_spenders[i]] = _allowed;
+      emit SpenderAllowed(_spenders[i], _allowed);
+    }
+  }
+
+  /**
+   * @dev Transfers the management of the allowed aggregator contracts.
+   */
+  function transferOwnership(address _owner) external onlyOwner {
+    require(_owner != address(0), "owner is the zero address");
+    owner = _owner;
+  }
+
+  /**
+   * @dev Revokes the caller's delegations with nonces up to and including _nonce.
+   */
+  function revoke(uint256 _nonce) external {
+    require(_nonce >= minNonce[msg.sender], "nonce already revoked");
+    minNonce[msg.sender] = _nonce + 1;
+    emit Revoked(msg.sender, _nonce);
+  }
+
   /**
    * @dev Repays a loan using a flash loan, then repays the flash loan by redeeming the collateral
-   *   and converting it to the loan asset type using 1inch.
+   *   and converting it to the loan asset type through a DEX aggregator.
    * @param _user the account owner
-   * @param _botDelegationSignature signature of the bot delegation message
+   * @param _botDelegation the terms and signature of the bot delegation message. See FlashParams.
    * @param _sDebtToken variable debt token
    * @param _vDebtToken stable debt token
    * @param _dAsset the underyling debt asset
-   * @param _packedParams contains encoded parameters used only after the flash loan callback. See
-   *     the FlashParams for a description of its contents.
+   * @param _packedParams contains encoded parameters used only after the flash loan callback, except
+   *     for the debt amount. See the FlashParams for a description of its contents.
    * @param _packedParamsSignature the bot's signature on _packedParams
    */
-  function execute(address _user, bytes memory _botDelegationSignature, address _sDebtToken,
+  function execute(address _user, bytes memory _botDelegation, address _sDebtToken,
       address _vDebtToken, address _dAsset, bytes memory _packedParams,
       bytes memory _packedParamsSignature) public {
     uint debtAmount;
@@ -85,9 +153,12 @@ contract RepaymentExecutor is IFlashLoanReceiver {
       uint sAmount = IERC20(_sDebtToken).balanceOf(_user);
       uint vAmount = IERC20(_vDebtToken).balanceOf(_user);
       require(sAmount + vAmount > 0, "debt not found");
-      debtAmount = sAmount + vAmount;
+      (, , , , uint dAmount, , , ) = abi.decode(_packedParams,
+          (address, address, uint, address, uint, address, address, bytes));
+      require(dAmount <= sAmount + vAmount, "debt amount exceeds debt");
+      debtAmount = dAmount > 0 ? dAmount : sAmount + vAmount;
       params = abi.encode(FlashParams(
-        _user, msg.sender, _botDelegationSignature, _packedParams, _packedParamsSignature));
+        _user, msg.sender, _botDelegation, _packedParams, _packedParamsSignature));
     }
 
     address[] memory assets = new address[](1);
@@ -122,10 +193,15 @@ contract RepaymentExecutor is IFlashLoanReceiver {
     {
       // Repays the debts.
       IERC20 debtAsset = IERC20(_assets[0]);
-      require(debtAsset.approve(LENDING_POOL_ADDRESS, _amounts[0]),
+      require(debtAsset.approve(address(LENDING_POOL), _amounts[0]),
           'failed to approve the lending pool');
       (uint sAmount, uint vAmount) = debtAmounts(fp.user, _assets[0]);
-      require(sAmount + vAmount == _amounts[0], "loan amount did not match debt");
+      require(sAmount + vAmount >= _amounts[0], "loan amount exceeds debt");
+      // Partial repayments pay off stable debt first since it usually carries the higher rate.
+      if (sAmount > _amounts[0]) {
+        sAmount = _amounts[0];
+      }
+      vAmount = _amounts[0] - sAmount;
       if (sAmount > 0) {
         LENDING_POOL.repay(_assets[0], sAmount, 1, fp.user);
       }
@@ -133,61 +209,104 @@ contract RepaymentExecutor is IFlashLoanReceiver {
         LENDING_POOL.repay(_assets[0], vAmount, 2, fp.user);
       }
     }
-    (address aToken, address cAsset, uint cAmount, address dAsset, bytes memory oneInchCalldata)
-        = abi.decode(fp.packedParams, (address, address, uint, address, bytes));
-    require(dAsset == _assets[0], "debt asset didn't match");
     uint flashLoanDebt = _amounts[0] + _premiums[0];
+    uint proceeds = swapCollateral(fp, _assets[0], flashLoanDebt);
 
-    // Withdraws ATokens to the underlying asset.
-    // Temporarily transfers ATokens into this contract.
-    require(IERC20(aToken).transferFrom(fp.user, address(this), cAmount),
-        'collateral transfer failed');
-    // Withdraws the underyling asset (transforming the transferred ATokens).
-    require(cAmount == LENDING_POOL.withdraw(cAsset, cAmount, address(this)),
-        "withdrew less than the expected amount");
-
-    // Swaps collateral to debt using 1inch.
-    uint proceeds = oneInchSwap(cAsset, cAmount, oneInchCalldata);
-
-    IERC20 debtAsset = IERC20(dAsset);
+    IERC20 debtAsset = IERC20(_assets[0]);
     // Distributes the proceeds.
     // Returns anything remaining back to the user.
     require(debtAsset.transfer(fp.user, proceeds - flashLoanDebt),
         "transferring remainder to user failed");
     // Approves the lending pool to take payment.
-    require(debtAsset.approve(LENDING_POOL_ADDRESS, flashLoanDebt),
+    require(debtAsset.approve(address(LENDING_POOL), flashLoanDebt),
         'failed to approve flash loan repayment');
 
     return true;
   }
 
-  function oneInchSwap(address _cAsset, uint _cAmount, bytes memory _oneInchSwapCalldata)
+  // Withdraws the user's collateral and swaps it to the debt asset through the aggregator chosen by
+  // the bot, which must be allowed by the owner. Returns the debt asset received, which must cover
+  // `_owed`.
+  function swapCollateral(FlashParams memory fp, address _debtAsset, uint _owed)
       private returns (uint) {
-    IERC20(_cAsset).approve(ONE_INCH, _cAmount);  // Grants 1inch approval to make the swap.
-    (bool s, bytes memory v) = ONE_INCH.call(_oneInchSwapCalldata);  // Performs the swap.
-    require(s, "1inch swap failed");
-    return abi.decode(v, (uint));
+    (address aToken, address cAsset, uint cAmount, address dAsset, , address router, address spender,
+        bytes memory swapCalldata) = abi.decode(fp.packedParams,
+            (address, address, uint, address, uint, address, address, bytes));
+    require(dAsset == _debtAsset, "debt asset didn't match");
+    require(routers[router], "swap router not allowed");
+    require(spenders[spender], "swap spender not allowed");
+
+    // Withdraws ATokens to the underlying asset.
+    // Temporarily transfers ATokens into this contract.
+    require(IERC20(aToken).transferFrom(fp.user, address(this), cAmount),
+        'collateral transfer failed');
+    // Withdraws the underyling asset (transforming the transferred ATokens).
+    require(cAmount == LENDING_POOL.withdraw(cAsset, cAmount, address(this)),
+        "withdrew less than the expected amount");
+
+    // Swaps collateral to debt. Aggregators return the proceeds differently, so the balance is
+    // read instead.
+    uint before = IERC20(dAsset).balanceOf(address(this));
+    require(IERC20(cAsset).approve(spender, cAmount), "failed to approve the swap");
+    (bool s, ) = router.call(swapCalldata);
+    require(s, "swap failed");
+    // Leaves no allowance behind in case the router didn't use all of it.
+    require(IERC20(cAsset).approve(spender, 0), "failed to reset the swap approval");
+    uint received = IERC20(dAsset).balanceOf(address(this)) - before;
+    require(received >= _owed, "swap proceeds don't cover the flash loan");
+    return received;
   }
 
   function verifySignatures(FlashParams memory fp) private view {
     // Verifies that the user has trusted the bot.
-    verifyBotDelegationSignature(fp.user, fp.bot, fp.botDelegationSignature);
+    uint maxCollateral = verifyBotDelegation(fp.user, fp.bot, fp.botDelegation);
     // Verifies that the bot produced packed parameters.
     verifyPackedParams(fp);
+    // Checked here rather than in execute since the callback can be reached without it.
+    if (maxCollateral > 0) {
+      (, address cAsset, uint cAmount, , , , , ) = abi.decode(fp.packedParams,
+          (address, address, uint, address, uint, address, address, bytes));
+      require(collateralValue(cAsset, cAmount) <= maxCollateral,
+          "collateral exceeds the delegation limit");
+    }
   }
 
-  function verifyBotDelegationSignature(address _user, address _bot, bytes memory _signature)
-      private view {
+  // Verifies the user's delegation to the bot and returns its collateral value limit.
+  function verifyBotDelegation(address _user, address _bot, bytes memory _delegation)
+      private view returns (uint) {
+    (uint deadline, uint nonce, uint maxCollateral, bytes memory signature)
+        = abi.decode(_delegation, (uint, uint, uint, bytes));
+    require(block.timestamp <= deadline, "delegation expired");
+    require(nonce >= minNonce[_user], "delegation revoked");
     bytes32 digest = keccak256(abi.encodePacked(
         "\x19\x01",
         DOMAIN_SEPARATOR,
-        hash(Delegate({ delegate: _bot }))
+        hash(Delegate({
+            delegate: _bot,
+            deadline: deadline,
+            nonce: nonce,
+            maxCollateral: maxCollateral
+        }))
     ));
-    require(recoverSigner(digest, _signature) == _user, "signer did not match");
+    require(recoverSigner(digest, signature) == _user, "signer did not match");
+    return maxCollateral;
+  }
+
+  // Returns the value in wei of _amount of _asset at the AAVE oracle price.
+  function collateralValue(address _asset, uint _amount) private view returns (uint) {
+    // The pool's provider is the one the bot reads prices through.
+    address oracle = LENDING_POOL.getAddressesProvider().getPriceOracle();
+    uint price = IPriceOracleGetter(oracle).getAssetPrice(_asset);
+    // Bits 48-55 of the reserve configuration are the asset's decimals.
+    uint decimals = (LENDING_POOL.getReserveData(_asset).configuration.data >> 48) & 0xFF;
+    return _amount * price / 10 ** decimals;
   }
 
+  // The bot signs the hash of the packed parameters as an EIP-191 personal message, which is what
+  // external signers such as Clef agree to sign.
   function verifyPackedParams(FlashParams memory fp) private pure {
-    bytes32 digest = keccak256(fp.packedParams);
+    bytes32 digest = keccak256(abi.encodePacked(
+        "\x19Ethereum Signed Message:\n32", keccak256(fp.packedParams)));
     require(recoverSigner(digest, fp.packedParamsSignature) == fp.bot,
         "packed parameters not signed by bot");
   }
@@ -220,12 +339,19 @@ contract RepaymentExecutor is IFlashLoanReceiver {
         keccak256(bytes(eip712Domain.name)),
         keccak256(bytes(eip712Domain.version)),
         eip712Domain.chainId,
+        eip712Domain.verifyingContract,
         keccak256(bytes(eip712Domain.salt))
     ));
   }
 
   function hash(Delegate memory delegate) private pure returns (bytes32) {
-    return keccak256(abi.encode(DELEGATE_TYPEHASH, delegate.delegate));
+    return keccak256(abi.encode(
+        DELEGATE_TYPEHASH,
+        delegate.delegate,
+        delegate.deadline,
+        delegate.nonce,
+        delegate.maxCollateral
+    ));
   }
 
 
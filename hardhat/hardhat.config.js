@@ -5,7 +5,16 @@ require("@nomiclabs/hardhat-web3");
  * @type import('hardhat/config').HardhatUserConfig
  */
 module.exports = {
-  solidity: "0.6.12",
+  solidity: {
+    version: "0.8.21",
+    settings: {
+      // London keeps the bytecode free of PUSH0, which go-ethereum's simulated backend predates.
+      evmVersion: "london",
+      // Leaves the source hashes out of the bytecode so build/ doesn't depend on the installed
+      // node_modules.
+      metadata: { bytecodeHash: "none" },
+    },
+  },
   networks: {
     hardhat: {
       forking: {
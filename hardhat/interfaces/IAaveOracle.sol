@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: agpl-3.0
+pragma solidity 0.6.12;
+
+import {IPriceOracleGetter} from './IPriceOracleGetter.sol';
+
+/**
+ * @title IAaveOracle interface
+ * @notice Interface for the Aave price oracle, which proxies Chainlink aggregators and falls back to
+ * a fallback oracle for assets without a source.
+ **/
+interface IAaveOracle is IPriceOracleGetter {
+  /// @notice Gets a list of prices from a list of assets addresses
+  function getAssetsPrices(address[] calldata assets) external view returns (uint256[] memory);
+
+  /// @notice Gets the address of the source for an asset address
+  function getSourceOfAsset(address asset) external view returns (address);
+
+  /// @notice Gets the address of the fallback oracle
+  function getFallbackOracle() external view returns (address);
+}
@@ -1,5 +1,5 @@
 // SPDX-License-Identifier: agpl-3.0
-pragma solidity 0.6.12;
+pragma solidity >=0.6.12 <0.9.0;
 
 /**
  * @title LendingPoolAddressesProvider contract
@@ -170,7 +170,7 @@ class ProtectionWidget extends React.Component {
           = '<p style="color:red;text-align: center;">Approval failed. Check console logs.</p>';
     }
 
-    let typedData = await getJSON(API.concat('cert'));
+    let typedData = await getJSON(API.concat('cert?address=').concat(account));
     let signature = await provider.request({
       method: 'eth_signTypedData_v4',
       params: [account, JSON.stringify(typedData)],
@@ -180,15 +180,23 @@ class ProtectionWidget extends React.Component {
       "user": account,
       "signature": signature,
       "threshold": value,
+      // The terms of the signed certificate.
+      "deadline": typedData.message.deadline,
+      "nonce": typedData.message.nonce,
+      "max-collateral": typedData.message.maxCollateral,
     };
     let resp = await fetch(API.concat('register'), {
       method: "POST",
       body: JSON.stringify(postContent),
     });
     if (!resp.ok) {
-      console.log("resp=", resp);
+      let body = await resp.json().catch(() => ({}));
+      console.log("resp=", resp, body);
+      let message = body.error ? body.error.message : 'Check console logs.';
       document.getElementById('status-placeholder').innerHTML
-          = '<p style="color:red;text-align: center;">Signing failed. Check console logs.</p>';
+          = '<p style="color:red;text-align: center;">Registration failed: </p>';
+      document.getElementById('status-placeholder').firstChild.append(message);
+      return;
     }
     document.getElementById('status-placeholder').innerHTML =
         '<div style="color:green;text-align: center;">Registration succeeded.</div>';